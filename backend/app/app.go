@@ -3,10 +3,14 @@ package gameapp
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 
 	gameconfig "github.com/Akif-jpg/MyHobieMMORPGGame/config"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/maploader"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -20,7 +24,11 @@ const (
 type GameApp struct {
 	App        *fiber.App
 	GameConfig *gameconfig.GameConfig
-	initOnce   sync.Once // Init'in bir kez çalışmasını garantiler
+	World      *engine.World
+	Entities   *maploader.EntityRegistry // Factories for the map's entity instances
+	streamer   *maploader.LevelStreamer
+	reloader   *maploader.HotReloader // Only set in DEVELOPER mode
+	initOnce   sync.Once              // Init'in bir kez çalışmasını garantiler
 }
 
 func New() *GameApp {
@@ -46,13 +54,59 @@ func (g *GameApp) Init(ree RuntimeEnvEnum) {
 			return c.SendString("OK")
 		})
 
+		g.World = engine.NewWorld(engine.WorldConfig{})
+		g.Entities = maploader.NewEntityRegistry()
+		g.initMap(ree)
+
 		fmt.Println("Port:", g.GameConfig.Port)
 	})
 }
 
+// initMap loads the configured LDtk map and streams it into the world. In
+// DEVELOPER mode the map directory is watched, so levels saved in LDtk are
+// swapped into the running world.
+func (g *GameApp) initMap(ree RuntimeEnvEnum) {
+	path := filepath.Join(g.GameConfig.Map.Dir, g.GameConfig.Map.File)
+	if _, err := os.Stat(path); err != nil {
+		fmt.Printf("Warning: no map at %s, the world starts empty\n", path)
+		return
+	}
+	project, err := maploader.Load(path)
+	if err != nil {
+		fmt.Printf("Warning: %v, the world starts empty\n", err)
+		return
+	}
+
+	g.streamer = maploader.NewLevelStreamer(project, g.Entities, maploader.StreamingConfig{})
+	g.World.SetLevelStreamer(g.streamer)
+	fmt.Println("Map loaded:", path)
+
+	if ree == DEVELOPER {
+		g.reloader = maploader.NewHotReloader(path, g.streamer, maploader.DefaultWatchInterval)
+		g.reloader.OnReload(func(changed []string, err error) {
+			if err != nil {
+				fmt.Printf("Warning: map hot reload failed: %v\n", err)
+				return
+			}
+			fmt.Printf("Map reloaded after changes to %v\n", changed)
+		})
+	}
+}
+
 func (g *GameApp) Start() error {
 	if g.App == nil || g.GameConfig == nil {
 		return fmt.Errorf("GameApp is not initialized, call Init() first")
 	}
+	if err := g.World.Start(); err != nil {
+		return err
+	}
+	defer g.World.Stop()
+	if g.reloader != nil {
+		if err := g.reloader.Start(); err != nil {
+			fmt.Printf("Warning: map hot reload disabled: %v\n", err)
+		} else {
+			defer g.reloader.Stop()
+		}
+	}
 	return g.App.Listen(":" + strconv.Itoa(g.GameConfig.Port))
 }
@@ -17,6 +17,7 @@ type GameConfig struct {
 	JWT      JWTConfig
 	Email    EmailConfig
 	Log      LogConfig
+	Map      MapConfig `yaml:",inline"`
 }
 
 // DatabaseConfig holds PostgreSQL connection settings.
@@ -48,6 +49,12 @@ type LogConfig struct {
 	Format string `yaml:"LOG_FORMAT"`
 }
 
+// MapConfig holds the location of the LDtk world map.
+type MapConfig struct {
+	Dir  string `yaml:"MAP_DIR"`  // Directory holding the project and its external levels
+	File string `yaml:"MAP_FILE"` // Project file name inside Dir
+}
+
 type GameConfigTypeEnum int
 
 const (
@@ -87,6 +94,11 @@ func (gc *GameConfig) InitGameConfigWithDefaults() {
 		Format: "json",
 	}
 
+	gc.Map = MapConfig{
+		Dir:  "maps",
+		File: "world.ldtk",
+	}
+
 	fmt.Printf("%+v\n", gc)
 }
 
@@ -181,5 +193,13 @@ func (gc *GameConfig) GetConfigWithEnvVars(gcte GameConfigTypeEnum) *GameConfig
 		gc.Log.Format = v
 	}
 
+	// Map
+	if v := os.Getenv("MAP_DIR"); v != "" {
+		gc.Map.Dir = v
+	}
+	if v := os.Getenv("MAP_FILE"); v != "" {
+		gc.Map.File = v
+	}
+
 	return gc
 }
@@ -0,0 +1,282 @@
+package behaviour
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// --- Test Helpers ---
+
+// scripted is a leaf returning its statuses in order, then the last one
+// forever, and counting ticks and resets.
+type scripted struct {
+	statuses []Status
+	ticks    int
+	resets   int
+}
+
+func (s *scripted) Tick(ctx *Context) Status {
+	status := s.statuses[min(s.ticks, len(s.statuses)-1)]
+	s.ticks++
+	return status
+}
+
+func (s *scripted) Reset() {
+	s.resets++
+}
+
+func leaf(statuses ...Status) *scripted {
+	return &scripted{statuses: statuses}
+}
+
+// bossYAML has two phases: above half health the boss swipes every second;
+// below it, it slams its left arm while it has one, with a cooldown, and
+// otherwise bites.
+const bossYAML = `
+type: selector
+children:
+  - type: sequence
+    children:
+      - {type: condition, name: value_below, params: {key: health, value: 50}}
+      - type: selector
+        children:
+          - type: sequence
+            children:
+              - {type: action, name: target_part, params: {part: left_arm}}
+              - {type: cooldown, seconds: 3, child: {type: action, name: slam}}
+          - {type: action, name: bite}
+  - type: sequence
+    children:
+      - {type: wait, seconds: 1}
+      - {type: action, name: swipe}
+`
+
+// --- Tests ---
+
+func TestTree_BossPhases(t *testing.T) {
+	def, err := ParseYAML([]byte(bossYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+
+	log := make([]string, 0)
+	registry := NewRegistry()
+	for _, name := range []string{"slam", "bite", "swipe"} {
+		name := name
+		registry.RegisterAction(name, func(ctx *Context, params Params) Status {
+			part, _ := ctx.Blackboard.String("target_part")
+			log = append(log, fmt.Sprintf("%.1f:%s%s", ctx.Time, name, part))
+			return Success
+		})
+	}
+
+	tree, err := registry.Build(def)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	body := collider.NewCompositeCollider(geometry.Vector2{}, 0)
+	head := geometry.NewCircle(geometry.Point{}, 8)
+	arm := geometry.NewCircle(geometry.Point{}, 4)
+	body.AddBodyPart("head", &head, geometry.Vector2{}, 0)
+	body.AddBodyPart("left_arm", &arm, geometry.Vector2{X: -12}, 0)
+	tree.Body = body
+	tree.Blackboard.Set("health", 100)
+
+	for i := 0; i < 4; i++ {
+		tree.Tick(0.5)
+	}
+	tree.Blackboard.Set("health", 40)
+	for i := 0; i < 8; i++ {
+		tree.Tick(0.5)
+	}
+	body.RemoveBodyPart("left_arm")
+	tree.Blackboard.Delete("target_part")
+	tree.Tick(0.5)
+
+	expected := "[1.0:swipe 2.0:swipe 2.5:slamleft_arm 3.0:biteleft_arm 3.5:biteleft_arm 4.0:biteleft_arm " +
+		"4.5:biteleft_arm 5.0:biteleft_arm 5.5:slamleft_arm 6.0:biteleft_arm 6.5:bite]"
+	if got := fmt.Sprint(log); got != expected {
+		t.Errorf("actions ran %s, expected %s", got, expected)
+	}
+}
+
+func TestComposites(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []*scripted
+		build    func(children []Node) Node
+		expected []Status
+		resets   []int // resets of each child after the ticks
+	}{
+		{
+			name:     "sequence resumes the running child",
+			children: []*scripted{leaf(Success), leaf(Running, Success), leaf(Success)},
+			build:    func(c []Node) Node { return &Sequence{Children: c} },
+			expected: []Status{Running, Success},
+			resets:   []int{0, 0, 0},
+		},
+		{
+			name:     "sequence fails on the first failure",
+			children: []*scripted{leaf(Success), leaf(Failure), leaf(Success)},
+			build:    func(c []Node) Node { return &Sequence{Children: c} },
+			expected: []Status{Failure},
+			resets:   []int{1, 1, 1},
+		},
+		{
+			name:     "selector interrupts a lower priority child",
+			children: []*scripted{leaf(Failure, Failure, Running), leaf(Running)},
+			build:    func(c []Node) Node { return &Selector{Children: c} },
+			expected: []Status{Running, Running, Running},
+			resets:   []int{0, 1},
+		},
+		{
+			name:     "selector fails when every child fails",
+			children: []*scripted{leaf(Failure), leaf(Failure)},
+			build:    func(c []Node) Node { return &Selector{Children: c} },
+			expected: []Status{Failure},
+			resets:   []int{0, 0},
+		},
+		{
+			name:     "parallel needs every child by default",
+			children: []*scripted{leaf(Success), leaf(Running, Running, Success)},
+			build:    func(c []Node) Node { return &Parallel{Children: c} },
+			expected: []Status{Running, Running, Success},
+			resets:   []int{1, 1},
+		},
+		{
+			name:     "parallel with a success count",
+			children: []*scripted{leaf(Running, Success), leaf(Running), leaf(Failure)},
+			build:    func(c []Node) Node { return &Parallel{Children: c, SuccessCount: 1} },
+			expected: []Status{Running, Success},
+			resets:   []int{1, 1, 1},
+		},
+		{
+			name:     "parallel fails when the count is out of reach",
+			children: []*scripted{leaf(Failure), leaf(Running, Failure), leaf(Running)},
+			build:    func(c []Node) Node { return &Parallel{Children: c, SuccessCount: 2} },
+			expected: []Status{Running, Failure},
+			resets:   []int{1, 1, 1},
+		},
+		{
+			name:     "invert",
+			children: []*scripted{leaf(Success, Running, Failure)},
+			build:    func(c []Node) Node { return &Invert{Child: c[0]} },
+			expected: []Status{Failure, Running, Success},
+			resets:   []int{0},
+		},
+		{
+			name:     "force success",
+			children: []*scripted{leaf(Failure, Running)},
+			build:    func(c []Node) Node { return &Force{Child: c[0], Status: Success} },
+			expected: []Status{Success, Running},
+			resets:   []int{0},
+		},
+		{
+			name:     "repeat runs the child count times",
+			children: []*scripted{leaf(Success, Running, Success, Success)},
+			build:    func(c []Node) Node { return &Repeat{Child: c[0], Count: 3} },
+			expected: []Status{Running, Running, Running, Success},
+			resets:   []int{3},
+		},
+		{
+			name:     "repeat fails with the child",
+			children: []*scripted{leaf(Success, Failure)},
+			build:    func(c []Node) Node { return &Repeat{Child: c[0]} },
+			expected: []Status{Running, Failure},
+			resets:   []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := make([]Node, len(tt.children))
+			for i, child := range tt.children {
+				nodes[i] = child
+			}
+			root := tt.build(nodes)
+			ctx := &Context{Blackboard: NewBlackboard()}
+			for i, expected := range tt.expected {
+				if got := root.Tick(ctx); got != expected {
+					t.Fatalf("tick %d: got %s, expected %s", i, got, expected)
+				}
+			}
+			for i, child := range tt.children {
+				if child.resets != tt.resets[i] {
+					t.Errorf("child %d: got %d resets, expected %d", i, child.resets, tt.resets[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	child := leaf(Running, Success)
+	cooldown := &Cooldown{Child: child, Seconds: 2.5}
+	ctx := &Context{}
+
+	expected := []Status{Running, Success, Failure, Failure, Success}
+	for i, status := range expected {
+		ctx.Time = float64(i)
+		if got := cooldown.Tick(ctx); got != status {
+			t.Fatalf("t=%d: got %s, expected %s", i, got, status)
+		}
+	}
+	if child.ticks != 3 {
+		t.Errorf("child ticked %d times, expected 3", child.ticks)
+	}
+
+	// Aborting the subtree does not end the cooldown.
+	cooldown.Reset()
+	ctx.Time = 5
+	if got := cooldown.Tick(ctx); got != Failure {
+		t.Errorf("after Reset: got %s, expected failure", got)
+	}
+}
+
+func TestBuild_JSONAndErrors(t *testing.T) {
+	def, err := ParseJSON([]byte(`{"type": "sequence", "children": [
+		{"type": "action", "name": "set", "params": {"key": "phase", "value": 2}},
+		{"type": "condition", "name": "value_above", "params": {"key": "phase", "value": 1}}]}`))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	tree, err := NewRegistry().Build(def)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if got := tree.Tick(0.1); got != Success {
+		t.Errorf("Tick() = %s, expected success", got)
+	}
+
+	tests := []struct {
+		name     string
+		yaml     string
+		expected string
+	}{
+		{"unknown type", "type: loop", `root: unknown node type "loop"`},
+		{"empty composite", "type: selector", "root: selector needs children"},
+		{"decorator without child", "type: invert", "root: invert needs a child"},
+		{"cooldown without seconds", "{type: cooldown, child: {type: wait}}", "root: cooldown needs seconds"},
+		{"unknown action", "{type: sequence, children: [{type: wait}, {type: repeat, child: {type: action, name: slam}}]}",
+			`root.children[1].child: unknown action "slam"`},
+		{"unknown condition", "{type: selector, children: [{type: condition, name: enraged}]}",
+			`root.children[0]: unknown condition "enraged"`},
+		{"success count", "{type: parallel, success_count: 3, children: [{type: wait}]}", "root: success_count 3 out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := ParseYAML([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("ParseYAML() error = %v", err)
+			}
+			_, err = NewRegistry().Build(def)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("got error %v, expected %q", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package behaviour
+
+import "sort"
+
+// Blackboard is the memory one entity's tree shares between its nodes, e.g.
+// the body part a boss is aiming at or the health it has left.
+type Blackboard struct {
+	values map[string]any
+}
+
+// NewBlackboard creates an empty blackboard.
+func NewBlackboard() *Blackboard {
+	return &Blackboard{values: make(map[string]any)}
+}
+
+// Get returns the value stored under key.
+func (b *Blackboard) Get(key string) (any, bool) {
+	value, exists := b.values[key]
+	return value, exists
+}
+
+// Set stores value under key.
+func (b *Blackboard) Set(key string, value any) {
+	b.values[key] = value
+}
+
+// Delete removes key.
+func (b *Blackboard) Delete(key string) {
+	delete(b.values, key)
+}
+
+// Has reports whether key is set.
+func (b *Blackboard) Has(key string) bool {
+	_, exists := b.values[key]
+	return exists
+}
+
+// Float returns the number stored under key. Any integer or float type is
+// accepted, since JSON and YAML decode numbers differently.
+func (b *Blackboard) Float(key string) (float64, bool) {
+	return toFloat(b.values[key])
+}
+
+// String returns the string stored under key.
+func (b *Blackboard) String(key string) (string, bool) {
+	s, ok := b.values[key].(string)
+	return s, ok
+}
+
+// Bool returns the bool stored under key.
+func (b *Blackboard) Bool(key string) (bool, bool) {
+	v, ok := b.values[key].(bool)
+	return v, ok
+}
+
+// Keys returns the keys set, sorted.
+func (b *Blackboard) Keys() []string {
+	keys := make([]string, 0, len(b.values))
+	for key := range b.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,275 @@
+package behaviour
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Node types of a definition.
+const (
+	TypeSequence  = "sequence"
+	TypeSelector  = "selector"
+	TypeParallel  = "parallel"
+	TypeInvert    = "invert"
+	TypeSucceed   = "succeed"
+	TypeFail      = "fail"
+	TypeRepeat    = "repeat"
+	TypeCooldown  = "cooldown"
+	TypeWait      = "wait"
+	TypeAction    = "action"
+	TypeCondition = "condition"
+)
+
+// NodeDef is the authored form of a node. A boss phase written in YAML
+// looks like
+//
+//	type: selector
+//	children:
+//	  - type: sequence
+//	    children:
+//	      - {type: condition, name: value_below, params: {key: health, value: 50}}
+//	      - {type: action, name: target_part, params: {part: left_arm}}
+//	      - {type: cooldown, seconds: 4, child: {type: action, name: slam}}
+//	  - {type: action, name: patrol}
+type NodeDef struct {
+	Type         string    `json:"type" yaml:"type"`
+	Name         string    `json:"name,omitempty" yaml:"name,omitempty"`     // Action or condition name
+	Params       Params    `json:"params,omitempty" yaml:"params,omitempty"` // Action or condition params
+	Children     []NodeDef `json:"children,omitempty" yaml:"children,omitempty"`
+	Child        *NodeDef  `json:"child,omitempty" yaml:"child,omitempty"`
+	Seconds      float64   `json:"seconds,omitempty" yaml:"seconds,omitempty"`             // Cooldown and wait duration
+	Count        int       `json:"count,omitempty" yaml:"count,omitempty"`                 // Repeat count, 0 repeats forever
+	SuccessCount int       `json:"success_count,omitempty" yaml:"success_count,omitempty"` // Parallel successes needed, 0 for all
+}
+
+// ParseJSON decodes a tree definition from JSON.
+func ParseJSON(data []byte) (*NodeDef, error) {
+	var def NodeDef
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("invalid behaviour tree: %w", err)
+	}
+	return &def, nil
+}
+
+// ParseYAML decodes a tree definition from YAML.
+func ParseYAML(data []byte) (*NodeDef, error) {
+	var def NodeDef
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("invalid behaviour tree: %w", err)
+	}
+	return &def, nil
+}
+
+// Params are the parameters of an action or condition node.
+type Params map[string]any
+
+// Float returns the number param key.
+func (p Params) Float(key string) (float64, bool) {
+	return toFloat(p[key])
+}
+
+// String returns the string param key.
+func (p Params) String(key string) (string, bool) {
+	s, ok := p[key].(string)
+	return s, ok
+}
+
+// ---------------------------------------------------------------------------
+// Registry
+// ---------------------------------------------------------------------------
+
+// Registry maps the action and condition names used in definitions to code.
+type Registry struct {
+	actions    map[string]ActionFunc
+	conditions map[string]ConditionFunc
+}
+
+// NewRegistry creates a registry holding the built-in actions and
+// conditions:
+//
+//	action set          params key, value: stores value on the blackboard
+//	action clear        params key: removes key from the blackboard
+//	action target_part  params part, key: stores the name of an existing body
+//	                    part under key (target_part by default)
+//	condition is_set       params key
+//	condition value_below  params key, value
+//	condition value_above  params key, value
+//	condition has_part     params part: the owner's body still has the part
+func NewRegistry() *Registry {
+	r := &Registry{
+		actions:    make(map[string]ActionFunc),
+		conditions: make(map[string]ConditionFunc),
+	}
+	r.RegisterAction("set", actionSet)
+	r.RegisterAction("clear", actionClear)
+	r.RegisterAction("target_part", actionTargetPart)
+	r.RegisterCondition("is_set", conditionIsSet)
+	r.RegisterCondition("value_below", conditionCompare(func(a, b float64) bool { return a < b }))
+	r.RegisterCondition("value_above", conditionCompare(func(a, b float64) bool { return a > b }))
+	r.RegisterCondition("has_part", conditionHasPart)
+	return r
+}
+
+// RegisterAction adds or replaces an action.
+func (r *Registry) RegisterAction(name string, action ActionFunc) {
+	r.actions[name] = action
+}
+
+// RegisterCondition adds or replaces a condition.
+func (r *Registry) RegisterCondition(name string, condition ConditionFunc) {
+	r.conditions[name] = condition
+}
+
+// Build creates a tree instance from def. Every entity needs its own
+// instance, as nodes hold the state of running subtrees. Errors name the
+// path of the offending node, e.g. "root.children[1].child".
+func (r *Registry) Build(def *NodeDef) (*Tree, error) {
+	root, err := r.build(def, "root")
+	if err != nil {
+		return nil, err
+	}
+	return NewTree(root), nil
+}
+
+func (r *Registry) build(def *NodeDef, path string) (Node, error) {
+	switch def.Type {
+	case TypeSequence, TypeSelector, TypeParallel:
+		if len(def.Children) == 0 {
+			return nil, fmt.Errorf("%s: %s needs children", path, def.Type)
+		}
+		children := make([]Node, len(def.Children))
+		for i := range def.Children {
+			child, err := r.build(&def.Children[i], fmt.Sprintf("%s.children[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			children[i] = child
+		}
+		switch def.Type {
+		case TypeSequence:
+			return &Sequence{Children: children}, nil
+		case TypeSelector:
+			return &Selector{Children: children}, nil
+		}
+		if def.SuccessCount < 0 || def.SuccessCount > len(children) {
+			return nil, fmt.Errorf("%s: success_count %d out of range", path, def.SuccessCount)
+		}
+		return &Parallel{Children: children, SuccessCount: def.SuccessCount}, nil
+
+	case TypeInvert, TypeSucceed, TypeFail, TypeRepeat, TypeCooldown:
+		if def.Child == nil {
+			return nil, fmt.Errorf("%s: %s needs a child", path, def.Type)
+		}
+		child, err := r.build(def.Child, path+".child")
+		if err != nil {
+			return nil, err
+		}
+		switch def.Type {
+		case TypeInvert:
+			return &Invert{Child: child}, nil
+		case TypeSucceed:
+			return &Force{Child: child, Status: Success}, nil
+		case TypeFail:
+			return &Force{Child: child, Status: Failure}, nil
+		case TypeRepeat:
+			if def.Count < 0 {
+				return nil, fmt.Errorf("%s: negative repeat count", path)
+			}
+			return &Repeat{Child: child, Count: def.Count}, nil
+		}
+		if def.Seconds <= 0 {
+			return nil, fmt.Errorf("%s: cooldown needs seconds", path)
+		}
+		return &Cooldown{Child: child, Seconds: def.Seconds}, nil
+
+	case TypeWait:
+		if def.Seconds < 0 {
+			return nil, fmt.Errorf("%s: negative wait", path)
+		}
+		return &Wait{Seconds: def.Seconds}, nil
+
+	case TypeAction:
+		action, exists := r.actions[def.Name]
+		if !exists {
+			return nil, fmt.Errorf("%s: unknown action %q", path, def.Name)
+		}
+		return &Action{Name: def.Name, Params: def.Params, Func: action}, nil
+
+	case TypeCondition:
+		condition, exists := r.conditions[def.Name]
+		if !exists {
+			return nil, fmt.Errorf("%s: unknown condition %q", path, def.Name)
+		}
+		return &Condition{Name: def.Name, Params: def.Params, Func: condition}, nil
+	}
+	return nil, fmt.Errorf("%s: unknown node type %q", path, def.Type)
+}
+
+// ---------------------------------------------------------------------------
+// Built-ins
+// ---------------------------------------------------------------------------
+
+func actionSet(ctx *Context, params Params) Status {
+	key, ok := params.String("key")
+	if !ok {
+		return Failure
+	}
+	ctx.Blackboard.Set(key, params["value"])
+	return Success
+}
+
+func actionClear(ctx *Context, params Params) Status {
+	key, ok := params.String("key")
+	if !ok {
+		return Failure
+	}
+	ctx.Blackboard.Delete(key)
+	return Success
+}
+
+func actionTargetPart(ctx *Context, params Params) Status {
+	part, ok := params.String("part")
+	if !ok || ctx.Body == nil {
+		return Failure
+	}
+	if _, exists := ctx.Body.GetBodyPart(part); !exists {
+		return Failure
+	}
+	key, ok := params.String("key")
+	if !ok {
+		key = "target_part"
+	}
+	ctx.Blackboard.Set(key, part)
+	return Success
+}
+
+func conditionIsSet(ctx *Context, params Params) bool {
+	key, ok := params.String("key")
+	return ok && ctx.Blackboard.Has(key)
+}
+
+func conditionCompare(compare func(a, b float64) bool) ConditionFunc {
+	return func(ctx *Context, params Params) bool {
+		key, ok := params.String("key")
+		if !ok {
+			return false
+		}
+		limit, ok := params.Float("value")
+		if !ok {
+			return false
+		}
+		value, ok := ctx.Blackboard.Float(key)
+		return ok && compare(value, limit)
+	}
+}
+
+func conditionHasPart(ctx *Context, params Params) bool {
+	part, ok := params.String("part")
+	if !ok || ctx.Body == nil {
+		return false
+	}
+	_, exists := ctx.Body.GetBodyPart(part)
+	return exists
+}
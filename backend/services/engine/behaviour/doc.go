@@ -0,0 +1,5 @@
+// Package behaviour runs behaviour trees for bosses and elite NPCs whose
+// logic outgrows the flat state machine of components.AIComponent. Trees are
+// authored in JSON or YAML, built against a Registry of named actions and
+// conditions, and ticked once per entity update with a per-entity Blackboard.
+package behaviour
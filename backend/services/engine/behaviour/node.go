@@ -0,0 +1,353 @@
+package behaviour
+
+import (
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+)
+
+// Status is the result of ticking a node.
+type Status int
+
+const (
+	Running Status = iota
+	Success
+	Failure
+)
+
+func (s Status) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Success:
+		return "success"
+	case Failure:
+		return "failure"
+	}
+	return "unknown"
+}
+
+// Context is what the nodes of a tree see while it ticks.
+type Context struct {
+	Blackboard *Blackboard
+	Owner      any                         // Entity running the tree
+	Body       *collider.CompositeCollider // Body parts of the owner, nil for single-shape NPCs
+	DeltaTime  float64                     // Seconds since the last tick
+	Time       float64                     // Seconds the tree has been ticking
+}
+
+// Node is a node of a behaviour tree. Nodes keep the state of a running
+// subtree between ticks; Reset drops it when a parent aborts the subtree.
+type Node interface {
+	Tick(ctx *Context) Status
+	Reset()
+}
+
+// ---------------------------------------------------------------------------
+// Composites
+// ---------------------------------------------------------------------------
+
+// Sequence runs its children in order until one fails. A running child is
+// resumed on the next tick rather than starting over.
+type Sequence struct {
+	Children []Node
+	current  int
+}
+
+func (s *Sequence) Tick(ctx *Context) Status {
+	for s.current < len(s.Children) {
+		switch s.Children[s.current].Tick(ctx) {
+		case Running:
+			return Running
+		case Failure:
+			s.Reset()
+			return Failure
+		}
+		s.current++
+	}
+	s.current = 0
+	return Success
+}
+
+func (s *Sequence) Reset() {
+	for _, child := range s.Children {
+		child.Reset()
+	}
+	s.current = 0
+}
+
+// Selector runs its children in order until one does not fail. It starts
+// from the first child on every tick, so a higher priority branch (e.g. the
+// enrage phase of a boss) interrupts a running lower priority one.
+type Selector struct {
+	Children []Node
+	running  int // index+1 of the child left running, 0 for none
+}
+
+func (s *Selector) Tick(ctx *Context) Status {
+	for i, child := range s.Children {
+		status := child.Tick(ctx)
+		if status == Failure {
+			continue
+		}
+		if s.running > 0 && s.running != i+1 {
+			s.Children[s.running-1].Reset()
+		}
+		s.running = 0
+		if status == Running {
+			s.running = i + 1
+		}
+		return status
+	}
+	s.running = 0
+	return Failure
+}
+
+func (s *Selector) Reset() {
+	for _, child := range s.Children {
+		child.Reset()
+	}
+	s.running = 0
+}
+
+// Parallel ticks all its children every tick. It succeeds once SuccessCount
+// children succeeded (all of them when 0) and fails as soon as that can no
+// longer happen; the children still running are then reset.
+type Parallel struct {
+	Children     []Node
+	SuccessCount int
+	results      []Status
+}
+
+func (p *Parallel) Tick(ctx *Context) Status {
+	if len(p.results) != len(p.Children) {
+		p.results = make([]Status, len(p.Children))
+	}
+	need := p.SuccessCount
+	if need <= 0 || need > len(p.Children) {
+		need = len(p.Children)
+	}
+
+	succeeded, failed := 0, 0
+	for i, child := range p.Children {
+		if p.results[i] == Running {
+			p.results[i] = child.Tick(ctx)
+		}
+		switch p.results[i] {
+		case Success:
+			succeeded++
+		case Failure:
+			failed++
+		}
+	}
+
+	switch {
+	case succeeded >= need:
+		p.Reset()
+		return Success
+	case failed > len(p.Children)-need:
+		p.Reset()
+		return Failure
+	}
+	return Running
+}
+
+func (p *Parallel) Reset() {
+	for i, child := range p.Children {
+		child.Reset()
+		if i < len(p.results) {
+			p.results[i] = Running
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Decorators
+// ---------------------------------------------------------------------------
+
+// Invert swaps the success and failure of its child.
+type Invert struct {
+	Child Node
+}
+
+func (d *Invert) Tick(ctx *Context) Status {
+	switch d.Child.Tick(ctx) {
+	case Success:
+		return Failure
+	case Failure:
+		return Success
+	}
+	return Running
+}
+
+func (d *Invert) Reset() {
+	d.Child.Reset()
+}
+
+// Force reports Status once its child finished, whatever the child returned.
+type Force struct {
+	Child  Node
+	Status Status
+}
+
+func (d *Force) Tick(ctx *Context) Status {
+	if d.Child.Tick(ctx) == Running {
+		return Running
+	}
+	return d.Status
+}
+
+func (d *Force) Reset() {
+	d.Child.Reset()
+}
+
+// Repeat runs its child Count times (forever when 0), one run per tick at
+// most, and fails as soon as the child fails.
+type Repeat struct {
+	Child Node
+	Count int
+	done  int
+}
+
+func (d *Repeat) Tick(ctx *Context) Status {
+	switch d.Child.Tick(ctx) {
+	case Running:
+		return Running
+	case Failure:
+		d.Reset()
+		return Failure
+	}
+	d.done++
+	if d.Count > 0 && d.done >= d.Count {
+		d.Reset()
+		return Success
+	}
+	d.Child.Reset()
+	return Running
+}
+
+func (d *Repeat) Reset() {
+	d.Child.Reset()
+	d.done = 0
+}
+
+// Cooldown fails without ticking its child for Seconds after the child last
+// finished, e.g. to keep a boss from chaining the same attack. Resetting the
+// node does not end the cooldown.
+type Cooldown struct {
+	Child   Node
+	Seconds float64
+	readyAt float64
+	started bool
+}
+
+func (d *Cooldown) Tick(ctx *Context) Status {
+	if d.started && ctx.Time < d.readyAt {
+		return Failure
+	}
+	status := d.Child.Tick(ctx)
+	if status != Running {
+		d.readyAt, d.started = ctx.Time+d.Seconds, true
+	}
+	return status
+}
+
+func (d *Cooldown) Reset() {
+	d.Child.Reset()
+}
+
+// ---------------------------------------------------------------------------
+// Leaves
+// ---------------------------------------------------------------------------
+
+// ActionFunc performs a named action with the params of its node. Actions
+// taking several ticks return Running until they are done.
+type ActionFunc func(ctx *Context, params Params) Status
+
+// ConditionFunc evaluates a named condition with the params of its node.
+type ConditionFunc func(ctx *Context, params Params) bool
+
+// Action is a leaf running an ActionFunc.
+type Action struct {
+	Name   string
+	Params Params
+	Func   ActionFunc
+}
+
+func (a *Action) Tick(ctx *Context) Status {
+	return a.Func(ctx, a.Params)
+}
+
+func (a *Action) Reset() {}
+
+// Condition is a leaf succeeding when its ConditionFunc holds.
+type Condition struct {
+	Name   string
+	Params Params
+	Func   ConditionFunc
+}
+
+func (c *Condition) Tick(ctx *Context) Status {
+	if c.Func(ctx, c.Params) {
+		return Success
+	}
+	return Failure
+}
+
+func (c *Condition) Reset() {}
+
+// Wait keeps running for Seconds, then succeeds.
+type Wait struct {
+	Seconds float64
+	elapsed float64
+}
+
+func (w *Wait) Tick(ctx *Context) Status {
+	w.elapsed += ctx.DeltaTime
+	if w.elapsed < w.Seconds {
+		return Running
+	}
+	w.elapsed = 0
+	return Success
+}
+
+func (w *Wait) Reset() {
+	w.elapsed = 0
+}
+
+// ---------------------------------------------------------------------------
+// Tree
+// ---------------------------------------------------------------------------
+
+// Tree is a behaviour tree instance belonging to one entity. Build one per
+// entity from a shared definition; instances are not safe for concurrent
+// use.
+type Tree struct {
+	Root       Node
+	Blackboard *Blackboard
+	Owner      any
+	Body       *collider.CompositeCollider
+
+	elapsed float64
+}
+
+// NewTree creates a tree with an empty blackboard.
+func NewTree(root Node) *Tree {
+	return &Tree{Root: root, Blackboard: NewBlackboard()}
+}
+
+// Tick advances the tree by deltaTime seconds and returns the root's status.
+// A finished tree starts over on the next tick.
+func (t *Tree) Tick(deltaTime float64) Status {
+	t.elapsed += deltaTime
+	return t.Root.Tick(&Context{
+		Blackboard: t.Blackboard,
+		Owner:      t.Owner,
+		Body:       t.Body,
+		DeltaTime:  deltaTime,
+		Time:       t.elapsed,
+	})
+}
+
+// Reset aborts whatever the tree is running. The blackboard is kept.
+func (t *Tree) Reset() {
+	t.Root.Reset()
+}
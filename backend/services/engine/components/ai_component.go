@@ -0,0 +1,446 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/google/uuid"
+)
+
+// AIState names a state of an NPC state machine. Machines may define their
+// own states; the GDD's NPC states are provided below.
+type AIState string
+
+const (
+	AIIdle   AIState = "idle"
+	AIPatrol AIState = "patrol"
+	AIAlert  AIState = "alert"
+	AIChase  AIState = "chase"
+	AIAttack AIState = "attack"
+	AIFlee   AIState = "flee"
+	AIDead   AIState = "dead"
+
+	// AIAnyState as the source of a transition matches every state but
+	// AIDead.
+	AIAnyState AIState = "*"
+)
+
+// AIConditionKind is a perception query a transition can depend on.
+type AIConditionKind string
+
+const (
+	TargetWithin  AIConditionKind = "target_within"  // target closer than Value
+	TargetBeyond  AIConditionKind = "target_beyond"  // no target, or target farther than Value
+	TargetVisible AIConditionKind = "target_visible" // target in line of sight, and within Value when Value > 0
+	TargetHidden  AIConditionKind = "target_hidden"  // negation of target_visible
+	HasTarget     AIConditionKind = "has_target"
+	NoTarget      AIConditionKind = "no_target"
+	InStateFor    AIConditionKind = "in_state_for" // seconds spent in the current state >= Value
+	ValueBelow    AIConditionKind = "value_below"  // Values[Key] < Value
+	ValueAbove    AIConditionKind = "value_above"  // Values[Key] > Value
+)
+
+// AICondition is one perception query of a transition.
+type AICondition struct {
+	Kind  AIConditionKind `json:"kind"`
+	Value float64         `json:"value,omitempty"`
+	Key   string          `json:"key,omitempty"` // blackboard key of value_below and value_above
+}
+
+// AITransition moves the machine from one state to another once all of its
+// conditions hold. Transitions are checked in order; the first match wins.
+type AITransition struct {
+	From       AIState       `json:"from"`
+	To         AIState       `json:"to"`
+	Conditions []AICondition `json:"conditions"`
+}
+
+// AIStateHooks are the callbacks of one state. Any of them may be nil.
+type AIStateHooks struct {
+	Enter  func(ai *AIComponent)
+	Update func(ai *AIComponent, deltaTime float64)
+	Exit   func(ai *AIComponent)
+}
+
+// AIMachine is the definition of a state machine, shared by every NPC of a
+// kind. Its transitions are data and can be loaded from JSON; the state
+// hooks are code and are attached with On.
+type AIMachine struct {
+	Initial     AIState        `json:"initial"`
+	Transitions []AITransition `json:"transitions"`
+
+	hooks map[AIState]AIStateHooks
+}
+
+// NewAIMachine creates a machine starting in initial.
+func NewAIMachine(initial AIState, transitions ...AITransition) *AIMachine {
+	return &AIMachine{Initial: initial, Transitions: transitions, hooks: make(map[AIState]AIStateHooks)}
+}
+
+// LoadAIMachine decodes a machine definition such as
+//
+//	{"initial": "idle", "transitions": [
+//	    {"from": "idle", "to": "chase", "conditions": [{"kind": "target_visible", "value": 96}]}]}
+//
+// and rejects unknown condition kinds. AIAnyState may only be used as the
+// source of a transition, never as the initial state or a target.
+func LoadAIMachine(data []byte) (*AIMachine, error) {
+	m := NewAIMachine("")
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Initial == "" {
+		return nil, fmt.Errorf("AI machine needs an initial state")
+	}
+	if m.Initial == AIAnyState {
+		return nil, fmt.Errorf("AI machine cannot start in %q", AIAnyState)
+	}
+	for i, t := range m.Transitions {
+		if t.From == "" || t.To == "" {
+			return nil, fmt.Errorf("AI transition %d needs from and to states", i)
+		}
+		if t.To == AIAnyState {
+			return nil, fmt.Errorf("AI transition %d: %q only matches source states", i, AIAnyState)
+		}
+		for _, c := range t.Conditions {
+			switch c.Kind {
+			case TargetWithin, TargetBeyond, TargetVisible, TargetHidden, HasTarget, NoTarget, InStateFor:
+			case ValueBelow, ValueAbove:
+				if c.Key == "" {
+					return nil, fmt.Errorf("AI transition %s -> %s: %s needs a key", t.From, t.To, c.Kind)
+				}
+			default:
+				return nil, fmt.Errorf("AI transition %s -> %s: unknown condition %q", t.From, t.To, c.Kind)
+			}
+		}
+	}
+	return m, nil
+}
+
+// On sets the hooks of a state and returns the machine for chaining.
+func (m *AIMachine) On(state AIState, hooks AIStateHooks) *AIMachine {
+	if m.hooks == nil {
+		m.hooks = make(map[AIState]AIStateHooks)
+	}
+	m.hooks[state] = hooks
+	return m
+}
+
+// AIComponent drives an NPC with an AIMachine. Every step it checks the
+// transitions leaving the current state against what the NPC perceives,
+// switches state if one matches, then runs the state's update hook. Inside
+// a World the AI system steps every active component once per tick, before
+// physics; game code using the component on its own calls AIMachine.Step.
+//
+// Perception reads the NPC's own Transform, the Target transform chosen by
+// game logic (e.g. the nearest player) and LineOfSight, usually a raycast
+// against the world's walls. Values is a blackboard for everything else,
+// such as health.
+type AIComponent struct {
+	componentID string
+	name        string
+	isActive    bool
+
+	machine *AIMachine
+
+	State         AIState            `json:"state"`
+	PreviousState AIState            `json:"previous_state"`
+	TimeInState   float64            `json:"time_in_state"` // seconds
+	TargetID      string             `json:"target_id"`     // IID of the target entity
+	Values        map[string]float64 `json:"values"`
+
+	// References, not serialized
+	Transform   *TransformComponent
+	Target      *TransformComponent
+	LineOfSight func(from, to geometry.Point) bool // nil sees everything
+}
+
+// NewAIComponent creates an AI component running machine for the NPC at
+// transform. The initial state is entered by Start or the first Update.
+func NewAIComponent(machine *AIMachine, transform *TransformComponent) *AIComponent {
+	return &AIComponent{
+		componentID: "ai-" + uuid.New().String(),
+		name:        "AI",
+		isActive:    true,
+		machine:     machine,
+		Transform:   transform,
+		Values:      make(map[string]float64),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Component interface implementation
+// ---------------------------------------------------------------------------
+
+func (a *AIComponent) ComponentID() string {
+	return a.componentID
+}
+
+func (a *AIComponent) Name() string {
+	return a.name
+}
+
+func (a *AIComponent) IsActive() bool {
+	return a.isActive
+}
+
+func (a *AIComponent) SetActive(active bool) bool {
+	prev := a.isActive
+	a.isActive = active
+	return prev
+}
+
+// Reset forgets the current state, target and blackboard; the initial state
+// is entered again on the next Update.
+func (a *AIComponent) Reset() error {
+	a.State = ""
+	a.PreviousState = ""
+	a.TimeInState = 0
+	a.TargetID = ""
+	a.Target = nil
+	a.Values = make(map[string]float64)
+	return nil
+}
+
+func (a *AIComponent) Start() error {
+	if a.machine == nil {
+		return fmt.Errorf("AIComponent needs an AIMachine")
+	}
+	a.isActive = true
+	if a.State == "" {
+		a.enter(a.machine.Initial)
+	}
+	return nil
+}
+
+// Update does nothing: the AI system steps the machine every tick, see
+// AIMachine.Step.
+func (a *AIComponent) Update(deltaTime float64) {}
+
+func (a *AIComponent) OnCreate() {
+	// No-op: the machine is set via constructor.
+}
+
+func (a *AIComponent) OnDestroy() {
+	a.Transform = nil
+	a.Target = nil
+	a.LineOfSight = nil
+}
+
+// Serialize stores the machine's runtime state, so an NPC restored from a
+// snapshot resumes where it was. The machine itself and the references are
+// not serialized.
+func (a *AIComponent) Serialize() []byte {
+	type serializable struct {
+		ComponentID   string             `json:"component_id"`
+		Name          string             `json:"name"`
+		IsActive      bool               `json:"is_active"`
+		State         AIState            `json:"state"`
+		PreviousState AIState            `json:"previous_state"`
+		TimeInState   float64            `json:"time_in_state"`
+		TargetID      string             `json:"target_id"`
+		Values        map[string]float64 `json:"values"`
+	}
+
+	data, err := json.Marshal(serializable{
+		ComponentID:   a.componentID,
+		Name:          a.name,
+		IsActive:      a.isActive,
+		State:         a.State,
+		PreviousState: a.PreviousState,
+		TimeInState:   a.TimeInState,
+		TargetID:      a.TargetID,
+		Values:        a.Values,
+	})
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// Deserialize restores the runtime state without running any hook.
+func (a *AIComponent) Deserialize(data []byte) error {
+	type serializable struct {
+		ComponentID   string             `json:"component_id"`
+		Name          string             `json:"name"`
+		IsActive      bool               `json:"is_active"`
+		State         AIState            `json:"state"`
+		PreviousState AIState            `json:"previous_state"`
+		TimeInState   float64            `json:"time_in_state"`
+		TargetID      string             `json:"target_id"`
+		Values        map[string]float64 `json:"values"`
+	}
+
+	var s serializable
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	a.componentID = s.ComponentID
+	a.name = s.Name
+	a.isActive = s.IsActive
+	a.State = s.State
+	a.PreviousState = s.PreviousState
+	a.TimeInState = s.TimeInState
+	a.TargetID = s.TargetID
+	a.Values = s.Values
+	if a.Values == nil {
+		a.Values = make(map[string]float64)
+	}
+	return nil
+}
+
+func (a *AIComponent) Clone() Component {
+	values := make(map[string]float64, len(a.Values))
+	for k, v := range a.Values {
+		values[k] = v
+	}
+	return &AIComponent{
+		componentID:   "ai-" + uuid.New().String(),
+		name:          a.name,
+		isActive:      a.isActive,
+		machine:       a.machine, // definitions are shared
+		State:         a.State,
+		PreviousState: a.PreviousState,
+		TimeInState:   a.TimeInState,
+		Values:        values,
+		LineOfSight:   a.LineOfSight,
+		// Transform and Target are intentionally nil – the clone belongs to
+		// another entity and picks its own target
+	}
+}
+
+// ---------------------------------------------------------------------------
+// State machine
+// ---------------------------------------------------------------------------
+
+// Step advances an active component running m: it enters the initial state
+// if none was entered yet, takes the first transition whose conditions hold
+// and runs the update hook of the resulting state.
+func (m *AIMachine) Step(a *AIComponent, deltaTime float64) {
+	if !a.isActive || a.machine != m {
+		return
+	}
+	if a.State == "" {
+		a.enter(m.Initial)
+	}
+
+	a.TimeInState += deltaTime
+	for _, t := range m.Transitions {
+		if a.leaves(t) && a.holds(t.Conditions) {
+			a.TransitionTo(t.To)
+			break
+		}
+	}
+	if hooks := m.hooks[a.State]; hooks.Update != nil {
+		hooks.Update(a, deltaTime)
+	}
+}
+
+// Machine returns the machine definition the component runs.
+func (a *AIComponent) Machine() *AIMachine {
+	return a.machine
+}
+
+// SetMachine replaces the machine, e.g. after Deserialize. The current state
+// is kept.
+func (a *AIComponent) SetMachine(machine *AIMachine) {
+	a.machine = machine
+}
+
+// TransitionTo leaves the current state and enters another one, running the
+// exit and enter hooks, whatever the transitions say. Game logic uses it for
+// events the machine cannot perceive, such as death.
+func (a *AIComponent) TransitionTo(state AIState) {
+	if a.machine != nil && a.State != "" {
+		if hooks := a.machine.hooks[a.State]; hooks.Exit != nil {
+			hooks.Exit(a)
+		}
+	}
+	a.PreviousState = a.State
+	a.enter(state)
+}
+
+// SetTarget sets the transform the NPC perceives as its target and the IID
+// stored in snapshots. A nil transform clears the target.
+func (a *AIComponent) SetTarget(iid string, target *TransformComponent) {
+	if target == nil {
+		iid = ""
+	}
+	a.TargetID, a.Target = iid, target
+}
+
+// DistanceToTarget returns the distance to the target, or false without a
+// target.
+func (a *AIComponent) DistanceToTarget() (float64, bool) {
+	if a.Transform == nil || a.Target == nil {
+		return 0, false
+	}
+	return a.Transform.DistanceTo(a.Target), true
+}
+
+// CanSeeTarget reports whether the target is within maxRange (unlimited when
+// 0) and in line of sight.
+func (a *AIComponent) CanSeeTarget(maxRange float64) bool {
+	distance, ok := a.DistanceToTarget()
+	if !ok || (maxRange > 0 && distance > maxRange) {
+		return false
+	}
+	return a.LineOfSight == nil || a.LineOfSight(*a.Transform.Position, *a.Target.Position)
+}
+
+func (a *AIComponent) enter(state AIState) {
+	a.State = state
+	a.TimeInState = 0
+	if a.machine == nil {
+		return
+	}
+	if hooks := a.machine.hooks[state]; hooks.Enter != nil {
+		hooks.Enter(a)
+	}
+}
+
+func (a *AIComponent) leaves(t AITransition) bool {
+	if t.To == a.State {
+		return false
+	}
+	return t.From == a.State || (t.From == AIAnyState && a.State != AIDead)
+}
+
+func (a *AIComponent) holds(conditions []AICondition) bool {
+	for _, c := range conditions {
+		if !a.check(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *AIComponent) check(c AICondition) bool {
+	switch c.Kind {
+	case TargetWithin:
+		distance, ok := a.DistanceToTarget()
+		return ok && distance <= c.Value
+	case TargetBeyond:
+		distance, ok := a.DistanceToTarget()
+		return !ok || distance > c.Value
+	case TargetVisible:
+		return a.CanSeeTarget(c.Value)
+	case TargetHidden:
+		return !a.CanSeeTarget(c.Value)
+	case HasTarget:
+		return a.Target != nil
+	case NoTarget:
+		return a.Target == nil
+	case InStateFor:
+		return a.TimeInState >= c.Value
+	case ValueBelow:
+		v, ok := a.Values[c.Key]
+		return ok && v < c.Value
+	case ValueAbove:
+		v, ok := a.Values[c.Key]
+		return ok && v > c.Value
+	}
+	return false
+}
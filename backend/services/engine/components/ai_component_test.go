@@ -0,0 +1,140 @@
+package components
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// npcMachine is the GDD's NPC behaviour: patrol, notice a player, chase and
+// attack, flee when hurt and die at zero health.
+const npcMachine = `{"initial": "idle", "transitions": [
+	{"from": "*", "to": "dead", "conditions": [{"kind": "value_below", "key": "health", "value": 0.001}]},
+	{"from": "*", "to": "flee", "conditions": [{"kind": "value_below", "key": "health", "value": 20}, {"kind": "target_within", "value": 64}]},
+	{"from": "idle", "to": "patrol", "conditions": [{"kind": "in_state_for", "value": 1}]},
+	{"from": "patrol", "to": "alert", "conditions": [{"kind": "target_visible", "value": 96}]},
+	{"from": "alert", "to": "chase", "conditions": [{"kind": "in_state_for", "value": 0.5}, {"kind": "target_visible"}]},
+	{"from": "alert", "to": "patrol", "conditions": [{"kind": "target_hidden"}]},
+	{"from": "chase", "to": "attack", "conditions": [{"kind": "target_within", "value": 16}]},
+	{"from": "chase", "to": "patrol", "conditions": [{"kind": "target_beyond", "value": 160}]},
+	{"from": "attack", "to": "chase", "conditions": [{"kind": "target_beyond", "value": 16}]},
+	{"from": "flee", "to": "patrol", "conditions": [{"kind": "target_beyond", "value": 128}]}]}`
+
+func TestAIComponent_NPCLifecycle(t *testing.T) {
+	machine, err := LoadAIMachine([]byte(npcMachine))
+	if err != nil {
+		t.Fatalf("LoadAIMachine() error = %v", err)
+	}
+	log := make([]string, 0)
+	for _, state := range []AIState{AIIdle, AIPatrol, AIAlert, AIChase, AIAttack, AIFlee, AIDead} {
+		state := state
+		machine.On(state, AIStateHooks{
+			Enter: func(ai *AIComponent) { log = append(log, "+"+string(state)) },
+			Exit:  func(ai *AIComponent) { log = append(log, "-"+string(state)) },
+		})
+	}
+
+	npc := NewAIComponent(machine, NewTransformComponent(geometry.NewPoint(0, 0), 0, 1))
+	npc.Values["health"] = 100
+	player := NewTransformComponent(geometry.NewPoint(200, 0), 0, 1)
+	wall := false
+	npc.LineOfSight = func(from, to geometry.Point) bool { return !wall }
+	if err := npc.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	steps := []struct {
+		name     string
+		setup    func()
+		expected AIState
+	}{
+		{"waits before patrolling", func() { npc.Update(10) }, AIIdle}, // Update leaves stepping to the AI system
+		{"starts patrolling", func() { machine.Step(npc, 0.6) }, AIPatrol},
+		{"ignores players out of range", func() { npc.SetTarget("player", player) }, AIPatrol},
+		{"notices a player in range", func() { player.SetPosition(90, 0) }, AIAlert},
+		{"loses a player behind a wall", func() { wall = true }, AIPatrol},
+		{"notices again", func() { wall = false }, AIAlert},
+		{"chases after a moment", func() { machine.Step(npc, 0.5) }, AIChase},
+		{"attacks in reach", func() { player.SetPosition(10, 0) }, AIAttack},
+		{"flees when hurt", func() { npc.Values["health"] = 10 }, AIFlee},
+		{"dies at zero health", func() { npc.Values["health"] = 0 }, AIDead},
+		{"stays dead", func() { npc.Values["health"] = 100; machine.Step(npc, 10) }, AIDead},
+	}
+	for _, step := range steps {
+		step.setup()
+		machine.Step(npc, 0.5)
+		if npc.State != step.expected {
+			t.Fatalf("%s: state = %s, expected %s", step.name, npc.State, step.expected)
+		}
+	}
+
+	expected := "[+idle -idle +patrol -patrol +alert -alert +patrol -patrol +alert -alert +chase -chase +attack -attack +flee -flee +dead]"
+	if got := fmt.Sprint(log); got != expected {
+		t.Errorf("hooks ran %s, expected %s", got, expected)
+	}
+}
+
+func TestAIComponent_SerializeRoundTrip(t *testing.T) {
+	updates := 0
+	machine := NewAIMachine(AIIdle,
+		AITransition{From: AIIdle, To: AIChase, Conditions: []AICondition{{Kind: HasTarget}}},
+		AITransition{From: AIChase, To: AIIdle, Conditions: []AICondition{{Kind: NoTarget}}},
+	).On(AIChase, AIStateHooks{Update: func(ai *AIComponent, dt float64) { updates++ }})
+
+	npc := NewAIComponent(machine, NewTransformComponent(geometry.NewPoint(0, 0), 0, 1))
+	npc.SetTarget("player", NewTransformComponent(geometry.NewPoint(5, 0), 0, 1))
+	npc.Values["health"] = 42
+	machine.Step(npc, 0.25)
+	machine.Step(npc, 0.25)
+
+	restored := NewAIComponent(nil, nil)
+	if err := restored.Deserialize(npc.Serialize()); err != nil {
+		t.Fatalf("Deserialize() error = %v", err)
+	}
+	if restored.ComponentID() != npc.ComponentID() || restored.State != AIChase || restored.PreviousState != AIIdle ||
+		restored.TimeInState != 0.25 || restored.TargetID != "player" || restored.Values["health"] != 42 {
+		t.Fatalf("restored = %+v, expected the state of %+v", restored, npc)
+	}
+
+	// The restored NPC resumes its state without entering it again.
+	restored.SetMachine(machine)
+	restored.SetTarget("player", NewTransformComponent(geometry.NewPoint(5, 0), 0, 1))
+	machine.Step(restored, 0.25)
+	if restored.State != AIChase || restored.TimeInState != 0.5 || updates != 3 {
+		t.Errorf("after Step: state = %s, time = %v, updates = %d, expected chase, 0.5 and 3",
+			restored.State, restored.TimeInState, updates)
+	}
+
+	clone := restored.Clone().(*AIComponent)
+	clone.Values["health"] = 1
+	if clone.ComponentID() == restored.ComponentID() || restored.Values["health"] != 42 || clone.Target != nil {
+		t.Errorf("Clone() shares identity, blackboard or target with the original")
+	}
+	machine.Step(clone, 0)
+	if clone.State != AIIdle {
+		t.Errorf("clone without a target: state = %s, expected idle", clone.State)
+	}
+}
+
+func TestLoadAIMachine_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"no initial state", `{"transitions": []}`},
+		{"missing state", `{"initial": "idle", "transitions": [{"from": "idle"}]}`},
+		{"any state as initial", `{"initial": "*", "transitions": []}`},
+		{"any state as target", `{"initial": "idle", "transitions": [{"from": "idle", "to": "*"}]}`},
+		{"unknown condition", `{"initial": "idle", "transitions": [{"from": "idle", "to": "chase", "conditions": [{"kind": "smells_player"}]}]}`},
+		{"value without key", `{"initial": "idle", "transitions": [{"from": "idle", "to": "flee", "conditions": [{"kind": "value_below", "value": 1}]}]}`},
+		{"invalid json", `{"initial": `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadAIMachine([]byte(tt.data)); err == nil {
+				t.Errorf("LoadAIMachine() expected an error")
+			}
+		})
+	}
+}
@@ -6,16 +6,19 @@ import (
 	"fmt"
 
 	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collision"
 	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
 	"github.com/google/uuid"
 )
 
-type physicType uint16
+// PhysicBodyType selects how a body reacts to the simulation.
+type PhysicBodyType uint16
 
+// Body types accepted by NewPhysicComponent.
 const (
-	staticBody    physicType = 0
-	kinematicBody physicType = 1
-	rigidBody     physicType = 2
+	StaticBody    PhysicBodyType = 0
+	KinematicBody PhysicBodyType = 1
+	RigidBody     PhysicBodyType = 2
 )
 
 // PhysicComponent handles physics simulation for an entity.
@@ -30,21 +33,26 @@ type PhysicComponent struct {
 
 	// Physics properties
 	collider         *collider.Collider
-	PhysicType       *physicType
+	PhysicType       *PhysicBodyType
 	Velocity         *geometry.Vector2
 	RotationVelocity float64
 
+	// ContinuousCollision sweeps the body from its previous to its current
+	// position every tick so that fast movers (projectiles) cannot skip
+	// through thin colliders. Costs a sweep test per nearby collider.
+	ContinuousCollision bool
+
 	// Additional physics properties (for future use)
-	Mass            float64
-	Friction        float64
-	Restitution     float64 // Bounciness
-	LinearDamping   float64 // Velocity damping over time
-	AngularDamping  float64 // Rotation damping over time
+	Mass           float64
+	Friction       float64
+	Restitution    float64 // Bounciness
+	LinearDamping  float64 // Velocity damping over time
+	AngularDamping float64 // Rotation damping over time
 }
 
 // NewPhysicComponent creates a new PhysicComponent with the given physics type and collider.
 // transform parameter is required and cannot be nil.
-func NewPhysicComponent(pt physicType, c *collider.Collider, transform *TransformComponent) (*PhysicComponent, error) {
+func NewPhysicComponent(pt PhysicBodyType, c *collider.Collider, transform *TransformComponent) (*PhysicComponent, error) {
 	if transform == nil {
 		return nil, errors.New("TransformComponent is required for PhysicComponent")
 	}
@@ -80,6 +88,57 @@ func (c *PhysicComponent) GetTransform() *TransformComponent {
 	return c.transform
 }
 
+// SetCollider replaces the collider driven by this component.
+func (c *PhysicComponent) SetCollider(col *collider.Collider) {
+	c.collider = col
+	c.SyncCollider()
+}
+
+// GetCollider returns the collider driven by this component, or nil.
+func (c *PhysicComponent) GetCollider() *collider.Collider {
+	return c.collider
+}
+
+// IsStatic reports whether the body never moves.
+func (c *PhysicComponent) IsStatic() bool {
+	return *c.PhysicType == StaticBody
+}
+
+// IsKinematic reports whether the body is moved programmatically and is not
+// pushed around by other bodies.
+func (c *PhysicComponent) IsKinematic() bool {
+	return *c.PhysicType == KinematicBody
+}
+
+// IsRigid reports whether the body reacts to forces and impulses.
+func (c *PhysicComponent) IsRigid() bool {
+	return *c.PhysicType == RigidBody
+}
+
+// Body returns the resolver's view of this component. Position and velocity
+// are shared with the component, so resolving a contact moves the entity.
+func (c *PhysicComponent) Body() *collision.Body {
+	kind := collision.Rigid
+	switch *c.PhysicType {
+	case StaticBody:
+		kind = collision.Static
+	case KinematicBody:
+		kind = collision.Kinematic
+	}
+
+	body := &collision.Body{
+		Kind:        kind,
+		Mass:        c.Mass,
+		Restitution: c.Restitution,
+		Friction:    c.Friction,
+		Velocity:    c.Velocity,
+	}
+	if c.transform != nil {
+		body.Position = c.transform.Position
+	}
+	return body
+}
+
 // ---------------------------------------------------------------------------
 // Component interface implementation
 // ---------------------------------------------------------------------------
@@ -116,58 +175,22 @@ func (c *PhysicComponent) Start() error {
 		return errors.New("TransformComponent is required but not set")
 	}
 	c.isActive = true
+	c.SyncCollider()
 	return nil
 }
 
-func (c *PhysicComponent) Update(deltaTime float64) {
-	if !c.isActive || c.transform == nil {
-		return
-	}
+// Update does nothing: the physics system integrates bodies every tick, see
+// systems.IntegrateBody.
+func (c *PhysicComponent) Update(deltaTime float64) {}
 
-	// Apply physics based on physic type
-	switch *c.PhysicType {
-	case staticBody:
-		// Static bodies don't move
-		c.Velocity = geometry.NewVector2(0, 0)
-		c.RotationVelocity = 0
-
-	case kinematicBody, rigidBody:
-		// Apply linear damping
-		if c.LinearDamping > 0 {
-			dampingFactor := 1.0 - (c.LinearDamping * deltaTime)
-			if dampingFactor < 0 {
-				dampingFactor = 0
-			}
-			c.Velocity.X *= dampingFactor
-			c.Velocity.Y *= dampingFactor
-		}
-
-		// Apply angular damping
-		if c.AngularDamping > 0 {
-			dampingFactor := 1.0 - (c.AngularDamping * deltaTime)
-			if dampingFactor < 0 {
-				dampingFactor = 0
-			}
-			c.RotationVelocity *= dampingFactor
-		}
-
-		// Update position based on velocity
-		displacementX := c.Velocity.X * deltaTime
-		displacementY := c.Velocity.Y * deltaTime
-		c.transform.Translate(displacementX, displacementY)
-
-		// Update rotation based on rotation velocity
-		if c.RotationVelocity != 0 {
-			rotationDelta := c.RotationVelocity * deltaTime
-			c.transform.Rotate(rotationDelta)
-		}
-
-		// Update collider position if it exists
-		if c.collider != nil {
-			// Collider position should be synced with transform
-			// This is a prototype - actual implementation depends on collider API
-		}
+// SyncCollider copies the transform's position and rotation onto the collider
+// so that collision queries see the body where the simulation moved it.
+func (c *PhysicComponent) SyncCollider() {
+	if c.collider == nil || c.transform == nil {
+		return
 	}
+	c.collider.SetTransform(geometry.Vector2{X: c.transform.Position.X, Y: c.transform.Position.Y})
+	c.collider.SetRotation(c.transform.Rotation)
 }
 
 func (c *PhysicComponent) OnCreate() {
@@ -187,32 +210,34 @@ func (c *PhysicComponent) OnDestroy() {
 
 func (c *PhysicComponent) Serialize() []byte {
 	type serializable struct {
-		ComponentID     string             `json:"component_id"`
-		Name            string             `json:"name"`
-		IsActive        bool               `json:"is_active"`
-		PhysicType      uint16             `json:"physic_type"`
-		Velocity        *geometry.Vector2  `json:"velocity"`
-		RotationVelocity float64           `json:"rotation_velocity"`
-		Mass            float64            `json:"mass"`
-		Friction        float64            `json:"friction"`
-		Restitution     float64            `json:"restitution"`
-		LinearDamping   float64            `json:"linear_damping"`
-		AngularDamping  float64            `json:"angular_damping"`
+		ComponentID         string            `json:"component_id"`
+		Name                string            `json:"name"`
+		IsActive            bool              `json:"is_active"`
+		PhysicType          uint16            `json:"physic_type"`
+		Velocity            *geometry.Vector2 `json:"velocity"`
+		RotationVelocity    float64           `json:"rotation_velocity"`
+		ContinuousCollision bool              `json:"continuous_collision"`
+		Mass                float64           `json:"mass"`
+		Friction            float64           `json:"friction"`
+		Restitution         float64           `json:"restitution"`
+		LinearDamping       float64           `json:"linear_damping"`
+		AngularDamping      float64           `json:"angular_damping"`
 		// Note: transform and collider are not serialized as they are references
 	}
 
 	s := serializable{
-		ComponentID:     c.componentID,
-		Name:            c.name,
-		IsActive:        c.isActive,
-		PhysicType:      uint16(*c.PhysicType),
-		Velocity:        c.Velocity,
-		RotationVelocity: c.RotationVelocity,
-		Mass:            c.Mass,
-		Friction:        c.Friction,
-		Restitution:     c.Restitution,
-		LinearDamping:   c.LinearDamping,
-		AngularDamping:  c.AngularDamping,
+		ComponentID:         c.componentID,
+		Name:                c.name,
+		IsActive:            c.isActive,
+		PhysicType:          uint16(*c.PhysicType),
+		Velocity:            c.Velocity,
+		RotationVelocity:    c.RotationVelocity,
+		ContinuousCollision: c.ContinuousCollision,
+		Mass:                c.Mass,
+		Friction:            c.Friction,
+		Restitution:         c.Restitution,
+		LinearDamping:       c.LinearDamping,
+		AngularDamping:      c.AngularDamping,
 	}
 
 	data, err := json.Marshal(s)
@@ -224,17 +249,18 @@ func (c *PhysicComponent) Serialize() []byte {
 
 func (c *PhysicComponent) Deserialize(data []byte) error {
 	type serializable struct {
-		ComponentID     string            `json:"component_id"`
-		Name            string            `json:"name"`
-		IsActive        bool              `json:"is_active"`
-		PhysicType      uint16            `json:"physic_type"`
-		Velocity        *geometry.Vector2 `json:"velocity"`
-		RotationVelocity float64          `json:"rotation_velocity"`
-		Mass            float64           `json:"mass"`
-		Friction        float64           `json:"friction"`
-		Restitution     float64           `json:"restitution"`
-		LinearDamping   float64           `json:"linear_damping"`
-		AngularDamping  float64           `json:"angular_damping"`
+		ComponentID         string            `json:"component_id"`
+		Name                string            `json:"name"`
+		IsActive            bool              `json:"is_active"`
+		PhysicType          uint16            `json:"physic_type"`
+		Velocity            *geometry.Vector2 `json:"velocity"`
+		RotationVelocity    float64           `json:"rotation_velocity"`
+		ContinuousCollision bool              `json:"continuous_collision"`
+		Mass                float64           `json:"mass"`
+		Friction            float64           `json:"friction"`
+		Restitution         float64           `json:"restitution"`
+		LinearDamping       float64           `json:"linear_damping"`
+		AngularDamping      float64           `json:"angular_damping"`
 	}
 
 	var s serializable
@@ -245,10 +271,11 @@ func (c *PhysicComponent) Deserialize(data []byte) error {
 	c.componentID = s.ComponentID
 	c.name = s.Name
 	c.isActive = s.IsActive
-	pt := physicType(s.PhysicType)
+	pt := PhysicBodyType(s.PhysicType)
 	c.PhysicType = &pt
 	c.Velocity = s.Velocity
 	c.RotationVelocity = s.RotationVelocity
+	c.ContinuousCollision = s.ContinuousCollision
 	c.Mass = s.Mass
 	c.Friction = s.Friction
 	c.Restitution = s.Restitution
@@ -260,19 +287,20 @@ func (c *PhysicComponent) Deserialize(data []byte) error {
 
 func (c *PhysicComponent) Clone() Component {
 	clone := &PhysicComponent{
-		componentID:      "physic-" + uuid.New().String(),
-		name:            c.name,
-		isActive:        c.isActive,
-		transform:       nil, // Clone should not copy transform reference
-		collider:        nil, // Clone should not copy collider reference
-		PhysicType:      new(physicType),
-		Velocity:        geometry.NewVector2(c.Velocity.X, c.Velocity.Y),
-		RotationVelocity: c.RotationVelocity,
-		Mass:            c.Mass,
-		Friction:        c.Friction,
-		Restitution:     c.Restitution,
-		LinearDamping:   c.LinearDamping,
-		AngularDamping:  c.AngularDamping,
+		componentID:         "physic-" + uuid.New().String(),
+		name:                c.name,
+		isActive:            c.isActive,
+		transform:           nil, // Clone should not copy transform reference
+		collider:            nil, // Clone should not copy collider reference
+		PhysicType:          new(PhysicBodyType),
+		Velocity:            geometry.NewVector2(c.Velocity.X, c.Velocity.Y),
+		RotationVelocity:    c.RotationVelocity,
+		ContinuousCollision: c.ContinuousCollision,
+		Mass:                c.Mass,
+		Friction:            c.Friction,
+		Restitution:         c.Restitution,
+		LinearDamping:       c.LinearDamping,
+		AngularDamping:      c.AngularDamping,
 	}
 	*clone.PhysicType = *c.PhysicType
 	return clone
@@ -286,7 +314,7 @@ func (c *PhysicComponent) Clone() Component {
 // For now, this is a simple prototype - in a full physics engine,
 // this would integrate with mass and acceleration.
 func (c *PhysicComponent) AddForce(force *geometry.Vector2) {
-	if *c.PhysicType == staticBody {
+	if *c.PhysicType == StaticBody {
 		return // Static bodies don't respond to forces
 	}
 
@@ -298,7 +326,7 @@ func (c *PhysicComponent) AddForce(force *geometry.Vector2) {
 
 // AddTorque applies rotational force (affects rotation velocity).
 func (c *PhysicComponent) AddTorque(torque float64) {
-	if *c.PhysicType == staticBody {
+	if *c.PhysicType == StaticBody {
 		return // Static bodies don't rotate
 	}
 
@@ -308,7 +336,7 @@ func (c *PhysicComponent) AddTorque(torque float64) {
 
 // SetVelocity sets the linear velocity directly.
 func (c *PhysicComponent) SetVelocity(vx, vy float64) {
-	if *c.PhysicType == staticBody {
+	if *c.PhysicType == StaticBody {
 		return
 	}
 	c.Velocity.X = vx
@@ -317,7 +345,7 @@ func (c *PhysicComponent) SetVelocity(vx, vy float64) {
 
 // SetRotationVelocity sets the angular velocity directly.
 func (c *PhysicComponent) SetRotationVelocity(angularVel float64) {
-	if *c.PhysicType == staticBody {
+	if *c.PhysicType == StaticBody {
 		return
 	}
 	c.RotationVelocity = angularVel
@@ -87,11 +87,9 @@ func (t *TransformComponent) Start() error {
 	return nil
 }
 
-func (t *TransformComponent) Update(dt float64) {
-	// Snapshot current state as "previous" before physics step applies forces.
-	t.PreviousPosition = geometry.NewPoint(t.Position.X, t.Position.Y)
-	t.PreviousRotation = t.Rotation
-}
+// Update does nothing: the transforms system snapshots the previous state
+// every tick, see systems.SnapshotTransform.
+func (t *TransformComponent) Update(dt float64) {}
 
 func (t *TransformComponent) OnCreate() {
 	// No-op: spatial state is set via constructor.
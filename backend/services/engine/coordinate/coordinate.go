@@ -0,0 +1,168 @@
+package coordinate
+
+import (
+	"math"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// The game uses four coordinate spaces:
+//
+//   - World: the space the simulation runs in. One world unit is one LDtk
+//     pixel, the origin is the top-left corner of the LDtk world, x grows to
+//     the right and y grows down. Physics is flat top-down; the isometric
+//     look is presentation only.
+//   - Pixel: LDtk pixel coordinates, relative to the top-left corner of a
+//     layer (the level's world position plus the layer offset).
+//   - Cell: integer grid coordinates of a layer. Cell (x, y) covers the
+//     pixels [x*size, (x+1)*size) x [y*size, (y+1)*size).
+//   - Screen: isometric screen space. The world x axis runs right-down and
+//     the world y axis left-down, so a square cell becomes a diamond.
+
+// Default sizes, matching LDtk's default grid and a 2:1 isometric tile.
+const (
+	DefaultCellSize   = 16
+	DefaultTileWidth  = 32
+	DefaultTileHeight = 16
+)
+
+// Pixel is a position in LDtk pixel space.
+type Pixel struct {
+	X, Y float64
+}
+
+// Cell is a position in grid space.
+type Cell struct {
+	X, Y int
+}
+
+// Screen is a position in isometric screen space.
+type Screen struct {
+	X, Y float64
+}
+
+// Grid converts between the pixel, cell and world spaces of one layer.
+type Grid struct {
+	CellSize float64          // Edge length of a cell in pixels, defaults to 16
+	Origin   geometry.Vector2 // World position of the layer's top-left corner
+}
+
+// NewGrid creates the grid of a layer whose top-left corner is at (x, y) in
+// the world.
+func NewGrid(cellSize, x, y float64) Grid {
+	return Grid{CellSize: cellSize, Origin: geometry.Vector2{X: x, Y: y}}
+}
+
+func (g Grid) cellSize() float64 {
+	if g.CellSize <= 0 {
+		return DefaultCellSize
+	}
+	return g.CellSize
+}
+
+// PixelToWorld returns the world position of a layer pixel.
+func (g Grid) PixelToWorld(p Pixel) geometry.Point {
+	return geometry.Point{X: g.Origin.X + p.X, Y: g.Origin.Y + p.Y}
+}
+
+// WorldToPixel returns the layer pixel at a world position.
+func (g Grid) WorldToPixel(p geometry.Point) Pixel {
+	return Pixel{X: p.X - g.Origin.X, Y: p.Y - g.Origin.Y}
+}
+
+// PixelToCell returns the cell containing a pixel. Pixels left of or above
+// the layer map to negative cells.
+func (g Grid) PixelToCell(p Pixel) Cell {
+	size := g.cellSize()
+	return Cell{X: int(math.Floor(p.X / size)), Y: int(math.Floor(p.Y / size))}
+}
+
+// CellToPixel returns the top-left pixel of a cell.
+func (g Grid) CellToPixel(c Cell) Pixel {
+	size := g.cellSize()
+	return Pixel{X: float64(c.X) * size, Y: float64(c.Y) * size}
+}
+
+// WorldToCell returns the cell containing a world position.
+func (g Grid) WorldToCell(p geometry.Point) Cell {
+	return g.PixelToCell(g.WorldToPixel(p))
+}
+
+// CellToWorld returns the world position of a cell's top-left corner.
+func (g Grid) CellToWorld(c Cell) geometry.Point {
+	return g.PixelToWorld(g.CellToPixel(c))
+}
+
+// CellCenter returns the world position of a cell's center.
+func (g Grid) CellCenter(c Cell) geometry.Point {
+	p, half := g.CellToWorld(c), g.cellSize()/2
+	return geometry.Point{X: p.X + half, Y: p.Y + half}
+}
+
+// CellBounds returns the world area covered by the w x h cells whose
+// top-left cell is c.
+func (g Grid) CellBounds(c Cell, w, h int) geometry.Bounds {
+	p, size := g.CellToWorld(c), g.cellSize()
+	return geometry.Bounds{MinX: p.X, MinY: p.Y, MaxX: p.X + float64(w)*size, MaxY: p.Y + float64(h)*size}
+}
+
+// CellRange returns the first and last cell overlapped by a world area, such
+// as the bounds of a collider. An area ending exactly on a cell edge does not
+// reach into the next cell.
+func (g Grid) CellRange(b geometry.Bounds) (first, last Cell) {
+	size := g.cellSize()
+	lo, hi := g.WorldToPixel(geometry.Point{X: b.MinX, Y: b.MinY}), g.WorldToPixel(geometry.Point{X: b.MaxX, Y: b.MaxY})
+	first = g.PixelToCell(lo)
+	last = Cell{X: int(math.Ceil(hi.X/size)) - 1, Y: int(math.Ceil(hi.Y/size)) - 1}
+	return first, last
+}
+
+// Isometric projects world positions onto isometric screen space. A square
+// of CellSize world units is drawn as a TileWidth x TileHeight diamond and
+// the world origin is drawn at the screen origin.
+type Isometric struct {
+	CellSize   float64 // World size of the cell drawn as one tile, defaults to 16
+	TileWidth  float64 // Screen width of a tile, defaults to 32
+	TileHeight float64 // Screen height of a tile, defaults to 16
+}
+
+// NewIsometric creates a projection drawing cells of cellSize world units as
+// tileWidth x tileHeight diamonds.
+func NewIsometric(cellSize, tileWidth, tileHeight float64) Isometric {
+	return Isometric{CellSize: cellSize, TileWidth: tileWidth, TileHeight: tileHeight}
+}
+
+// halfTile returns half the tile size in screen units per world unit.
+func (iso Isometric) halfTile() (float64, float64) {
+	cellSize, w, h := iso.CellSize, iso.TileWidth, iso.TileHeight
+	if cellSize <= 0 {
+		cellSize = DefaultCellSize
+	}
+	if w <= 0 {
+		w = DefaultTileWidth
+	}
+	if h <= 0 {
+		h = DefaultTileHeight
+	}
+	return w / 2 / cellSize, h / 2 / cellSize
+}
+
+// WorldToScreen returns the screen position of a world position.
+func (iso Isometric) WorldToScreen(p geometry.Point) Screen {
+	hw, hh := iso.halfTile()
+	return Screen{X: (p.X - p.Y) * hw, Y: (p.X + p.Y) * hh}
+}
+
+// ScreenToWorld returns the world position drawn at a screen position, e.g.
+// the ground position a player clicked on.
+func (iso Isometric) ScreenToWorld(s Screen) geometry.Point {
+	hw, hh := iso.halfTile()
+	x, y := s.X/hw, s.Y/hh // x - y and x + y
+	return geometry.Point{X: (y + x) / 2, Y: (y - x) / 2}
+}
+
+// CellToScreen returns the screen position of the top corner of a cell's
+// diamond.
+func (iso Isometric) CellToScreen(g Grid, c Cell) Screen {
+	return iso.WorldToScreen(g.CellToWorld(c))
+}
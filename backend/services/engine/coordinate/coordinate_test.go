@@ -0,0 +1,132 @@
+package coordinate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+const epsilon = 1e-6
+
+// quickConfig keeps the property tests deterministic.
+func quickConfig(seed int64) *quick.Config {
+	return &quick.Config{MaxCount: 2000, Rand: rand.New(rand.NewSource(seed))}
+}
+
+// coord maps an arbitrary float into a map-sized range, so the properties are
+// checked where float precision is meaningful.
+func coord(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return math.Mod(v, 1e5)
+}
+
+func grid(cellSize uint8, x, y float64) Grid {
+	return NewGrid(float64(cellSize%64+1), coord(x), coord(y))
+}
+
+func cellRange(g Grid, b geometry.Bounds) [2]Cell {
+	first, last := g.CellRange(b)
+	return [2]Cell{first, last}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= epsilon*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestGrid_PixelWorldRoundTrip(t *testing.T) {
+	property := func(cellSize uint8, ox, oy, px, py float64) bool {
+		g := grid(cellSize, ox, oy)
+		p := Pixel{X: coord(px), Y: coord(py)}
+		back := g.WorldToPixel(g.PixelToWorld(p))
+		return near(back.X, p.X) && near(back.Y, p.Y)
+	}
+	if err := quick.Check(property, quickConfig(1)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGrid_CellRoundTrip(t *testing.T) {
+	property := func(cellSize uint8, ox, oy float64, cx, cy int16, fx, fy uint8) bool {
+		g := grid(cellSize, ox, oy)
+		c := Cell{X: int(cx), Y: int(cy)}
+		if g.WorldToCell(g.CellToWorld(c)) != c || g.WorldToCell(g.CellCenter(c)) != c {
+			return false
+		}
+		// Every pixel inside the cell maps back to it.
+		inside := g.CellToPixel(c)
+		inside.X += float64(fx) / 256 * g.CellSize
+		inside.Y += float64(fy) / 256 * g.CellSize
+		return g.PixelToCell(inside) == c
+	}
+	if err := quick.Check(property, quickConfig(2)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsometric_ScreenRoundTrip(t *testing.T) {
+	property := func(cellSize, tileWidth, tileHeight uint8, x, y float64) bool {
+		iso := NewIsometric(float64(cellSize%64+1), float64(tileWidth%128+1), float64(tileHeight%64+1))
+		p := geometry.Point{X: coord(x), Y: coord(y)}
+		back := iso.ScreenToWorld(iso.WorldToScreen(p))
+		return near(back.X, p.X) && near(back.Y, p.Y)
+	}
+	if err := quick.Check(property, quickConfig(3)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGrid_Conventions(t *testing.T) {
+	g := NewGrid(16, 64, 32)
+
+	tests := []struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{"pixel to world", g.PixelToWorld(Pixel{X: 8, Y: 4}), geometry.Point{X: 72, Y: 36}},
+		{"cell of a pixel", g.PixelToCell(Pixel{X: 31.9, Y: 16}), Cell{X: 1, Y: 1}},
+		{"negative pixels", g.PixelToCell(Pixel{X: -0.5, Y: -16}), Cell{X: -1, Y: -1}},
+		{"cell top-left", g.CellToWorld(Cell{X: 1, Y: 2}), geometry.Point{X: 80, Y: 64}},
+		{"cell center", g.CellCenter(Cell{X: 0, Y: 0}), geometry.Point{X: 72, Y: 40}},
+		{"cell bounds", g.CellBounds(Cell{X: 1, Y: 0}, 2, 1), geometry.Bounds{MinX: 80, MinY: 32, MaxX: 112, MaxY: 48}},
+		{"zero cell size", Grid{}.PixelToCell(Pixel{X: 16, Y: 15}), Cell{X: 1, Y: 0}},
+		{"cell range", cellRange(g, geometry.Bounds{MinX: 70, MinY: 32, MaxX: 96, MaxY: 48.5}), [2]Cell{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %+v, expected %+v", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsometric_Conventions(t *testing.T) {
+	iso := Isometric{}
+	g := NewGrid(16, 0, 0)
+
+	tests := []struct {
+		name     string
+		cell     Cell
+		expected Screen
+	}{
+		{"origin", Cell{X: 0, Y: 0}, Screen{X: 0, Y: 0}},
+		{"world x runs right-down", Cell{X: 1, Y: 0}, Screen{X: 16, Y: 8}},
+		{"world y runs left-down", Cell{X: 0, Y: 1}, Screen{X: -16, Y: 8}},
+		{"diagonal runs straight down", Cell{X: 1, Y: 1}, Screen{X: 0, Y: 16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := iso.CellToScreen(g, tt.cell); got != tt.expected {
+				t.Errorf("CellToScreen(%+v) = %+v, expected %+v", tt.cell, got, tt.expected)
+			}
+		})
+	}
+}
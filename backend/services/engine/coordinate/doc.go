@@ -0,0 +1,4 @@
+// Package coordinate converts positions between the spaces the game works
+// in: LDtk pixels, grid cells, engine world units and isometric screen space.
+// See coordinate.go for the conventions every package relies on.
+package coordinate
@@ -0,0 +1,6 @@
+// Package ecs is the engine's entity registry. Entities are stable numeric
+// IDs, components live in one typed storage per Go type, and queries iterate
+// the entities holding a combination of components by walking the matching
+// archetypes. Systems registered with an explicit order run over the
+// registry every tick, instead of each component updating itself.
+package ecs
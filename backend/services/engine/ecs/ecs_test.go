@@ -0,0 +1,228 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// --- Test Helpers ---
+
+type position struct{ X, Y float64 }
+type velocity struct{ X, Y float64 }
+type health struct{ HP int }
+type dead struct{}
+
+func collect(q *Query) []EntityID {
+	out := make([]EntityID, 0)
+	for e := range q.Entities() {
+		out = append(out, e)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// --- Tests ---
+
+func TestRegistry_EntityLifecycle(t *testing.T) {
+	r := NewRegistry()
+	a, b := r.Create(), r.Create()
+	if a == Nil || a == b || r.Len() != 2 {
+		t.Fatalf("Create() = %v, %v with Len() = %d", a, b, r.Len())
+	}
+
+	if !r.Destroy(a) || r.Alive(a) || r.Destroy(a) {
+		t.Errorf("Destroy(a) did not kill a exactly once")
+	}
+	c := r.Create()
+	if c.Index() != a.Index() || c.Generation() != a.Generation()+1 {
+		t.Errorf("Create() = %v, expected slot %d reused with a new generation", c, a.Index())
+	}
+	if r.Alive(a) || !r.Alive(c) {
+		t.Errorf("stale ID %v is alive or new ID %v is not", a, c)
+	}
+	if err := Add(r, a, position{}); !errors.Is(err, ErrDeadEntity) {
+		t.Errorf("Add() on a destroyed entity error = %v, expected ErrDeadEntity", err)
+	}
+}
+
+func TestRegistry_Components(t *testing.T) {
+	r := NewRegistry()
+	e := r.Create()
+	Add(r, e, position{X: 1})
+	Add(r, e, velocity{X: 2})
+	Add(r, e, position{X: 3}) // replaces
+
+	if p, ok := Get[position](r, e); !ok || p.X != 3 {
+		t.Errorf("Get[position]() = %v, %v, expected {3 0}", p, ok)
+	}
+	if _, ok := Get[health](r, e); ok || Has[health](r, e) {
+		t.Errorf("entity holds a health it was never given")
+	}
+	if !Remove[velocity](r, e) || Has[velocity](r, e) || Remove[velocity](r, e) {
+		t.Errorf("Remove[velocity]() did not remove exactly once")
+	}
+
+	// A destroyed entity's components are gone even if its slot is reused.
+	r.Destroy(e)
+	reused := r.Create()
+	if Has[position](r, reused) {
+		t.Errorf("reused slot inherited a component")
+	}
+	s, _, _ := StorageOf[position](r)
+	if s.Len() != 0 {
+		t.Errorf("position storage holds %d components, expected 0", s.Len())
+	}
+}
+
+func TestRegistry_AddAny(t *testing.T) {
+	r := NewRegistry()
+	e := r.Create()
+	if err := r.AddAny(e, &health{HP: 3}); !errors.Is(err, ErrUnregisteredType) {
+		t.Fatalf("AddAny() error = %v, expected ErrUnregisteredType", err)
+	}
+	RegisterType[*health](r)
+	var component any = &health{HP: 3}
+	if err := r.AddAny(e, component); err != nil {
+		t.Fatalf("AddAny() error = %v", err)
+	}
+	if h, ok := Get[*health](r, e); !ok || h.HP != 3 {
+		t.Errorf("Get[*health]() = %v, %v", h, ok)
+	}
+}
+
+func TestQuery_MatchesCombinations(t *testing.T) {
+	r := NewRegistry()
+	moving, _ := NewQuery2[position, velocity](r)
+	alive := Without[dead](moving.Query)
+
+	ids := make(map[string]EntityID)
+	for _, name := range []string{"still", "mover", "ghost", "corpse", "runner"} {
+		ids[name] = r.Create()
+	}
+	Add(r, ids["still"], position{})
+	Add(r, ids["ghost"], velocity{})
+	for _, name := range []string{"mover", "corpse", "runner"} {
+		Add(r, ids[name], position{X: 1})
+		Add(r, ids[name], velocity{X: 2})
+	}
+	Add(r, ids["corpse"], dead{})
+	Add(r, ids["runner"], health{HP: 1}) // extra types still match
+
+	expected := []EntityID{ids["mover"], ids["runner"]}
+	slices.Sort(expected)
+	if got := collect(alive); !slices.Equal(got, expected) {
+		t.Errorf("query matched %v, expected %v", got, expected)
+	}
+
+	sum := 0.0
+	moving.Each(func(e EntityID, p position, v velocity) { sum += p.X + v.X })
+	if sum != 6 {
+		t.Errorf("Each() saw components summing to %v, expected 6", sum)
+	}
+
+	// Archetypes created after the first iteration are picked up.
+	Remove[velocity](r, ids["mover"])
+	late := r.Create()
+	Add(r, late, velocity{})
+	Add(r, late, position{})
+	Add(r, late, health{})
+	if alive.Len() != 2 {
+		t.Errorf("Len() = %d after the changes, expected 2", alive.Len())
+	}
+}
+
+func TestQuery_DefersStructuralChanges(t *testing.T) {
+	r := NewRegistry()
+	q, _ := NewQuery1[health](r)
+	for i := 0; i < 10; i++ {
+		e := r.Create()
+		Add(r, e, health{HP: i})
+	}
+
+	visited := 0
+	q.Each(func(e EntityID, h health) {
+		visited++
+		if h.HP%2 == 0 {
+			r.Destroy(e)
+		} else {
+			Add(r, e, dead{})
+			Remove[health](r, e)
+		}
+		spawned := r.Create()
+		Add(r, spawned, health{HP: 100})
+		if !r.Alive(e) || !Has[health](r, e) {
+			t.Fatalf("change applied during the iteration")
+		}
+	})
+
+	if visited != 10 {
+		t.Errorf("visited %d entities, expected 10", visited)
+	}
+	if q.Len() != 10 || r.Len() != 15 {
+		t.Errorf("after the loop: %d with health, %d alive, expected 10 and 15", q.Len(), r.Len())
+	}
+	q.Each(func(e EntityID, h health) {
+		if h.HP != 100 {
+			t.Errorf("entity %v kept health %d", e, h.HP)
+		}
+	})
+
+	// Breaking out of a range loop ends the iteration too.
+	for e := range q.Entities() {
+		r.Destroy(e)
+		break
+	}
+	if q.Len() != 9 {
+		t.Errorf("Len() = %d after breaking out, expected 9", q.Len())
+	}
+}
+
+func TestRegistry_SystemsRunInOrder(t *testing.T) {
+	r := NewRegistry()
+	log := make([]string, 0)
+	system := func(name string) System {
+		return SystemFunc(func(r *Registry, dt float64) { log = append(log, fmt.Sprintf("%s@%v", name, dt)) })
+	}
+
+	r.AddSystem("physics", 200, system("physics"))
+	r.AddSystem("ai", 50, system("ai"))
+	r.AddSystem("steering", 150, system("steering"))
+	r.AddSystem("animation", 200, system("animation"))
+	if err := r.AddSystem("ai", 10, system("ai")); !errors.Is(err, ErrDuplicateSystem) {
+		t.Errorf("AddSystem() duplicate error = %v, expected ErrDuplicateSystem", err)
+	}
+
+	r.Update(0.02)
+	if got := fmt.Sprint(log); got != "[ai@0.02 steering@0.02 physics@0.02 animation@0.02]" {
+		t.Errorf("systems ran %s", got)
+	}
+	if !r.RemoveSystem("steering") || fmt.Sprint(r.Systems()) != "[ai physics animation]" {
+		t.Errorf("Systems() = %v after removing steering", r.Systems())
+	}
+}
+
+func BenchmarkQuery2_Each(b *testing.B) {
+	r := NewRegistry()
+	q, _ := NewQuery2[position, velocity](r)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		e := r.Create()
+		Add(r, e, position{})
+		if rng.Intn(2) == 0 {
+			Add(r, e, velocity{X: 1})
+		}
+		if rng.Intn(4) == 0 {
+			Add(r, e, health{})
+		}
+	}
+	sum := 0.0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Each(func(e EntityID, p position, v velocity) {
+			sum += p.X + v.X
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package ecs
+
+import "fmt"
+
+// EntityID identifies an entity for as long as it lives. The low 32 bits
+// are a slot index, reused after the entity is destroyed; the high 32 bits
+// are the slot's generation, so IDs of destroyed entities never match a
+// newer entity.
+type EntityID uint64
+
+// Nil is never a live entity.
+const Nil EntityID = 0
+
+func newEntityID(index, generation uint32) EntityID {
+	return EntityID(uint64(generation)<<32 | uint64(index))
+}
+
+// Index returns the slot index of the entity.
+func (e EntityID) Index() uint32 {
+	return uint32(e)
+}
+
+// Generation returns the slot generation of the entity.
+func (e EntityID) Generation() uint32 {
+	return uint32(e >> 32)
+}
+
+func (e EntityID) String() string {
+	return fmt.Sprintf("%d:%d", e.Index(), e.Generation())
+}
@@ -0,0 +1,153 @@
+package ecs
+
+import "iter"
+
+// Query finds the entities holding a set of component types. It remembers
+// the archetypes matching it and only checks archetypes created since the
+// last iteration, so iterating costs one step per matching entity.
+type Query struct {
+	r       *Registry
+	mask    Mask
+	without Mask
+	matched []*archetype
+	checked int // archetypes of r.archetypeList already matched
+}
+
+func newQuery(r *Registry, types ...ComponentType) *Query {
+	q := &Query{r: r}
+	for _, t := range types {
+		q.mask.set(t)
+	}
+	return q
+}
+
+// Without excludes entities holding T, e.g. dead NPCs from a movement
+// query, and returns the query for chaining. Call it before iterating.
+func Without[T any](q *Query) *Query {
+	t, err := RegisterType[T](q.r)
+	if err == nil {
+		q.without.set(t)
+		q.matched, q.checked = q.matched[:0], 0
+	}
+	return q
+}
+
+func (q *Query) refresh() {
+	for ; q.checked < len(q.r.archetypeList); q.checked++ {
+		a := q.r.archetypeList[q.checked]
+		if a.mask.contains(q.mask) && !a.mask.intersects(q.without) {
+			q.matched = append(q.matched, a)
+		}
+	}
+}
+
+// Len returns the number of matching entities.
+func (q *Query) Len() int {
+	q.refresh()
+	n := 0
+	for _, a := range q.matched {
+		n += len(a.entities)
+	}
+	return n
+}
+
+// Entities iterates the matching entities. Structural changes made while
+// iterating are applied once the loop ends.
+func (q *Query) Entities() iter.Seq[EntityID] {
+	return func(yield func(EntityID) bool) {
+		q.refresh()
+		q.r.lock()
+		defer q.r.unlock()
+		for _, a := range q.matched {
+			for _, e := range a.entities {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Query1 iterates the entities holding an A.
+type Query1[A any] struct {
+	*Query
+	a *Storage[A]
+}
+
+// NewQuery1 creates a query over A, registering the type if needed.
+func NewQuery1[A any](r *Registry) (*Query1[A], error) {
+	a, ta, err := StorageOf[A](r)
+	if err != nil {
+		return nil, err
+	}
+	return &Query1[A]{Query: newQuery(r, ta), a: a}, nil
+}
+
+// Each calls fn with every matching entity and its component.
+func (q *Query1[A]) Each(fn func(e EntityID, a A)) {
+	for e := range q.Entities() {
+		fn(e, q.a.at(e.Index()))
+	}
+}
+
+// Query2 iterates the entities holding an A and a B.
+type Query2[A, B any] struct {
+	*Query
+	a *Storage[A]
+	b *Storage[B]
+}
+
+// NewQuery2 creates a query over A and B, registering the types if needed.
+func NewQuery2[A, B any](r *Registry) (*Query2[A, B], error) {
+	a, ta, err := StorageOf[A](r)
+	if err != nil {
+		return nil, err
+	}
+	b, tb, err := StorageOf[B](r)
+	if err != nil {
+		return nil, err
+	}
+	return &Query2[A, B]{Query: newQuery(r, ta, tb), a: a, b: b}, nil
+}
+
+// Each calls fn with every matching entity and its components.
+func (q *Query2[A, B]) Each(fn func(e EntityID, a A, b B)) {
+	for e := range q.Entities() {
+		i := e.Index()
+		fn(e, q.a.at(i), q.b.at(i))
+	}
+}
+
+// Query3 iterates the entities holding an A, a B and a C.
+type Query3[A, B, C any] struct {
+	*Query
+	a *Storage[A]
+	b *Storage[B]
+	c *Storage[C]
+}
+
+// NewQuery3 creates a query over A, B and C, registering the types if
+// needed.
+func NewQuery3[A, B, C any](r *Registry) (*Query3[A, B, C], error) {
+	a, ta, err := StorageOf[A](r)
+	if err != nil {
+		return nil, err
+	}
+	b, tb, err := StorageOf[B](r)
+	if err != nil {
+		return nil, err
+	}
+	c, tc, err := StorageOf[C](r)
+	if err != nil {
+		return nil, err
+	}
+	return &Query3[A, B, C]{Query: newQuery(r, ta, tb, tc), a: a, b: b, c: c}, nil
+}
+
+// Each calls fn with every matching entity and its components.
+func (q *Query3[A, B, C]) Each(fn func(e EntityID, a A, b B, c C)) {
+	for e := range q.Entities() {
+		i := e.Index()
+		fn(e, q.a.at(i), q.b.at(i), q.c.at(i))
+	}
+}
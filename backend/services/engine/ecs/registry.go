@@ -0,0 +1,309 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrDeadEntity       = errors.New("entity is not alive")
+	ErrUnregisteredType = errors.New("component type is not registered")
+	ErrTooManyTypes     = errors.New("too many component types")
+	ErrDuplicateSystem  = errors.New("system already registered")
+)
+
+// archetype groups the entities holding exactly the same component types.
+type archetype struct {
+	mask     Mask
+	entities []EntityID
+}
+
+// Registry owns entities, their components and the systems running over
+// them. Structural changes (destroying entities, adding or removing
+// components) requested while a query iterates are deferred until the
+// outermost iteration ends, so iterations never see half-applied changes.
+// A Registry is not safe for concurrent use.
+type Registry struct {
+	generations []uint32
+	alive       []bool
+	masks       []Mask
+	rows        []int32 // position of each entity in its archetype
+	free        []uint32
+	count       int
+
+	types    map[reflect.Type]ComponentType
+	storages []storage
+
+	archetypes    map[Mask]*archetype
+	archetypeList []*archetype // in creation order, append-only
+
+	iterating int
+	deferred  []func()
+
+	systems  []systemEntry
+	sequence int
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		types:      make(map[reflect.Type]ComponentType),
+		archetypes: make(map[Mask]*archetype),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Entities
+// ---------------------------------------------------------------------------
+
+// Create adds an entity without components. It takes effect immediately,
+// even during an iteration, since an entity without components matches no
+// query.
+func (r *Registry) Create() EntityID {
+	var index uint32
+	if n := len(r.free); n > 0 {
+		index, r.free = r.free[n-1], r.free[:n-1]
+	} else {
+		index = uint32(len(r.generations))
+		r.generations = append(r.generations, 0)
+		r.alive = append(r.alive, false)
+		r.masks = append(r.masks, Mask{})
+		r.rows = append(r.rows, 0)
+	}
+	r.generations[index]++
+	r.alive[index] = true
+	r.count++
+
+	e := newEntityID(index, r.generations[index])
+	r.enter(e, Mask{})
+	return e
+}
+
+// Alive reports whether e exists.
+func (r *Registry) Alive(e EntityID) bool {
+	index := e.Index()
+	return int(index) < len(r.alive) && r.alive[index] && r.generations[index] == e.Generation()
+}
+
+// Len returns the number of live entities.
+func (r *Registry) Len() int {
+	return r.count
+}
+
+// Destroy removes e and its components. It returns false for entities that
+// are not alive.
+func (r *Registry) Destroy(e EntityID) bool {
+	if !r.Alive(e) {
+		return false
+	}
+	if r.iterating > 0 {
+		r.Defer(func() { r.Destroy(e) })
+		return true
+	}
+
+	index := e.Index()
+	r.leave(e)
+	for t, s := range r.storages {
+		if r.masks[index].Has(ComponentType(t)) {
+			s.remove(index)
+		}
+	}
+	r.masks[index] = Mask{}
+	r.alive[index] = false
+	r.free = append(r.free, index)
+	r.count--
+	return true
+}
+
+// Mask returns the component types e holds.
+func (r *Registry) Mask(e EntityID) Mask {
+	if !r.Alive(e) {
+		return Mask{}
+	}
+	return r.masks[e.Index()]
+}
+
+// Defer runs fn once no query is iterating, right away if none is.
+func (r *Registry) Defer(fn func()) {
+	if r.iterating == 0 {
+		fn()
+		return
+	}
+	r.deferred = append(r.deferred, fn)
+}
+
+func (r *Registry) lock() {
+	r.iterating++
+}
+
+func (r *Registry) unlock() {
+	r.iterating--
+	if r.iterating > 0 {
+		return
+	}
+	// Deferred changes may defer more changes only if they iterate.
+	for len(r.deferred) > 0 {
+		pending := r.deferred
+		r.deferred = nil
+		for _, fn := range pending {
+			fn()
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Archetypes
+// ---------------------------------------------------------------------------
+
+func (r *Registry) archetype(mask Mask) *archetype {
+	a, exists := r.archetypes[mask]
+	if !exists {
+		a = &archetype{mask: mask, entities: make([]EntityID, 0)}
+		r.archetypes[mask] = a
+		r.archetypeList = append(r.archetypeList, a)
+	}
+	return a
+}
+
+func (r *Registry) enter(e EntityID, mask Mask) {
+	a := r.archetype(mask)
+	r.masks[e.Index()] = mask
+	r.rows[e.Index()] = int32(len(a.entities))
+	a.entities = append(a.entities, e)
+}
+
+func (r *Registry) leave(e EntityID) {
+	index := e.Index()
+	a := r.archetypes[r.masks[index]]
+	row, last := r.rows[index], len(a.entities)-1
+	if int(row) != last {
+		moved := a.entities[last]
+		a.entities[row] = moved
+		r.rows[moved.Index()] = row
+	}
+	a.entities = a.entities[:last]
+}
+
+func (r *Registry) move(e EntityID, mask Mask) {
+	if r.masks[e.Index()] == mask {
+		return
+	}
+	r.leave(e)
+	r.enter(e, mask)
+}
+
+// ---------------------------------------------------------------------------
+// Components
+// ---------------------------------------------------------------------------
+
+// RegisterType returns the component type of T, registering it on first
+// use. Types only need registering up front to be added through AddAny.
+func RegisterType[T any](r *Registry) (ComponentType, error) {
+	key := reflect.TypeFor[T]()
+	if t, exists := r.types[key]; exists {
+		return t, nil
+	}
+	if len(r.storages) >= maxComponentTypes {
+		return 0, fmt.Errorf("%w: cannot register %s", ErrTooManyTypes, key)
+	}
+	t := ComponentType(len(r.storages))
+	r.types[key] = t
+	r.storages = append(r.storages, &Storage[T]{})
+	return t, nil
+}
+
+// TypeOf returns the component type of T, if registered.
+func TypeOf[T any](r *Registry) (ComponentType, bool) {
+	t, exists := r.types[reflect.TypeFor[T]()]
+	return t, exists
+}
+
+// StorageOf returns the storage of T, registering T if needed.
+func StorageOf[T any](r *Registry) (*Storage[T], ComponentType, error) {
+	t, err := RegisterType[T](r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.storages[t].(*Storage[T]), t, nil
+}
+
+// Add sets the T component of e, replacing any it had. During an iteration
+// the change is deferred.
+func Add[T any](r *Registry, e EntityID, component T) error {
+	if !r.Alive(e) {
+		return ErrDeadEntity
+	}
+	s, t, err := StorageOf[T](r)
+	if err != nil {
+		return err
+	}
+	r.Defer(func() {
+		if !r.Alive(e) {
+			return // destroyed by an earlier deferred change
+		}
+		s.set(e, component)
+		mask := r.masks[e.Index()]
+		mask.set(t)
+		r.move(e, mask)
+	})
+	return nil
+}
+
+// AddAny sets a component whose type is only known at run time, e.g. one of
+// an entity's []components.Component. Its dynamic type must have been
+// registered with RegisterType.
+func (r *Registry) AddAny(e EntityID, component any) error {
+	if !r.Alive(e) {
+		return ErrDeadEntity
+	}
+	t, exists := r.types[reflect.TypeOf(component)]
+	if !exists {
+		return fmt.Errorf("%w: %T", ErrUnregisteredType, component)
+	}
+	r.Defer(func() {
+		if !r.Alive(e) || !r.storages[t].setAny(e, component) {
+			return
+		}
+		mask := r.masks[e.Index()]
+		mask.set(t)
+		r.move(e, mask)
+	})
+	return nil
+}
+
+// Get returns the T component of e.
+func Get[T any](r *Registry, e EntityID) (T, bool) {
+	var zero T
+	t, exists := TypeOf[T](r)
+	if !exists || !r.Alive(e) || !r.masks[e.Index()].Has(t) {
+		return zero, false
+	}
+	return r.storages[t].(*Storage[T]).get(e.Index())
+}
+
+// Has reports whether e holds a T component.
+func Has[T any](r *Registry, e EntityID) bool {
+	t, exists := TypeOf[T](r)
+	return exists && r.Alive(e) && r.masks[e.Index()].Has(t)
+}
+
+// Remove removes the T component of e and reports whether it had one.
+// During an iteration the change is deferred.
+func Remove[T any](r *Registry, e EntityID) bool {
+	if !Has[T](r, e) {
+		return false
+	}
+	t, _ := TypeOf[T](r)
+	r.Defer(func() {
+		index := e.Index()
+		if !r.Alive(e) || !r.masks[index].Has(t) {
+			return
+		}
+		r.storages[t].remove(index)
+		mask := r.masks[index]
+		mask.clear(t)
+		r.move(e, mask)
+	})
+	return true
+}
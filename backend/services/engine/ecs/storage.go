@@ -0,0 +1,116 @@
+package ecs
+
+// maxComponentTypes is the number of component types a registry can hold,
+// bounded by the size of Mask.
+const maxComponentTypes = 256
+
+// ComponentType is the index of a component type within a registry.
+type ComponentType int
+
+// Mask is the set of component types an entity holds, which is also the key
+// of its archetype.
+type Mask [maxComponentTypes / 64]uint64
+
+func (m *Mask) set(t ComponentType) {
+	m[t/64] |= 1 << (t % 64)
+}
+
+func (m *Mask) clear(t ComponentType) {
+	m[t/64] &^= 1 << (t % 64)
+}
+
+// Has reports whether t is in the mask.
+func (m Mask) Has(t ComponentType) bool {
+	return m[t/64]&(1<<(t%64)) != 0
+}
+
+// contains reports whether every type of other is in m.
+func (m Mask) contains(other Mask) bool {
+	for i := range m {
+		if m[i]&other[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// intersects reports whether m and other share a type.
+func (m Mask) intersects(other Mask) bool {
+	for i := range m {
+		if m[i]&other[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// storage is the type-erased view of a Storage the registry works with.
+type storage interface {
+	remove(index uint32)
+	setAny(e EntityID, component any) bool
+}
+
+// Storage holds the components of one type as a sparse set: a dense slice
+// iterated by queries plus an index from entity slot to dense position.
+type Storage[T any] struct {
+	sparse []int32 // dense position + 1 per entity slot, 0 when absent
+	dense  []T
+	owners []EntityID
+}
+
+// Len returns the number of components stored.
+func (s *Storage[T]) Len() int {
+	return len(s.dense)
+}
+
+func (s *Storage[T]) get(index uint32) (T, bool) {
+	if int(index) < len(s.sparse) {
+		if i := s.sparse[index]; i > 0 {
+			return s.dense[i-1], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// at returns the component of an entity known to hold one.
+func (s *Storage[T]) at(index uint32) T {
+	return s.dense[s.sparse[index]-1]
+}
+
+func (s *Storage[T]) set(e EntityID, component T) {
+	index := e.Index()
+	for int(index) >= len(s.sparse) {
+		s.sparse = append(s.sparse, 0)
+	}
+	if i := s.sparse[index]; i > 0 {
+		s.dense[i-1], s.owners[i-1] = component, e
+		return
+	}
+	s.dense = append(s.dense, component)
+	s.owners = append(s.owners, e)
+	s.sparse[index] = int32(len(s.dense))
+}
+
+func (s *Storage[T]) setAny(e EntityID, component any) bool {
+	typed, ok := component.(T)
+	if ok {
+		s.set(e, typed)
+	}
+	return ok
+}
+
+func (s *Storage[T]) remove(index uint32) {
+	if int(index) >= len(s.sparse) || s.sparse[index] == 0 {
+		return
+	}
+	i, last := s.sparse[index]-1, int32(len(s.dense)-1)
+	if i != last {
+		s.dense[i], s.owners[i] = s.dense[last], s.owners[last]
+		s.sparse[s.owners[i].Index()] = i + 1
+	}
+	var zero T
+	s.dense[last] = zero // drop the reference for the GC
+	s.dense, s.owners = s.dense[:last], s.owners[:last]
+	s.sparse[index] = 0
+}
@@ -0,0 +1,74 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// System updates the components of the entities it queries once per tick.
+type System interface {
+	Update(r *Registry, deltaTime float64)
+}
+
+// SystemFunc adapts a function to System.
+type SystemFunc func(r *Registry, deltaTime float64)
+
+func (f SystemFunc) Update(r *Registry, deltaTime float64) {
+	f(r, deltaTime)
+}
+
+type systemEntry struct {
+	name     string
+	order    int
+	sequence int
+	system   System
+}
+
+// AddSystem registers a system under a unique name. Systems run by
+// ascending order; systems of equal order run in registration order.
+func (r *Registry) AddSystem(name string, order int, system System) error {
+	for _, s := range r.systems {
+		if s.name == name {
+			return fmt.Errorf("%w: %s", ErrDuplicateSystem, name)
+		}
+	}
+	r.sequence++
+	r.systems = append(r.systems, systemEntry{name: name, order: order, sequence: r.sequence, system: system})
+	sort.Slice(r.systems, func(i, j int) bool {
+		if r.systems[i].order != r.systems[j].order {
+			return r.systems[i].order < r.systems[j].order
+		}
+		return r.systems[i].sequence < r.systems[j].sequence
+	})
+	return nil
+}
+
+// RemoveSystem unregisters a system and reports whether it existed.
+func (r *Registry) RemoveSystem(name string) bool {
+	for i, s := range r.systems {
+		if s.name == name {
+			r.systems = append(r.systems[:i], r.systems[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Systems returns the names of the systems in the order they run.
+func (r *Registry) Systems() []string {
+	names := make([]string, len(r.systems))
+	for i, s := range r.systems {
+		names[i] = s.name
+	}
+	return names
+}
+
+// Update runs every system once. Changes deferred while a system iterates
+// are applied when its loop ends, so later systems see them.
+func (r *Registry) Update(deltaTime float64) {
+	systems := make([]systemEntry, len(r.systems))
+	copy(systems, r.systems) // systems may add or remove systems
+	for _, s := range systems {
+		s.system.Update(r, deltaTime)
+	}
+}
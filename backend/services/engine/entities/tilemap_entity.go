@@ -1,5 +1,10 @@
 package entities
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // TileMapEntity representing a tilemap entity in the game engine.
 type TileMapEntity struct {
 	// Define the fields of the TileMapEntity struct here.
@@ -37,7 +42,50 @@ type Tileset struct {
 	Height     int `json:"pxHei"`
 	Identifier string
 	CustomData map[int]string  `json:"-"` // Key: tileID, Value: custom data string
-	Enums      map[int]EnumSet `json:"-"` // Key: enumValueID, Value: tileIDs (tile indices)
+	Enums      map[int]EnumSet `json:"-"` // Key: tileID, Value: enum values the tile is tagged with
 }
 
 type EnumSet []string
+
+// GridPoint is the value of a Point field: a cell of the level grid.
+type GridPoint struct {
+	CX int `json:"cx"`
+	CY int `json:"cy"`
+}
+
+// EntityRef is the value of an EntityRef field, e.g. the exit of a portal.
+type EntityRef struct {
+	EntityIID string `json:"entityIid"`
+	LayerIID  string `json:"layerIid"`
+	LevelIID  string `json:"levelIid"`
+	WorldIID  string `json:"worldIid"`
+}
+
+// DecodeProperties decodes the entity's field instances into target, see
+// DecodeProperties.
+func (t *TileMapEntity) DecodeProperties(target any) error {
+	return DecodeProperties(t.Properties, target)
+}
+
+// DecodeProperties decodes field instances into target, a pointer to a struct
+// whose json tags name the field identifiers. Point fields decode into
+// GridPoint, EntityRef fields into EntityRef, enums into strings and arrays
+// into slices. Fields missing from the properties keep their current value,
+// so defaults can be set before decoding; null fields are skipped as well.
+func DecodeProperties(properties []*Property, target any) error {
+	values := make(map[string]any, len(properties))
+	for _, p := range properties {
+		if p.Value != nil {
+			values[p.Identifier] = p.Value
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("encode properties: %w", err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("decode properties: %w", err)
+	}
+	return nil
+}
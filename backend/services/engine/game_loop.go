@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"errors"
+	"time"
+)
+
+// Clock is the source of real time for the game loop. Tests replace it with a
+// fake clock to step the simulation deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Start runs the game loop in its own goroutine. The loop wakes up once per
+// tick interval and calls Advance, so real time is converted into fixed
+// simulation ticks with the accumulator pattern.
+func (w *World) Start() error {
+	w.loopMu.Lock()
+	defer w.loopMu.Unlock()
+
+	if w.running {
+		return errors.New("world is already running")
+	}
+	w.running = true
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	w.lastTime = w.config.Clock.Now()
+	w.accumulator = 0
+
+	go w.run(w.stop, w.done)
+	return nil
+}
+
+// Stop halts the game loop and waits for the current tick to finish. Calling
+// Stop on a world that is not running is a no-op.
+func (w *World) Stop() {
+	w.loopMu.Lock()
+	if !w.running {
+		w.loopMu.Unlock()
+		return
+	}
+	w.running = false
+	stop, done := w.stop, w.done
+	w.loopMu.Unlock()
+
+	close(stop)
+	<-done
+}
+
+// IsRunning reports whether the game loop goroutine is active.
+func (w *World) IsRunning() bool {
+	w.loopMu.Lock()
+	defer w.loopMu.Unlock()
+	return w.running
+}
+
+func (w *World) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.config.TickRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.Advance()
+		}
+	}
+}
+
+// Advance reads the clock, adds the elapsed real time to the accumulator and
+// runs as many fixed ticks as fit into it. At most MaxTicksPerStep ticks run
+// per call; any further backlog is dropped so a slow tick cannot snowball.
+// It returns the number of ticks that were simulated.
+func (w *World) Advance() int {
+	w.loopMu.Lock()
+	now := w.config.Clock.Now()
+	if w.lastTime.IsZero() {
+		w.lastTime = now
+	}
+	w.accumulator += now.Sub(w.lastTime)
+	w.lastTime = now
+
+	steps := 0
+	for w.accumulator >= w.config.TickRate && steps < w.config.MaxTicksPerStep {
+		w.accumulator -= w.config.TickRate
+		steps++
+	}
+	if w.accumulator >= w.config.TickRate {
+		w.accumulator %= w.config.TickRate
+	}
+	w.loopMu.Unlock()
+
+	for i := 0; i < steps; i++ {
+		w.Tick()
+	}
+	return steps
+}
+
+// Alpha returns how far real time has progressed into the next tick, in
+// [0, 1). It is meant for interpolating between previous and current state.
+func (w *World) Alpha() float64 {
+	w.loopMu.Lock()
+	defer w.loopMu.Unlock()
+	return float64(w.accumulator) / float64(w.config.TickRate)
+}
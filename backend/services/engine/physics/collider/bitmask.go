@@ -2,6 +2,10 @@ package collider
 
 type bitmask uint32
 
+// Bitmask names the layer mask type outside of this package, e.g. for query
+// filters.
+type Bitmask = bitmask
+
 // Predefined layer constants
 const (
 	LayerPlayer     uint32 = 0
@@ -9,6 +13,7 @@ const (
 	LayerProjectile uint32 = 2
 	LayerWall       uint32 = 3
 	LayerTrigger    uint32 = 4
+	LayerWater      uint32 = 5
 )
 
 /**
@@ -28,6 +28,13 @@ func (c *Collider) CanCollideWith(other *Collider) bool {
 	return c.MatchMask.CanMatch(other.LayerMask)
 }
 
+// CanPairWith reports whether two colliders should be tested against each
+// other at all: both must be enabled and at least one side must match the
+// other's layer.
+func (c *Collider) CanPairWith(other *Collider) bool {
+	return c.CanCollideWith(other) || other.CanCollideWith(c)
+}
+
 func (c *Collider) GetShapes() []geometry.Shape {
 	return c.ShapeList
 }
@@ -57,7 +64,7 @@ func (c *Collider) GetRotatedShapes() []geometry.Shape {
 	rotatedShapes := make([]geometry.Shape, len(c.ShapeList))
 
 	for i, shape := range c.ShapeList {
-		rotatedShapes[i] = shape
+		rotatedShapes[i] = shape.Clone()
 		center := shape.GetCenter()
 
 		// Rotate point around origin
@@ -68,16 +75,42 @@ func (c *Collider) GetRotatedShapes() []geometry.Shape {
 		rotatedY := center.X*sin + center.Y*cos
 
 		rotatedShapes[i].SetCenter(geometry.Point(geometry.Vector2{X: rotatedX, Y: rotatedY}))
-
-		// If shape has rotation property (for rectangles, polygons)
-		if rotatable, ok := rotatedShapes[i].(interface{ SetRotation(float64) }); ok {
-			rotatable.SetRotation(c.Rotation)
-		}
+		rotatedShapes[i] = RotateShape(rotatedShapes[i], c.Rotation)
 	}
 
 	return rotatedShapes
 }
 
+// rotatable is implemented by shapes that carry their own orientation.
+type rotatable interface {
+	GetRotation() float64
+	SetRotation(float64)
+}
+
+// RotateShape turns shape by angle radians around its own center. Shapes are
+// modified in place where possible, so pass a clone, and the returned shape
+// must be used: axis-aligned rectangles are replaced by oriented rectangles so
+// that a rotated collider does not keep colliding as its unrotated box. Lines
+// turn their endpoints around their midpoint; circles are left unchanged.
+func RotateShape(shape geometry.Shape, angle float64) geometry.Shape {
+	if angle == 0 {
+		return shape
+	}
+	switch s := shape.(type) {
+	case *geometry.Rectangle:
+		return geometry.NewOrientedRectangle(s.Center, s.Width, s.Height, angle)
+	case *geometry.Line:
+		center := s.GetCenter()
+		half := geometry.Vector2{X: (s.End.X - s.Start.X) / 2, Y: (s.End.Y - s.Start.Y) / 2}
+		half = *half.Rotate(angle)
+		s.Start = geometry.Point{X: center.X - half.X, Y: center.Y - half.Y}
+		s.End = geometry.Point{X: center.X + half.X, Y: center.Y + half.Y}
+	case rotatable:
+		s.SetRotation(s.GetRotation() + angle)
+	}
+	return shape
+}
+
 // GetWorldSpaceShapes returns fully transformed shapes (rotation + translation)
 func (c *Collider) GetWorldSpaceShapes() []geometry.Shape {
 	worldShapes := make([]geometry.Shape, len(c.ShapeList))
@@ -93,21 +126,37 @@ func (c *Collider) GetWorldSpaceShapes() []geometry.Shape {
 		rotatedY := center.X*sin + center.Y*cos
 
 		// Then translate
-		worldShapes[i] = shape
+		worldShapes[i] = shape.Clone()
 		worldShapes[i].SetCenter(geometry.Point(geometry.Vector2{
 			X: rotatedX + c.Transform.X,
 			Y: rotatedY + c.Transform.Y,
 		}))
 
-		// Apply rotation to shape itself if supported
-		if rotatable, ok := worldShapes[i].(interface{ SetRotation(float64) }); ok {
-			rotatable.SetRotation(c.Rotation)
-		}
+		worldShapes[i] = RotateShape(worldShapes[i], c.Rotation)
 	}
 
 	return worldShapes
 }
 
+// GetBounds returns the world-space AABB enclosing every shape of the collider.
+// A collider without shapes collapses to a zero-sized box at its transform.
+func (c *Collider) GetBounds() geometry.Bounds {
+	shapes := c.GetWorldSpaceShapes()
+	if len(shapes) == 0 {
+		return geometry.Bounds{MinX: c.Transform.X, MinY: c.Transform.Y, MaxX: c.Transform.X, MaxY: c.Transform.Y}
+	}
+
+	bounds := shapes[0].GetBounds()
+	for _, shape := range shapes[1:] {
+		b := shape.GetBounds()
+		bounds.MinX = math.Min(bounds.MinX, b.MinX)
+		bounds.MinY = math.Min(bounds.MinY, b.MinY)
+		bounds.MaxX = math.Max(bounds.MaxX, b.MaxX)
+		bounds.MaxY = math.Max(bounds.MaxY, b.MaxY)
+	}
+	return bounds
+}
+
 // AddTransform applies relative transformation
 func (c *Collider) AddTransform(delta geometry.Vector2) {
 	c.Transform.X += delta.X
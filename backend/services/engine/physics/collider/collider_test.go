@@ -1,6 +1,7 @@
 package collider
 
 import (
+	"math"
 	"testing"
 
 	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
@@ -55,3 +56,71 @@ func TestCollider_LayerMatching(t *testing.T) {
 		})
 	}
 }
+
+func TestCollider_RotatedRectangleBecomesOriented(t *testing.T) {
+	c := &Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{Center: geometry.Point{X: 10, Y: 0}, Width: 4, Height: 2}},
+		Transform: geometry.Vector2{X: 100, Y: 100},
+		Rotation:  math.Pi / 2,
+	}
+
+	shapes := c.GetWorldSpaceShapes()
+	obb, ok := shapes[0].(*geometry.OrientedRectangle)
+	if !ok {
+		t.Fatalf("GetWorldSpaceShapes()[0] = %T, expected *geometry.OrientedRectangle", shapes[0])
+	}
+	if math.Abs(obb.Center.X-100) > 1e-9 || math.Abs(obb.Center.Y-110) > 1e-9 {
+		t.Errorf("Center = %+v, expected (100, 110)", obb.Center)
+	}
+	if obb.Rotation != math.Pi/2 {
+		t.Errorf("Rotation = %v, expected %v", obb.Rotation, math.Pi/2)
+	}
+
+	bounds := c.GetBounds()
+	if math.Abs(bounds.Width()-2) > 1e-9 || math.Abs(bounds.Height()-4) > 1e-9 {
+		t.Errorf("GetBounds() = %+v, expected a 2x4 box", bounds)
+	}
+	if _, ok := c.ShapeList[0].(*geometry.Rectangle); !ok {
+		t.Errorf("ShapeList[0] = %T, local shapes must not be modified", c.ShapeList[0])
+	}
+}
+
+func TestCollider_RotationComposesWithShapeRotation(t *testing.T) {
+	c := &Collider{
+		ShapeList: []geometry.Shape{
+			geometry.NewOrientedRectangle(geometry.Point{}, 4, 2, math.Pi/4),
+			&geometry.Line{Start: geometry.Point{X: -1, Y: 0}, End: geometry.Point{X: 1, Y: 0}},
+		},
+		Rotation: math.Pi / 4,
+	}
+
+	shapes := c.GetWorldSpaceShapes()
+	if got := shapes[0].(*geometry.OrientedRectangle).Rotation; math.Abs(got-math.Pi/2) > 1e-9 {
+		t.Errorf("OrientedRectangle rotation = %v, expected %v", got, math.Pi/2)
+	}
+	line := shapes[1].(*geometry.Line)
+	if math.Abs(line.End.X-math.Sqrt2/2) > 1e-9 || math.Abs(line.End.Y-math.Sqrt2/2) > 1e-9 {
+		t.Errorf("Line end = %+v, expected the line to turn with the collider", line.End)
+	}
+}
+
+func TestCollider_RotatesCapsule(t *testing.T) {
+	c := &Collider{
+		ShapeList: []geometry.Shape{geometry.NewCapsule(geometry.Point{}, 4, 1, 0)},
+		Transform: geometry.Vector2{X: 10, Y: 10},
+		Rotation:  math.Pi / 2,
+	}
+
+	capsule, ok := c.GetWorldSpaceShapes()[0].(*geometry.Capsule)
+	if !ok {
+		t.Fatalf("GetWorldSpaceShapes()[0] = %T, expected *geometry.Capsule", c.GetWorldSpaceShapes()[0])
+	}
+	if capsule.Rotation != math.Pi/2 {
+		t.Errorf("Rotation = %v, expected %v", capsule.Rotation, math.Pi/2)
+	}
+
+	bounds := c.GetBounds()
+	if math.Abs(bounds.Width()-2) > 1e-9 || math.Abs(bounds.Height()-6) > 1e-9 {
+		t.Errorf("GetBounds() = %+v, expected an upright 2x6 box", bounds)
+	}
+}
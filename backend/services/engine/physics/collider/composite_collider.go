@@ -3,11 +3,14 @@ package collider
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
 )
 
-// CompositeCollider - Multi-part collider for complex entities (bosses, vehicles)
+// CompositeCollider - Multi-part collider for complex entities (bosses, vehicles).
+// Parts must be changed through AddBodyPart, UpdateBodyPart and RemoveBodyPart
+// so that the embedded Collider's ShapeList stays in sync with them.
 type CompositeCollider struct {
 	*Collider
 	BodyParts map[string]*BodyPart
@@ -40,11 +43,13 @@ func (cc *CompositeCollider) AddBodyPart(name string, shape geometry.Shape, offs
 		LocalOffset:   offset,
 		LocalRotation: rotation,
 	}
+	cc.refreshShapes()
 }
 
 // RemoveBodyPart removes a body part by name
 func (cc *CompositeCollider) RemoveBodyPart(name string) {
 	delete(cc.BodyParts, name)
+	cc.refreshShapes()
 }
 
 // GetBodyPart returns a body part by name
@@ -66,54 +71,51 @@ func (cc *CompositeCollider) UpdateBodyPart(name string, offset *geometry.Vector
 	if rotation != nil {
 		part.LocalRotation = *rotation
 	}
+	cc.refreshShapes()
 
 	return nil
 }
 
-// GetWorldSpaceShapes returns all body parts transformed to world space
+// GetWorldSpaceShapes returns all body parts transformed to world space, in
+// the order of BodyPartNames.
 func (cc *CompositeCollider) GetWorldSpaceShapes() []geometry.Shape {
-	var allShapes []geometry.Shape
-
-	parentRotation := cc.Rotation
-	parentPos := cc.Transform
-
-	cos := math.Cos(parentRotation)
-	sin := math.Sin(parentRotation)
-
-	for _, part := range cc.BodyParts {
-		// Step 1: Rotate local offset around origin by parent rotation
-		rotatedOffsetX := part.LocalOffset.X*cos - part.LocalOffset.Y*sin
-		rotatedOffsetY := part.LocalOffset.X*sin + part.LocalOffset.Y*cos
-
-		// Step 2: Get shape's local center
-		localCenter := part.LocalShape.GetCenter()
-
-		// Step 3: Calculate total rotation (parent + local)
-		totalRotation := parentRotation + part.LocalRotation
-
-		// Step 4: Rotate shape center by total rotation
-		totalCos := math.Cos(totalRotation)
-		totalSin := math.Sin(totalRotation)
-
-		rotatedCenterX := localCenter.X*totalCos - localCenter.Y*totalSin
-		rotatedCenterY := localCenter.X*totalSin + localCenter.Y*totalCos
-
-		// Step 5: Create world space shape
-		worldShape := part.LocalShape
-		worldShape.SetCenter(geometry.Point(geometry.Vector2{
-			X: parentPos.X + rotatedOffsetX + rotatedCenterX,
-			Y: parentPos.Y + rotatedOffsetY + rotatedCenterY,
-		}))
+	return cc.Collider.GetWorldSpaceShapes()
+}
 
-		// Apply rotation if shape supports it
-		if rotatable, ok := worldShape.(interface{ SetRotation(float64) }); ok {
-			rotatable.SetRotation(totalRotation)
-		}
+// BodyPartNames returns the part names sorted, which is also the order of the
+// shapes in the embedded collider's ShapeList.
+func (cc *CompositeCollider) BodyPartNames() []string {
+	names := make([]string, 0, len(cc.BodyParts))
+	for name := range cc.BodyParts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
 
-		allShapes = append(allShapes, worldShape)
+// refreshShapes rebuilds the embedded collider's ShapeList from the body parts
+// in parent-local space, so the plain Collider (which is what the world and
+// the broad phase see) collides with every part.
+func (cc *CompositeCollider) refreshShapes() {
+	names := cc.BodyPartNames()
+	shapes := make([]geometry.Shape, 0, len(names))
+
+	for _, name := range names {
+		part := cc.BodyParts[name]
+
+		// Rotate the shape's own center by the part rotation, then offset it.
+		center := part.LocalShape.GetCenter()
+		cos, sin := math.Cos(part.LocalRotation), math.Sin(part.LocalRotation)
+
+		shape := part.LocalShape.Clone()
+		shape.SetCenter(geometry.Point{
+			X: part.LocalOffset.X + center.X*cos - center.Y*sin,
+			Y: part.LocalOffset.Y + center.X*sin + center.Y*cos,
+		})
+		shapes = append(shapes, RotateShape(shape, part.LocalRotation))
 	}
 
-	return allShapes
+	cc.ShapeList = shapes
 }
 
 // GetShapes returns world space shapes (override parent method)
@@ -123,33 +125,10 @@ func (cc *CompositeCollider) GetShapes() []geometry.Shape {
 
 // GetBounds returns the AABB bounding box containing all body parts
 func (cc *CompositeCollider) GetBounds() geometry.Rectangle {
-	shapes := cc.GetWorldSpaceShapes()
-	if len(shapes) == 0 {
-		return geometry.Rectangle{
-			Center: geometry.Point(cc.Transform),
-			Width:  0,
-			Height: 0,
-		}
-	}
-
-	// Calculate AABB from all shapes
-	firstBounds := shapes[0].GetBounds()
-	minX := firstBounds.MinX
-	minY := firstBounds.MinY
-	maxX := firstBounds.MaxX
-	maxY := firstBounds.MaxY
-
-	for i := 1; i < len(shapes); i++ {
-		bounds := shapes[i].GetBounds()
-		minX = math.Min(minX, bounds.MinX)
-		minY = math.Min(minY, bounds.MinY)
-		maxX = math.Max(maxX, bounds.MaxX)
-		maxY = math.Max(maxY, bounds.MaxY)
-	}
-
+	bounds := cc.Collider.GetBounds()
 	return geometry.Rectangle{
-		Center: *geometry.NewPoint(minX, minY),
-		Width:  maxX - minX,
-		Height: maxY - minY,
+		Center: geometry.Point{X: (bounds.MinX + bounds.MaxX) / 2, Y: (bounds.MinY + bounds.MaxY) / 2},
+		Width:  bounds.Width(),
+		Height: bounds.Height(),
 	}
 }
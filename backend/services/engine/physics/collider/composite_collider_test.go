@@ -0,0 +1,55 @@
+package collider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+func TestCompositeCollider_RotatedBodyPart(t *testing.T) {
+	// A long arm sticking out to the right, turned 90° by the part itself.
+	boss := NewCompositeCollider(geometry.Vector2{X: 0, Y: 0}, 0)
+	boss.AddBodyPart("torso", &geometry.Circle{Radius: 4}, geometry.Vector2{}, 0)
+	boss.AddBodyPart("arm", &geometry.Rectangle{Width: 20, Height: 2}, geometry.Vector2{X: 10, Y: 0}, math.Pi/2)
+
+	if names := boss.BodyPartNames(); len(names) != 2 || names[0] != "arm" || names[1] != "torso" {
+		t.Fatalf("BodyPartNames() = %v, expected [arm torso]", names)
+	}
+
+	// The embedded collider is what the world sees; it must hold the parts.
+	shapes := boss.Collider.GetWorldSpaceShapes()
+	if len(shapes) != 2 {
+		t.Fatalf("embedded collider has %d shapes, expected 2", len(shapes))
+	}
+	arm, ok := shapes[0].(*geometry.OrientedRectangle)
+	if !ok {
+		t.Fatalf("arm shape = %T, expected *geometry.OrientedRectangle", shapes[0])
+	}
+
+	// The arm now points up and down at x=10; as an AABB it would have
+	// covered (18, 0) as well.
+	if !arm.IntersectsCircle(&geometry.Circle{Center: geometry.Point{X: 10, Y: 8}, Radius: 0.5}) {
+		t.Errorf("expected the rotated arm to reach (10, 8)")
+	}
+	if arm.IntersectsCircle(&geometry.Circle{Center: geometry.Point{X: 18, Y: 0}, Radius: 0.5}) {
+		t.Errorf("expected the rotated arm not to reach (18, 0)")
+	}
+
+	// Turning the boss turns the arm with it.
+	boss.SetRotation(math.Pi / 2)
+	arm = boss.GetWorldSpaceShapes()[0].(*geometry.OrientedRectangle)
+	if math.Abs(arm.Center.X) > 1e-9 || math.Abs(arm.Center.Y-10) > 1e-9 || math.Abs(arm.Rotation-math.Pi) > 1e-9 {
+		t.Errorf("arm = %+v, expected center (0, 10) and rotation pi", *arm)
+	}
+
+	bounds := boss.GetBounds()
+	if math.Abs(bounds.Center.X) > 1e-9 || math.Abs(bounds.Width-20) > 1e-9 {
+		t.Errorf("GetBounds() = %+v, expected a 20 wide box centered on x=0", bounds)
+	}
+
+	boss.RemoveBodyPart("arm")
+	if len(boss.ShapeList) != 1 {
+		t.Errorf("ShapeList has %d shapes after RemoveBodyPart, expected 1", len(boss.ShapeList))
+	}
+}
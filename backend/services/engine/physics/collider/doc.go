@@ -1,2 +1,4 @@
 // Package collider provides collision detection and response functionality.
+// Colliders are placed in world units; map data in LDtk pixels or grid cells
+// is converted with the coordinate package before it reaches a collider.
 package collider
@@ -0,0 +1,314 @@
+package collision
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// Impact describes the first contact of a shape swept along a straight path.
+type Impact struct {
+	Time     float64          // fraction of the path travelled before contact, in [0, 1]
+	Position geometry.Point   // center of the swept shape (or collider transform) at contact
+	Normal   geometry.Vector2 // target surface normal at contact, pointing towards the swept shape
+	Contact  geometry.Point   // contact point on the target
+}
+
+// SweepCircle moves a circle of the given radius from one point to another
+// and reports the first contact with target. A circle that already overlaps
+// target hits it at time 0 unless it is moving away from it.
+func SweepCircle(from, to geometry.Point, radius float64, target geometry.Shape) (*Impact, bool) {
+	d := sub(to, from)
+	if impact, overlapping := startOverlap(&geometry.Circle{Center: from, Radius: radius}, from, d, target); overlapping {
+		return impact, impact != nil
+	}
+
+	switch t := target.(type) {
+	case *geometry.Circle:
+		hit, ok := rayCircle(from, d, t.Center, radius+t.Radius)
+		if !ok {
+			return nil, false
+		}
+		position := addScaled(from, d, hit)
+		normal := normalize(sub(position, t.Center))
+		return &Impact{Time: hit, Position: position, Normal: normal, Contact: addScaled(t.Center, normal, t.Radius)}, true
+	case *geometry.Capsule:
+		// The circle touches the capsule when its center reaches the segment
+		// rounded by both radii.
+		impact, ok := sweepCircleVertices(from, d, radius+t.Radius, capsuleVertices(t))
+		if !ok {
+			return nil, false
+		}
+		impact.Contact = addScaled(impact.Position, impact.Normal, -radius)
+		return impact, true
+	case geometry.ConvexShape:
+		return sweepCircleVertices(from, d, radius, counterClockwise(t.Vertices()))
+	}
+	return nil, false
+}
+
+// SweepAABB moves an axis-aligned box with the given half extents from one
+// center to another and reports the first contact with target. A box that
+// already overlaps target hits it at time 0 unless it is moving away from it.
+func SweepAABB(from, to geometry.Point, halfWidth, halfHeight float64, target geometry.Shape) (*Impact, bool) {
+	d := sub(to, from)
+	box := &geometry.Rectangle{Center: from, Width: 2 * halfWidth, Height: 2 * halfHeight}
+	if impact, overlapping := startOverlap(box, from, d, target); overlapping {
+		return impact, impact != nil
+	}
+
+	switch t := target.(type) {
+	case *geometry.Circle:
+		// Sweep the circle backwards against the resting box; the time of
+		// impact is the same and the normal flips sides.
+		impact, ok := sweepCircleVertices(t.Center, negate(d), t.Radius, box.Vertices())
+		if !ok {
+			return nil, false
+		}
+		normal := negate(impact.Normal)
+		return &Impact{
+			Time:     impact.Time,
+			Position: addScaled(from, d, impact.Time),
+			Normal:   normal,
+			Contact:  addScaled(t.Center, normal, t.Radius),
+		}, true
+	case *geometry.Capsule:
+		// The box touches the capsule when its center lies within Radius of
+		// the Minkowski sum of the capsule's segment and the box.
+		impact, ok := sweepCircleVertices(from, d, t.Radius, boxSum(capsuleVertices(t), halfWidth, halfHeight))
+		if !ok {
+			return nil, false
+		}
+		box.Center = impact.Position
+		impact.Contact = boxContact(box, target, impact.Position)
+		return impact, true
+	case geometry.ConvexShape:
+		// The box touches the target exactly when its center lies on the
+		// Minkowski sum of the target and the box.
+		hit, normal, ok := rayConvex(from, d, boxSum(t.Vertices(), halfWidth, halfHeight))
+		if !ok {
+			return nil, false
+		}
+		position := addScaled(from, d, hit)
+		box.Center = position
+		return &Impact{Time: hit, Position: position, Normal: normal, Contact: boxContact(box, target, position)}, true
+	}
+	return nil, false
+}
+
+// boxSum returns the Minkowski sum of a vertex list and a box with the given
+// half extents, as a convex hull in counter-clockwise order.
+func boxSum(verts []geometry.Point, halfWidth, halfHeight float64) []geometry.Point {
+	corners := []geometry.Vector2{{X: -halfWidth, Y: -halfHeight}, {X: halfWidth, Y: -halfHeight}, {X: halfWidth, Y: halfHeight}, {X: -halfWidth, Y: halfHeight}}
+	sum := make([]geometry.Point, 0, len(corners)*len(verts))
+	for _, v := range verts {
+		for _, c := range corners {
+			sum = append(sum, addScaled(v, c, 1))
+		}
+	}
+	return convexHull(sum)
+}
+
+// boxContact returns where a box placed at its point of impact touches
+// target, or fallback if the shapes report no contact.
+func boxContact(box *geometry.Rectangle, target geometry.Shape, fallback geometry.Point) geometry.Point {
+	if m, ok, _ := Collide(box, target); ok && len(m.Contacts) > 0 {
+		return m.Contacts[0]
+	}
+	return fallback
+}
+
+// capsuleVertices returns the segment of a capsule as a two-vertex list.
+// Rounded by the capsule's radius it is the capsule itself.
+func capsuleVertices(c *geometry.Capsule) []geometry.Point {
+	s := c.Segment()
+	return []geometry.Point{s.Start, s.End}
+}
+
+// SweepCollider moves body's transform from one position to another and
+// reports its first contact with any shape of target, which stays where it
+// is. Circles are swept exactly; every other shape is swept as its bounding
+// box, which errs on the side of reporting the impact early. The impact
+// Position is the collider transform at contact.
+func SweepCollider(body *collider.Collider, from, to geometry.Vector2, target *collider.Collider) (*Impact, bool) {
+	placed := *body
+	placed.Transform = from
+	d := geometry.Vector2{X: to.X - from.X, Y: to.Y - from.Y}
+
+	var first *Impact
+	for _, shape := range placed.GetWorldSpaceShapes() {
+		for _, other := range target.GetWorldSpaceShapes() {
+			var impact *Impact
+			var ok bool
+			switch s := shape.(type) {
+			case *geometry.Circle:
+				impact, ok = SweepCircle(s.Center, addScaled(s.Center, d, 1), s.Radius, other)
+			default:
+				b := s.GetBounds()
+				center := geometry.Point{X: (b.MinX + b.MaxX) / 2, Y: (b.MinY + b.MaxY) / 2}
+				impact, ok = SweepAABB(center, addScaled(center, d, 1), b.Width()/2, b.Height()/2, other)
+			}
+			if ok && (first == nil || impact.Time < first.Time) {
+				first = impact
+			}
+		}
+	}
+	if first == nil {
+		return nil, false
+	}
+
+	first.Position = geometry.Point{X: from.X + d.X*first.Time, Y: from.Y + d.Y*first.Time}
+	return first, true
+}
+
+// startOverlap handles shapes that already touch target before moving. It
+// returns overlapping=false when the sweep has to run, and a nil impact when
+// the shape is moving out of target and should not be stopped.
+func startOverlap(shape geometry.Shape, from geometry.Point, d geometry.Vector2, target geometry.Shape) (*Impact, bool) {
+	m, ok, err := Collide(shape, target)
+	if err != nil || !ok || m.Depth <= epsilon {
+		return nil, false
+	}
+	if dot(d, m.Normal) <= 0 {
+		return nil, true
+	}
+	return &Impact{Position: from, Normal: negate(m.Normal), Contact: m.Contacts[0]}, true
+}
+
+// sweepCircleVertices sweeps a circle along d against a convex vertex list in
+// counter-clockwise order. The rounded Minkowski sum is tested as the edges
+// pushed out by radius plus a circle around every vertex.
+func sweepCircleVertices(from geometry.Point, d geometry.Vector2, radius float64, verts []geometry.Point) (*Impact, bool) {
+	best := math.Inf(1)
+	var normal geometry.Vector2
+
+	for i := range verts {
+		p1, p2 := verts[i], verts[(i+1)%len(verts)]
+		n := outwardNormal(p1, p2)
+		if n.X == 0 && n.Y == 0 {
+			continue
+		}
+		approach := dot(d, n)
+		if approach >= 0 {
+			continue
+		}
+		start := addScaled(p1, n, radius)
+		t := dot(sub(start, from), n) / approach
+		if t < 0 || t > 1 || t >= best {
+			continue
+		}
+		edge := sub(p2, p1)
+		s := dot(sub(addScaled(from, d, t), start), edge) / dot(edge, edge)
+		if s >= 0 && s <= 1 {
+			best, normal = t, n
+		}
+	}
+
+	for _, v := range verts {
+		t, ok := rayCircle(from, d, v, radius)
+		if ok && t < best {
+			best, normal = t, normalize(sub(addScaled(from, d, t), v))
+		}
+	}
+
+	if math.IsInf(best, 1) {
+		return nil, false
+	}
+	position := addScaled(from, d, best)
+	return &Impact{Time: best, Position: position, Normal: normal, Contact: addScaled(position, normal, -radius)}, true
+}
+
+// rayCircle returns the first t in [0, 1] at which from + d*t lies on the
+// circle, for a point starting outside of it.
+func rayCircle(from geometry.Point, d geometry.Vector2, center geometry.Point, radius float64) (float64, bool) {
+	m := sub(from, center)
+	a := dot(d, d)
+	b := dot(m, d)
+	c := dot(m, m) - radius*radius
+	if a == 0 || b >= 0 {
+		return 0, false // not moving, or moving away
+	}
+	disc := b*b - a*c
+	if disc < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(disc)) / a
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// rayConvex clips the segment from + d*[0, 1] against a convex polygon in
+// counter-clockwise order (Cyrus-Beck) and returns the entry time and the
+// normal of the edge it enters through.
+func rayConvex(from geometry.Point, d geometry.Vector2, verts []geometry.Point) (float64, geometry.Vector2, bool) {
+	enter, exit := 0.0, 1.0
+	var normal geometry.Vector2
+
+	for i := range verts {
+		p1, p2 := verts[i], verts[(i+1)%len(verts)]
+		n := outwardNormal(p1, p2)
+		if n.X == 0 && n.Y == 0 {
+			continue
+		}
+		dist := dot(sub(p1, from), n) // > 0 while from is inside this edge
+		denom := dot(d, n)
+		if denom == 0 {
+			if dist < 0 {
+				return 0, normal, false
+			}
+			continue
+		}
+		t := dist / denom
+		if denom < 0 {
+			if t > enter {
+				enter, normal = t, n
+			}
+		} else if t < exit {
+			exit = t
+		}
+		if enter > exit {
+			return 0, normal, false
+		}
+	}
+
+	if normal.X == 0 && normal.Y == 0 {
+		return 0, normal, false // started inside, handled by startOverlap
+	}
+	return enter, normal, true
+}
+
+// convexHull returns the convex hull of points in counter-clockwise order
+// (Andrew's monotone chain).
+func convexHull(points []geometry.Point) []geometry.Point {
+	sorted := make([]geometry.Point, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	hull := make([]geometry.Point, 0, 2*len(sorted))
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range sorted {
+			for len(hull) >= start+2 && cross(sub(hull[len(hull)-1], hull[len(hull)-2]), sub(p, hull[len(hull)-2])) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+	return hull
+}
@@ -0,0 +1,287 @@
+package collision
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+func assertImpact(t *testing.T, impact *Impact, time float64, position geometry.Point, normal geometry.Vector2) {
+	t.Helper()
+	if !almostEqual(impact.Time, time) {
+		t.Errorf("Time = %v, expected %v", impact.Time, time)
+	}
+	if !almostEqual(impact.Position.X, position.X) || !almostEqual(impact.Position.Y, position.Y) {
+		t.Errorf("Position = %+v, expected %+v", impact.Position, position)
+	}
+	if !almostEqual(impact.Normal.X, normal.X) || !almostEqual(impact.Normal.Y, normal.Y) {
+		t.Errorf("Normal = %+v, expected %+v", impact.Normal, normal)
+	}
+}
+
+// thinWall is a 2 unit thick wall spanning y in [-50, 50] at x = 50.
+func thinWall() *geometry.Rectangle {
+	return &geometry.Rectangle{Center: geometry.Point{X: 50, Y: 0}, Width: 2, Height: 100}
+}
+
+// standingCapsule is an upright character body of radius 1 spanning y in
+// [-6, 6] at x = 50.
+func standingCapsule() *geometry.Capsule {
+	return geometry.NewCapsule(geometry.Point{X: 50, Y: 0}, 10, 1, math.Pi/2)
+}
+
+func TestSweepCircle(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to geometry.Point
+		radius   float64
+		target   geometry.Shape
+		hit      bool
+		time     float64
+		position geometry.Point
+		normal   geometry.Vector2
+	}{
+		{
+			name:     "Passes through thin wall in one step",
+			from:     geometry.Point{X: 0, Y: 0},
+			to:       geometry.Point{X: 100, Y: 0},
+			radius:   1,
+			target:   thinWall(),
+			hit:      true,
+			time:     0.48,
+			position: geometry.Point{X: 48, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Clips wall corner",
+			from:     geometry.Point{X: 0, Y: 51},
+			to:       geometry.Point{X: 100, Y: 51},
+			radius:   2,
+			target:   thinWall(),
+			hit:      true,
+			time:     (49 - math.Sqrt(3)) / 100,
+			position: geometry.Point{X: 49 - math.Sqrt(3), Y: 51},
+			normal:   geometry.Vector2{X: -math.Sqrt(3) / 2, Y: 0.5},
+		},
+		{
+			name:   "Misses wall",
+			from:   geometry.Point{X: 0, Y: 60},
+			to:     geometry.Point{X: 100, Y: 60},
+			radius: 2,
+			target: thinWall(),
+		},
+		{
+			name:   "Stops short of wall",
+			from:   geometry.Point{X: 0, Y: 0},
+			to:     geometry.Point{X: 40, Y: 0},
+			radius: 2,
+			target: thinWall(),
+		},
+		{
+			name:     "Hits circle",
+			from:     geometry.Point{X: 0, Y: 0},
+			to:       geometry.Point{X: 20, Y: 0},
+			radius:   1,
+			target:   &geometry.Circle{Center: geometry.Point{X: 10, Y: 0}, Radius: 2},
+			hit:      true,
+			time:     0.35,
+			position: geometry.Point{X: 7, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Crosses line",
+			from:     geometry.Point{X: 0, Y: -10},
+			to:       geometry.Point{X: 0, Y: 10},
+			radius:   1,
+			target:   &geometry.Line{Start: geometry.Point{X: -5, Y: 0}, End: geometry.Point{X: 5, Y: 0}},
+			hit:      true,
+			time:     0.45,
+			position: geometry.Point{X: 0, Y: -1},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+		},
+		{
+			name:     "Passes through capsule in one step",
+			from:     geometry.Point{X: 0, Y: 0},
+			to:       geometry.Point{X: 100, Y: 0},
+			radius:   1,
+			target:   standingCapsule(),
+			hit:      true,
+			time:     0.48,
+			position: geometry.Point{X: 48, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Lands on capsule top",
+			from:     geometry.Point{X: 50, Y: -20},
+			to:       geometry.Point{X: 50, Y: 0},
+			radius:   1,
+			target:   standingCapsule(),
+			hit:      true,
+			time:     0.65,
+			position: geometry.Point{X: 50, Y: -7},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+		},
+		{
+			name:   "Misses capsule",
+			from:   geometry.Point{X: 0, Y: 8},
+			to:     geometry.Point{X: 100, Y: 8},
+			radius: 1,
+			target: standingCapsule(),
+		},
+		{
+			name:     "Starts overlapping and moves in",
+			from:     geometry.Point{X: 49, Y: 0},
+			to:       geometry.Point{X: 60, Y: 0},
+			radius:   1,
+			target:   thinWall(),
+			hit:      true,
+			time:     0,
+			position: geometry.Point{X: 49, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:   "Starts overlapping and moves out",
+			from:   geometry.Point{X: 49, Y: 0},
+			to:     geometry.Point{X: 0, Y: 0},
+			radius: 1,
+			target: thinWall(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impact, ok := SweepCircle(tt.from, tt.to, tt.radius, tt.target)
+			if ok != tt.hit {
+				t.Fatalf("SweepCircle() hit = %v, expected %v", ok, tt.hit)
+			}
+			if tt.hit {
+				assertImpact(t, impact, tt.time, tt.position, tt.normal)
+			}
+		})
+	}
+}
+
+func TestSweepCircle_CapsuleContact(t *testing.T) {
+	impact, ok := SweepCircle(geometry.Point{X: 0, Y: 0}, geometry.Point{X: 100, Y: 0}, 1, standingCapsule())
+	if !ok {
+		t.Fatalf("SweepCircle() hit = false, expected a hit")
+	}
+	if !almostEqual(impact.Contact.X, 49) || !almostEqual(impact.Contact.Y, 0) {
+		t.Errorf("Contact = %+v, expected the capsule surface at (49, 0)", impact.Contact)
+	}
+}
+
+func TestSweepAABB(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to geometry.Point
+		target   geometry.Shape
+		hit      bool
+		time     float64
+		position geometry.Point
+		normal   geometry.Vector2
+	}{
+		{
+			name:     "Passes through thin wall in one step",
+			from:     geometry.Point{X: 0, Y: 0},
+			to:       geometry.Point{X: 100, Y: 0},
+			target:   thinWall(),
+			hit:      true,
+			time:     0.47,
+			position: geometry.Point{X: 47, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Falls onto wall top",
+			from:     geometry.Point{X: 50, Y: -100},
+			to:       geometry.Point{X: 50, Y: 0},
+			target:   thinWall(),
+			hit:      true,
+			time:     0.48,
+			position: geometry.Point{X: 50, Y: -52},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+		},
+		{
+			name:   "Misses wall",
+			from:   geometry.Point{X: 0, Y: 53},
+			to:     geometry.Point{X: 100, Y: 53},
+			target: thinWall(),
+		},
+		{
+			name:     "Hits circle",
+			from:     geometry.Point{X: 0, Y: 0},
+			to:       geometry.Point{X: 20, Y: 0},
+			target:   &geometry.Circle{Center: geometry.Point{X: 10, Y: 0}, Radius: 2},
+			hit:      true,
+			time:     0.3,
+			position: geometry.Point{X: 6, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Passes through capsule in one step",
+			from:     geometry.Point{X: 0, Y: 0},
+			to:       geometry.Point{X: 100, Y: 0},
+			target:   standingCapsule(),
+			hit:      true,
+			time:     0.47,
+			position: geometry.Point{X: 47, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Falls onto capsule top",
+			from:     geometry.Point{X: 50, Y: -20},
+			to:       geometry.Point{X: 50, Y: 0},
+			target:   standingCapsule(),
+			hit:      true,
+			time:     0.6,
+			position: geometry.Point{X: 50, Y: -8},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+		},
+		{
+			name:     "Crosses line",
+			from:     geometry.Point{X: 0, Y: -10},
+			to:       geometry.Point{X: 0, Y: 10},
+			target:   &geometry.Line{Start: geometry.Point{X: -5, Y: 0}, End: geometry.Point{X: 5, Y: 0}},
+			hit:      true,
+			time:     0.4,
+			position: geometry.Point{X: 0, Y: -2},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impact, ok := SweepAABB(tt.from, tt.to, 2, 2, tt.target)
+			if ok != tt.hit {
+				t.Fatalf("SweepAABB() hit = %v, expected %v", ok, tt.hit)
+			}
+			if tt.hit {
+				assertImpact(t, impact, tt.time, tt.position, tt.normal)
+			}
+		})
+	}
+}
+
+func TestSweepCollider_EarliestShape(t *testing.T) {
+	// A bullet made of a circle and a box trailing behind it.
+	bullet := &collider.Collider{
+		ShapeList: []geometry.Shape{
+			&geometry.Circle{Center: geometry.Point{X: 0, Y: 0}, Radius: 1},
+			&geometry.Rectangle{Center: geometry.Point{X: -4, Y: 0}, Width: 4, Height: 2},
+		},
+		Enabled: true,
+	}
+	wall := &collider.Collider{ShapeList: []geometry.Shape{thinWall()}, Enabled: true}
+
+	impact, ok := SweepCollider(bullet, geometry.Vector2{X: 0, Y: 0}, geometry.Vector2{X: 100, Y: 0}, wall)
+	if !ok {
+		t.Fatalf("SweepCollider() expected a hit")
+	}
+	assertImpact(t, impact, 0.48, geometry.Point{X: 48, Y: 0}, geometry.Vector2{X: -1, Y: 0})
+
+	if _, ok := SweepCollider(bullet, geometry.Vector2{X: 0, Y: 0}, geometry.Vector2{X: 0, Y: 100}, wall); ok {
+		t.Errorf("SweepCollider() parallel to the wall should not hit")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
 )
 
+// CollisionBody places a collider at a position. Radius is a bounding circle
+// around the position used to reject far away bodies before any shape test;
+// a radius of 0 disables the early-out.
 type CollisionBody struct {
 	transform geometry.Vector2
 	Radius    float64
@@ -18,3 +21,53 @@ func NewCollisionBody(transform geometry.Vector2, radius float64, collider colli
 		collider:  collider,
 	}
 }
+
+func (b *CollisionBody) Transform() geometry.Vector2 {
+	return b.transform
+}
+
+func (b *CollisionBody) SetTransform(transform geometry.Vector2) {
+	b.transform = transform
+}
+
+func (b *CollisionBody) Collider() *collider.Collider {
+	return &b.collider
+}
+
+// WorldShapes returns the collider's shapes placed at the body's transform.
+func (b *CollisionBody) WorldShapes() []geometry.Shape {
+	placed := b.collider
+	placed.Transform = b.transform
+	return placed.GetWorldSpaceShapes()
+}
+
+// Collide runs the narrow phase against other and returns the deepest
+// manifold between any pair of their shapes. The normal points from b
+// towards other.
+func (b *CollisionBody) Collide(other *CollisionBody) (*Manifold, bool) {
+	if !b.collider.CanPairWith(&other.collider) {
+		return nil, false
+	}
+	if b.Radius > 0 && other.Radius > 0 {
+		dx := other.transform.X - b.transform.X
+		dy := other.transform.Y - b.transform.Y
+		reach := b.Radius + other.Radius
+		if dx*dx+dy*dy > reach*reach {
+			return nil, false
+		}
+	}
+
+	var deepest *Manifold
+	for _, sa := range b.WorldShapes() {
+		for _, sb := range other.WorldShapes() {
+			m, ok, err := Collide(sa, sb)
+			if err != nil || !ok {
+				continue
+			}
+			if deepest == nil || m.Depth > deepest.Depth {
+				deepest = m
+			}
+		}
+	}
+	return deepest, deepest != nil
+}
@@ -0,0 +1,526 @@
+package collision
+
+import (
+	"math"
+	"sync"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// epsilon absorbs floating point noise in the narrow phase.
+const epsilon = 1e-9
+
+// ManifoldFunc computes the manifold between two shapes of known types. It
+// returns false when the shapes do not touch.
+type ManifoldFunc func(a, b geometry.Shape) (*Manifold, bool)
+
+type typePair struct {
+	a, b string
+}
+
+var (
+	dispatchMu sync.RWMutex
+	dispatch   = make(map[typePair]ManifoldFunc)
+)
+
+func init() {
+	RegisterManifoldFunc(geometry.CircleType, geometry.CircleType, circleCircle)
+	RegisterManifoldFunc(geometry.CircleType, geometry.RectangleType, circleConvex)
+	RegisterManifoldFunc(geometry.CircleType, geometry.LineType, circleConvex)
+	RegisterManifoldFunc(geometry.RectangleType, geometry.RectangleType, convexConvex)
+	RegisterManifoldFunc(geometry.RectangleType, geometry.LineType, convexConvex)
+	RegisterManifoldFunc(geometry.LineType, geometry.LineType, convexConvex)
+	RegisterManifoldFunc(geometry.CircleType, geometry.OrientedRectangleType, circleConvex)
+	RegisterManifoldFunc(geometry.OrientedRectangleType, geometry.OrientedRectangleType, convexConvex)
+	RegisterManifoldFunc(geometry.OrientedRectangleType, geometry.RectangleType, convexConvex)
+	RegisterManifoldFunc(geometry.OrientedRectangleType, geometry.LineType, convexConvex)
+	RegisterManifoldFunc(geometry.CircleType, geometry.PolygonType, circleConvex)
+	RegisterManifoldFunc(geometry.PolygonType, geometry.PolygonType, convexConvex)
+	RegisterManifoldFunc(geometry.PolygonType, geometry.RectangleType, convexConvex)
+	RegisterManifoldFunc(geometry.PolygonType, geometry.OrientedRectangleType, convexConvex)
+	RegisterManifoldFunc(geometry.PolygonType, geometry.LineType, convexConvex)
+	RegisterManifoldFunc(geometry.CircleType, geometry.CapsuleType, circleCapsule)
+	RegisterManifoldFunc(geometry.CapsuleType, geometry.CapsuleType, capsuleCapsule)
+	RegisterManifoldFunc(geometry.CapsuleType, geometry.RectangleType, capsuleConvex)
+	RegisterManifoldFunc(geometry.CapsuleType, geometry.OrientedRectangleType, capsuleConvex)
+	RegisterManifoldFunc(geometry.CapsuleType, geometry.PolygonType, capsuleConvex)
+	RegisterManifoldFunc(geometry.CapsuleType, geometry.LineType, capsuleConvex)
+}
+
+// RegisterManifoldFunc registers fn for shapes of typeA against shapes of
+// typeB, as returned by Shape.GetType. The reversed order is registered as
+// well, with the resulting manifold flipped.
+func RegisterManifoldFunc(typeA, typeB string, fn ManifoldFunc) {
+	dispatchMu.Lock()
+	defer dispatchMu.Unlock()
+
+	dispatch[typePair{typeA, typeB}] = fn
+	if typeA != typeB {
+		dispatch[typePair{typeB, typeA}] = func(a, b geometry.Shape) (*Manifold, bool) {
+			m, ok := fn(b, a)
+			if !ok {
+				return nil, false
+			}
+			return m.Flipped(), true
+		}
+	}
+}
+
+// Collide runs the narrow phase between two world-space shapes. It returns a
+// CollisionSystemError when no ManifoldFunc is registered for the pair.
+func Collide(a, b geometry.Shape) (*Manifold, bool, error) {
+	dispatchMu.RLock()
+	fn, exists := dispatch[typePair{a.GetType(), b.GetType()}]
+	dispatchMu.RUnlock()
+
+	if !exists {
+		return nil, false, NewCollisionSystemError("no narrow phase for " + a.GetType() + " vs " + b.GetType())
+	}
+	m, ok := fn(a, b)
+	return m, ok, nil
+}
+
+// CollideColliders tests every world-space shape of a against every one of b
+// and returns the deepest manifold. Shape pairs without a registered
+// ManifoldFunc are skipped.
+func CollideColliders(a, b *collider.Collider) (*Manifold, bool) {
+	var deepest *Manifold
+	for _, sa := range a.GetWorldSpaceShapes() {
+		for _, sb := range b.GetWorldSpaceShapes() {
+			m, ok, err := Collide(sa, sb)
+			if err != nil || !ok {
+				continue
+			}
+			if deepest == nil || m.Depth > deepest.Depth {
+				deepest = m
+			}
+		}
+	}
+	return deepest, deepest != nil
+}
+
+// ---------------------------------------------------------------------------
+// Circle vs circle
+// ---------------------------------------------------------------------------
+
+func circleCircle(a, b geometry.Shape) (*Manifold, bool) {
+	ca, cb := a.(*geometry.Circle), b.(*geometry.Circle)
+
+	d := sub(cb.Center, ca.Center)
+	distSq := d.X*d.X + d.Y*d.Y
+	radii := ca.Radius + cb.Radius
+	if distSq > radii*radii {
+		return nil, false
+	}
+
+	dist := math.Sqrt(distSq)
+	normal := geometry.Vector2{X: 1, Y: 0}
+	if dist > epsilon {
+		normal = geometry.Vector2{X: d.X / dist, Y: d.Y / dist}
+	}
+	depth := radii - dist
+
+	return &Manifold{
+		Normal:   normal,
+		Depth:    depth,
+		Contacts: []geometry.Point{addScaled(ca.Center, normal, ca.Radius-depth/2)},
+	}, true
+}
+
+// ---------------------------------------------------------------------------
+// Circle vs convex shape (rectangles, segments, polygons...)
+// ---------------------------------------------------------------------------
+
+func circleConvex(a, b geometry.Shape) (*Manifold, bool) {
+	circle := a.(*geometry.Circle)
+	verts := counterClockwise(b.(geometry.ConvexShape).Vertices())
+	return circleVertices(circle.Center, circle.Radius, verts)
+}
+
+// circleVertices computes the manifold between a circle and a convex vertex
+// list in counter-clockwise order. Two-vertex lists are treated as segments.
+func circleVertices(center geometry.Point, radius float64, verts []geometry.Point) (*Manifold, bool) {
+	inside := len(verts) > 2
+	closest := verts[0]
+	closestDistSq := math.Inf(1)
+	closestEdge := 0
+
+	for i := range verts {
+		p1, p2 := verts[i], verts[(i+1)%len(verts)]
+		q := closestPointOnSegment(center, p1, p2)
+		d := sub(center, q)
+		if distSq := d.X*d.X + d.Y*d.Y; distSq < closestDistSq {
+			closest, closestDistSq, closestEdge = q, distSq, i
+		}
+		if cross(sub(p2, p1), sub(center, p1)) < 0 {
+			inside = false
+		}
+	}
+
+	dist := math.Sqrt(closestDistSq)
+	if !inside && dist > radius {
+		return nil, false
+	}
+
+	edgeNormal := outwardNormal(verts[closestEdge], verts[(closestEdge+1)%len(verts)])
+	if inside {
+		// The circle has to travel out through the nearest edge, so B lies
+		// behind that edge from the circle's point of view.
+		return &Manifold{
+			Normal:   negate(edgeNormal),
+			Depth:    radius + dist,
+			Contacts: []geometry.Point{closest},
+		}, true
+	}
+
+	normal := negate(edgeNormal)
+	if dist > epsilon {
+		d := sub(closest, center)
+		normal = geometry.Vector2{X: d.X / dist, Y: d.Y / dist}
+	}
+
+	return &Manifold{
+		Normal:   normal,
+		Depth:    radius - dist,
+		Contacts: []geometry.Point{closest},
+	}, true
+}
+
+// ---------------------------------------------------------------------------
+// Convex vs convex (SAT with contact clipping)
+// ---------------------------------------------------------------------------
+
+func convexConvex(a, b geometry.Shape) (*Manifold, bool) {
+	va := counterClockwise(a.(geometry.ConvexShape).Vertices())
+	vb := counterClockwise(b.(geometry.ConvexShape).Vertices())
+	return convexVertices(va, vb)
+}
+
+// convexVertices runs the separating axis test on two convex vertex lists in
+// counter-clockwise order and clips the incident edge against the reference
+// edge to find up to two contact points.
+func convexVertices(va, vb []geometry.Point) (*Manifold, bool) {
+	depth := math.Inf(1)
+	var normal geometry.Vector2
+
+	for _, axis := range separatingAxes(va, vb) {
+		minA, maxA := project(va, axis)
+		minB, maxB := project(vb, axis)
+
+		forward := maxA - minB  // push B along +axis
+		backward := maxB - minA // push B along -axis
+		if forward < -epsilon || backward < -epsilon {
+			return nil, false
+		}
+		if forward < depth {
+			depth, normal = forward, axis
+		}
+		if backward < depth {
+			depth, normal = backward, negate(axis)
+		}
+	}
+
+	if math.IsInf(depth, 1) {
+		return nil, false
+	}
+
+	return &Manifold{
+		Normal:   normal,
+		Depth:    math.Max(depth, 0),
+		Contacts: clipContacts(va, vb, normal),
+	}, true
+}
+
+// separatingAxes returns the edge normals of both vertex lists. Segments also
+// contribute their direction, otherwise two collinear segments could never be
+// separated.
+func separatingAxes(va, vb []geometry.Point) []geometry.Vector2 {
+	axes := make([]geometry.Vector2, 0, len(va)+len(vb)+2)
+	for _, verts := range [][]geometry.Point{va, vb} {
+		for i := range verts {
+			axis := outwardNormal(verts[i], verts[(i+1)%len(verts)])
+			if axis.X == 0 && axis.Y == 0 {
+				continue
+			}
+			axes = append(axes, axis)
+			if len(verts) == 2 && i == 0 {
+				axes = append(axes, normalize(sub(verts[1], verts[0])))
+			}
+		}
+	}
+	return axes
+}
+
+type edge struct {
+	max    geometry.Point // vertex farthest along the search direction
+	v1, v2 geometry.Point
+}
+
+// bestEdge returns the edge of verts most perpendicular to n among the two
+// edges sharing the vertex farthest along n.
+func bestEdge(verts []geometry.Point, n geometry.Vector2) edge {
+	index := 0
+	best := math.Inf(-1)
+	for i, v := range verts {
+		if d := dot(n, toVector(v)); d > best {
+			best, index = d, i
+		}
+	}
+
+	v := verts[index]
+	next := verts[(index+1)%len(verts)]
+	prev := verts[(index-1+len(verts))%len(verts)]
+
+	left := normalize(sub(v, next))
+	right := normalize(sub(v, prev))
+	if math.Abs(dot(right, n)) <= math.Abs(dot(left, n)) {
+		return edge{max: v, v1: prev, v2: v}
+	}
+	return edge{max: v, v1: v, v2: next}
+}
+
+func clipContacts(va, vb []geometry.Point, n geometry.Vector2) []geometry.Point {
+	e1 := bestEdge(va, n)
+	e2 := bestEdge(vb, negate(n))
+
+	ref, inc := e1, e2
+	refNormal := n // reference face normal, pointing at the incident shape
+	if math.Abs(dot(normalize(sub(e2.v2, e2.v1)), n)) < math.Abs(dot(normalize(sub(e1.v2, e1.v1)), n)) {
+		ref, inc = e2, e1
+		refNormal = negate(n)
+	}
+
+	refDir := normalize(sub(ref.v2, ref.v1))
+	if refDir.X == 0 && refDir.Y == 0 {
+		return []geometry.Point{inc.max}
+	}
+
+	points := clip(inc.v1, inc.v2, refDir, dot(refDir, toVector(ref.v1)))
+	if len(points) < 2 {
+		return []geometry.Point{inc.max}
+	}
+	points = clip(points[0], points[1], negate(refDir), -dot(refDir, toVector(ref.v2)))
+	if len(points) < 2 {
+		return []geometry.Point{inc.max}
+	}
+
+	face := dot(refNormal, toVector(ref.max))
+	contacts := make([]geometry.Point, 0, 2)
+	for _, p := range points {
+		if dot(refNormal, toVector(p)) >= face-epsilon {
+			continue
+		}
+		contacts = append(contacts, p)
+	}
+	if len(contacts) == 0 {
+		// Shapes are only touching: the clipped points lie on the reference face.
+		return points
+	}
+	return contacts
+}
+
+// clip keeps the part of segment v1-v2 whose projection on n is at least o.
+func clip(v1, v2 geometry.Point, n geometry.Vector2, o float64) []geometry.Point {
+	points := make([]geometry.Point, 0, 2)
+	d1 := dot(n, toVector(v1)) - o
+	d2 := dot(n, toVector(v2)) - o
+	if d1 >= 0 {
+		points = append(points, v1)
+	}
+	if d2 >= 0 {
+		points = append(points, v2)
+	}
+	if d1*d2 < 0 {
+		u := d1 / (d1 - d2)
+		points = append(points, geometry.Point{X: v1.X + (v2.X-v1.X)*u, Y: v1.Y + (v2.Y-v1.Y)*u})
+	}
+	return points
+}
+
+// ---------------------------------------------------------------------------
+// Capsules (a segment inflated by a radius)
+// ---------------------------------------------------------------------------
+
+func circleCapsule(a, b geometry.Shape) (*Manifold, bool) {
+	circle, capsule := a.(*geometry.Circle), b.(*geometry.Capsule)
+	segment := capsule.Segment()
+	q := closestPointOnSegment(circle.Center, segment.Start, segment.End)
+	return circleCircle(circle, &geometry.Circle{Center: q, Radius: capsule.Radius})
+}
+
+func capsuleCapsule(a, b geometry.Shape) (*Manifold, bool) {
+	ca, cb := a.(*geometry.Capsule), b.(*geometry.Capsule)
+	sa, sb := ca.Segment(), cb.Segment()
+	return inflatedVertices([]geometry.Point{sa.Start, sa.End}, []geometry.Point{sb.Start, sb.End}, ca.Radius, cb.Radius)
+}
+
+func capsuleConvex(a, b geometry.Shape) (*Manifold, bool) {
+	capsule := a.(*geometry.Capsule)
+	segment := capsule.Segment()
+	verts := counterClockwise(b.(geometry.ConvexShape).Vertices())
+	return inflatedVertices([]geometry.Point{segment.Start, segment.End}, verts, capsule.Radius, 0)
+}
+
+// inflatedVertices computes the manifold between two convex vertex lists in
+// counter-clockwise order, each grown by its radius. Separated cores are
+// resolved along the line joining their closest points; overlapping cores
+// fall back to SAT with the radii added to the depth.
+func inflatedVertices(va, vb []geometry.Point, ra, rb float64) (*Manifold, bool) {
+	p, q, dist := closestVertices(va, vb)
+	radii := ra + rb
+	if dist > radii {
+		return nil, false
+	}
+	if dist > epsilon {
+		normal := geometry.Vector2{X: (q.X - p.X) / dist, Y: (q.Y - p.Y) / dist}
+		depth := radii - dist
+		return &Manifold{
+			Normal:   normal,
+			Depth:    depth,
+			Contacts: []geometry.Point{addScaled(p, normal, ra-depth/2)},
+		}, true
+	}
+
+	m, ok := convexVertices(va, vb)
+	if !ok {
+		return nil, false
+	}
+	m.Depth += radii
+	return m, true
+}
+
+// closestVertices returns the closest pair of points between the outlines of
+// two convex vertex lists and their distance. The distance is 0 when the
+// lists overlap, in which case the points are meaningless.
+func closestVertices(va, vb []geometry.Point) (geometry.Point, geometry.Point, float64) {
+	if len(va) > 2 && containsPoint(va, vb[0]) || len(vb) > 2 && containsPoint(vb, va[0]) {
+		return va[0], vb[0], 0
+	}
+
+	var p, q geometry.Point
+	best := math.Inf(1)
+	for i := range va {
+		a1, a2 := va[i], va[(i+1)%len(va)]
+		for j := range vb {
+			b1, b2 := vb[j], vb[(j+1)%len(vb)]
+			ep, eq := closestSegmentPoints(a1, a2, b1, b2)
+			d := sub(eq, ep)
+			if distSq := dot(d, d); distSq < best {
+				p, q, best = ep, eq, distSq
+			}
+		}
+	}
+	return p, q, math.Sqrt(best)
+}
+
+// closestSegmentPoints returns the closest points between segments a1-a2 and
+// b1-b2. Crossing segments return their intersection twice.
+func closestSegmentPoints(a1, a2, b1, b2 geometry.Point) (geometry.Point, geometry.Point) {
+	sa := geometry.Line{Start: a1, End: a2}
+	sb := geometry.Line{Start: b1, End: b2}
+	if point, _, ok := sa.Intersection(&sb); ok {
+		return point, point
+	}
+
+	// Without a crossing, one of the four endpoints is part of the closest pair.
+	candidates := [][2]geometry.Point{
+		{a1, closestPointOnSegment(a1, b1, b2)},
+		{a2, closestPointOnSegment(a2, b1, b2)},
+		{closestPointOnSegment(b1, a1, a2), b1},
+		{closestPointOnSegment(b2, a1, a2), b2},
+	}
+	best := candidates[0]
+	bestDistSq := math.Inf(1)
+	for _, c := range candidates {
+		d := sub(c[1], c[0])
+		if distSq := dot(d, d); distSq < bestDistSq {
+			best, bestDistSq = c, distSq
+		}
+	}
+	return best[0], best[1]
+}
+
+// ---------------------------------------------------------------------------
+// Vector helpers (value based to keep the narrow phase allocation free)
+// ---------------------------------------------------------------------------
+
+func sub(a, b geometry.Point) geometry.Vector2 {
+	return geometry.Vector2{X: a.X - b.X, Y: a.Y - b.Y}
+}
+
+func toVector(p geometry.Point) geometry.Vector2 {
+	return geometry.Vector2{X: p.X, Y: p.Y}
+}
+
+func addScaled(p geometry.Point, v geometry.Vector2, s float64) geometry.Point {
+	return geometry.Point{X: p.X + v.X*s, Y: p.Y + v.Y*s}
+}
+
+func dot(a, b geometry.Vector2) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+func cross(a, b geometry.Vector2) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+func negate(v geometry.Vector2) geometry.Vector2 {
+	return geometry.Vector2{X: -v.X, Y: -v.Y}
+}
+
+func normalize(v geometry.Vector2) geometry.Vector2 {
+	length := math.Sqrt(v.X*v.X + v.Y*v.Y)
+	if length == 0 {
+		return geometry.Vector2{}
+	}
+	return geometry.Vector2{X: v.X / length, Y: v.Y / length}
+}
+
+// outwardNormal returns the unit normal of edge p1->p2 pointing out of a
+// counter-clockwise polygon.
+func outwardNormal(p1, p2 geometry.Point) geometry.Vector2 {
+	return normalize(geometry.Vector2{X: p2.Y - p1.Y, Y: -(p2.X - p1.X)})
+}
+
+func project(verts []geometry.Point, axis geometry.Vector2) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range verts {
+		d := dot(axis, toVector(v))
+		lo = math.Min(lo, d)
+		hi = math.Max(hi, d)
+	}
+	return lo, hi
+}
+
+func closestPointOnSegment(p, a, b geometry.Point) geometry.Point {
+	ab := sub(b, a)
+	lengthSq := ab.X*ab.X + ab.Y*ab.Y
+	if lengthSq == 0 {
+		return a
+	}
+	t := dot(sub(p, a), ab) / lengthSq
+	t = math.Max(0, math.Min(1, t))
+	return addScaled(a, ab, t)
+}
+
+// counterClockwise returns verts in counter-clockwise order, reversing a
+// clockwise list. Lists with fewer than three vertices are returned as is.
+func counterClockwise(verts []geometry.Point) []geometry.Point {
+	if len(verts) < 3 {
+		return verts
+	}
+	area := 0.0
+	for i := range verts {
+		p1, p2 := verts[i], verts[(i+1)%len(verts)]
+		area += p1.X*p2.Y - p2.X*p1.Y
+	}
+	if area >= 0 {
+		return verts
+	}
+	reversed := make([]geometry.Point, len(verts))
+	for i, v := range verts {
+		reversed[len(verts)-1-i] = v
+	}
+	return reversed
+}
@@ -0,0 +1,343 @@
+package collision
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+const tolerance = 1e-6
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < tolerance
+}
+
+func assertManifold(t *testing.T, m *Manifold, normal geometry.Vector2, depth float64) {
+	t.Helper()
+	if !almostEqual(m.Normal.X, normal.X) || !almostEqual(m.Normal.Y, normal.Y) {
+		t.Errorf("Normal = %+v, expected %+v", m.Normal, normal)
+	}
+	if !almostEqual(m.Depth, depth) {
+		t.Errorf("Depth = %v, expected %v", m.Depth, depth)
+	}
+	if len(m.Contacts) == 0 {
+		t.Errorf("Contacts is empty")
+	}
+}
+
+// rotatedBox returns the corners of a w*h box centered at c rotated by angle.
+func rotatedBox(c geometry.Point, w, h, angle float64) []geometry.Point {
+	corners := []geometry.Vector2{{X: -w / 2, Y: -h / 2}, {X: w / 2, Y: -h / 2}, {X: w / 2, Y: h / 2}, {X: -w / 2, Y: h / 2}}
+	out := make([]geometry.Point, len(corners))
+	for i, corner := range corners {
+		r := corner.Rotate(angle)
+		out[i] = geometry.Point{X: c.X + r.X, Y: c.Y + r.Y}
+	}
+	return out
+}
+
+func mustPolygon(t *testing.T, vertices ...geometry.Point) *geometry.Polygon {
+	t.Helper()
+	p, err := geometry.NewPolygon(vertices)
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+	return p
+}
+
+func TestCollide_Manifolds(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        geometry.Shape
+		b        geometry.Shape
+		hit      bool
+		normal   geometry.Vector2
+		depth    float64
+		contacts int
+	}{
+		{
+			name:     "Circle overlaps circle",
+			a:        &geometry.Circle{Center: geometry.Point{X: 0, Y: 0}, Radius: 5},
+			b:        &geometry.Circle{Center: geometry.Point{X: 8, Y: 0}, Radius: 5},
+			hit:      true,
+			normal:   geometry.Vector2{X: 1, Y: 0},
+			depth:    2,
+			contacts: 1,
+		},
+		{
+			name: "Circle separated from circle",
+			a:    &geometry.Circle{Center: geometry.Point{X: 0, Y: 0}, Radius: 5},
+			b:    &geometry.Circle{Center: geometry.Point{X: 11, Y: 0}, Radius: 5},
+		},
+		{
+			name:     "Circle hits rectangle face",
+			a:        &geometry.Circle{Center: geometry.Point{X: 0, Y: -6}, Radius: 2},
+			b:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: 1},
+			depth:    1,
+			contacts: 1,
+		},
+		{
+			name:     "Circle center inside rectangle",
+			a:        &geometry.Circle{Center: geometry.Point{X: 4, Y: 0}, Radius: 2},
+			b:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector2{X: -1, Y: 0},
+			depth:    3,
+			contacts: 1,
+		},
+		{
+			name: "Circle near rectangle corner but outside",
+			a:    &geometry.Circle{Center: geometry.Point{X: 7, Y: 7}, Radius: 2},
+			b:    &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+		},
+		{
+			name:     "Rectangle overlaps rectangle",
+			a:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			b:        &geometry.Rectangle{Center: geometry.Point{X: 8, Y: 1}, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector2{X: 1, Y: 0},
+			depth:    2,
+			contacts: 2,
+		},
+		{
+			name: "Rectangle separated from rectangle",
+			a:    &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			b:    &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 11}, Width: 10, Height: 10},
+		},
+		{
+			name:     "Rectangle hits circle (reversed dispatch)",
+			a:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			b:        &geometry.Circle{Center: geometry.Point{X: 0, Y: -6}, Radius: 2},
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			depth:    1,
+			contacts: 1,
+		},
+		{
+			name:     "Line crosses circle",
+			a:        &geometry.Line{Start: geometry.Point{X: -10, Y: 4}, End: geometry.Point{X: 10, Y: 4}},
+			b:        &geometry.Circle{Center: geometry.Point{X: 0, Y: 0}, Radius: 5},
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			depth:    1,
+			contacts: 1,
+		},
+		{
+			name:     "Line enters rectangle",
+			a:        &geometry.Line{Start: geometry.Point{X: -10, Y: 0}, End: geometry.Point{X: -4, Y: 0}},
+			b:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector2{X: 1, Y: 0},
+			depth:    1,
+			contacts: 1,
+		},
+		{
+			name:     "Crossing lines",
+			a:        &geometry.Line{Start: geometry.Point{X: -1, Y: 0}, End: geometry.Point{X: 2, Y: 0}},
+			b:        &geometry.Line{Start: geometry.Point{X: 0, Y: -3}, End: geometry.Point{X: 0, Y: 2}},
+			hit:      true,
+			normal:   geometry.Vector2{X: -1, Y: 0},
+			depth:    1,
+			contacts: 1,
+		},
+		{
+			name:     "Circle hits oriented rectangle tip",
+			a:        &geometry.Circle{Center: geometry.Point{X: 0, Y: 4.5}, Radius: 2},
+			b:        geometry.NewOrientedRectangle(geometry.Point{X: 0, Y: 0}, 3*math.Sqrt2, 3*math.Sqrt2, math.Pi/4),
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name: "Circle in oriented rectangle bounding box corner",
+			a:    &geometry.Circle{Center: geometry.Point{X: 2.5, Y: 2.5}, Radius: 0.5},
+			b:    geometry.NewOrientedRectangle(geometry.Point{X: 0, Y: 0}, 3*math.Sqrt2, 3*math.Sqrt2, math.Pi/4),
+		},
+		{
+			name:     "Oriented rectangle rests on rectangle",
+			a:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			b:        geometry.NewOrientedRectangle(geometry.Point{X: 0, Y: 5 + math.Sqrt2 - 0.5}, 2, 2, math.Pi/4),
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: 1},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name:     "Circle hits polygon hypotenuse",
+			a:        &geometry.Circle{Center: geometry.Point{X: 4, Y: 4}, Radius: 1.5},
+			b:        mustPolygon(t, geometry.Point{X: 0, Y: 0}, geometry.Point{X: 6, Y: 0}, geometry.Point{X: 0, Y: 6}),
+			hit:      true,
+			normal:   geometry.Vector2{X: -math.Sqrt2 / 2, Y: -math.Sqrt2 / 2},
+			depth:    1.5 - math.Sqrt2,
+			contacts: 1,
+		},
+		{
+			name:     "Polygon tip rests on rectangle",
+			a:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			b:        mustPolygon(t, geometry.Point{X: 0, Y: 4.5}, geometry.Point{X: 2, Y: 7}, geometry.Point{X: -2, Y: 7}),
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: 1},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name:     "Circle touches capsule side",
+			a:        &geometry.Circle{Center: geometry.Point{X: 1, Y: 3}, Radius: 1.5},
+			b:        geometry.NewCapsule(geometry.Point{X: 0, Y: 0}, 4, 2, 0),
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name:     "Capsule end caps overlap",
+			a:        geometry.NewCapsule(geometry.Point{X: 0, Y: 0}, 4, 1, 0),
+			b:        geometry.NewCapsule(geometry.Point{X: 3.5, Y: 0}, 2, 1, math.Pi/2),
+			hit:      true,
+			normal:   geometry.Vector2{X: 1, Y: 0},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name:     "Crossing capsules",
+			a:        geometry.NewCapsule(geometry.Point{X: 0, Y: 0}, 4, 1, 0),
+			b:        geometry.NewCapsule(geometry.Point{X: 0, Y: 0.5}, 4, 1, math.Pi/2),
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: 1},
+			depth:    3.5,
+			contacts: 1,
+		},
+		{
+			name:     "Capsule slides over rectangle corner",
+			a:        geometry.NewCapsule(geometry.Point{X: 5 + 1.5/math.Sqrt2, Y: 5 + 1.5/math.Sqrt2}, 0, 2, 0),
+			b:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector2{X: -math.Sqrt2 / 2, Y: -math.Sqrt2 / 2},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name:     "Capsule lying on a polygon",
+			a:        geometry.NewCapsule(geometry.Point{X: 0, Y: 7.5}, 4, 2, 0),
+			b:        mustPolygon(t, geometry.Point{X: -5, Y: 0}, geometry.Point{X: 5, Y: 0}, geometry.Point{X: 5, Y: 6}, geometry.Point{X: -5, Y: 6}),
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			depth:    0.5,
+			contacts: 1,
+		},
+		{
+			name:     "Capsule core inside a rectangle",
+			a:        geometry.NewCapsule(geometry.Point{X: 0, Y: 4}, 2, 1, 0),
+			b:        &geometry.Rectangle{Center: geometry.Point{X: 0, Y: 0}, Width: 10, Height: 10},
+			hit:      true,
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			depth:    2,
+			contacts: 2,
+		},
+		{
+			name: "Capsule clear of a line",
+			a:    geometry.NewCapsule(geometry.Point{X: 0, Y: 0}, 4, 1, math.Pi/2),
+			b:    &geometry.Line{Start: geometry.Point{X: 1.5, Y: -5}, End: geometry.Point{X: 1.5, Y: 5}},
+		},
+		{
+			name: "Collinear separated lines",
+			a:    &geometry.Line{Start: geometry.Point{X: 0, Y: 0}, End: geometry.Point{X: 1, Y: 0}},
+			b:    &geometry.Line{Start: geometry.Point{X: 2, Y: 0}, End: geometry.Point{X: 3, Y: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok, err := Collide(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Collide() error = %v", err)
+			}
+			if ok != tt.hit {
+				t.Fatalf("Collide() hit = %v, expected %v", ok, tt.hit)
+			}
+			if !tt.hit {
+				return
+			}
+			assertManifold(t, m, tt.normal, tt.depth)
+			if len(m.Contacts) != tt.contacts {
+				t.Errorf("len(Contacts) = %d, expected %d (%v)", len(m.Contacts), tt.contacts, m.Contacts)
+			}
+		})
+	}
+}
+
+func TestConvexVertices_RotatedBoxes(t *testing.T) {
+	// A 45° diamond resting its bottom corner on top of an axis-aligned box.
+	box := rotatedBox(geometry.Point{X: 0, Y: 0}, 10, 10, 0)
+	diamond := rotatedBox(geometry.Point{X: 0, Y: 5 + 5*math.Sqrt2 - 1}, 10, 10, math.Pi/4)
+
+	m, ok := convexVertices(box, diamond)
+	if !ok {
+		t.Fatalf("expected rotated boxes to overlap")
+	}
+	assertManifold(t, m, geometry.Vector2{X: 0, Y: 1}, 1)
+	if len(m.Contacts) != 1 || !almostEqual(m.Contacts[0].X, 0) || !almostEqual(m.Contacts[0].Y, 4) {
+		t.Errorf("Contacts = %v, expected the diamond tip at (0, 4)", m.Contacts)
+	}
+
+	// Lifted just clear of the box.
+	far := rotatedBox(geometry.Point{X: 0, Y: 5 + 5*math.Sqrt2 + 0.1}, 10, 10, math.Pi/4)
+	if _, ok := convexVertices(box, far); ok {
+		t.Errorf("expected separated rotated boxes not to overlap")
+	}
+}
+
+type unknownShape struct {
+	*geometry.Circle
+}
+
+func (unknownShape) GetType() string { return "unknown" }
+
+func TestCollide_UnregisteredPair(t *testing.T) {
+	a := &geometry.Circle{Radius: 1}
+	b := unknownShape{&geometry.Circle{Radius: 1}}
+
+	_, _, err := Collide(a, b)
+	if err == nil {
+		t.Fatalf("Collide() expected an error for an unregistered pair")
+	}
+	if _, ok := err.(*CollisionSystemError); !ok {
+		t.Errorf("error type = %T, expected *CollisionSystemError", err)
+	}
+}
+
+func TestCollisionBody_Collide(t *testing.T) {
+	newBody := func(x float64, layer uint32) *CollisionBody {
+		c := collider.Collider{
+			ShapeList: []geometry.Shape{&geometry.Circle{Radius: 2}},
+			Enabled:   true,
+		}
+		c.LayerMask.SetBit(layer)
+		c.MatchMask.SetBit(collider.LayerPlayer)
+		return NewCollisionBody(geometry.Vector2{X: x, Y: 0}, 2, c)
+	}
+
+	a, b := newBody(0, collider.LayerPlayer), newBody(3, collider.LayerPlayer)
+	m, ok := a.Collide(b)
+	if !ok {
+		t.Fatalf("expected bodies to collide")
+	}
+	assertManifold(t, m, geometry.Vector2{X: 1, Y: 0}, 1)
+
+	b.SetTransform(geometry.Vector2{X: 10, Y: 0})
+	if _, ok := a.Collide(b); ok {
+		t.Errorf("expected far bodies not to collide")
+	}
+
+	trigger := newBody(3, collider.LayerTrigger)
+	trigger.Collider().MatchMask = collider.NewBitmask()
+	a.Collider().MatchMask = collider.NewBitmask()
+	if _, ok := a.Collide(trigger); ok {
+		t.Errorf("expected bodies with disjoint masks not to collide")
+	}
+}
@@ -0,0 +1,23 @@
+package collision
+
+import "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+
+// Manifold describes how two shapes overlap. Moving shape B along Normal by
+// Depth separates the pair.
+type Manifold struct {
+	Normal   geometry.Vector2 // Unit vector pointing from shape A towards shape B
+	Depth    float64          // Penetration depth along Normal, 0 when just touching
+	Contacts []geometry.Point // World-space contact points, at most two
+}
+
+// Flipped returns the manifold seen from the other shape: same depth and
+// contacts, opposite normal.
+func (m *Manifold) Flipped() *Manifold {
+	contacts := make([]geometry.Point, len(m.Contacts))
+	copy(contacts, m.Contacts)
+	return &Manifold{
+		Normal:   geometry.Vector2{X: -m.Normal.X, Y: -m.Normal.Y},
+		Depth:    m.Depth,
+		Contacts: contacts,
+	}
+}
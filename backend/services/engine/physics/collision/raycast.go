@@ -0,0 +1,128 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// RayHit is where a ray (a segment from its origin to its maximum reach)
+// first meets a shape.
+type RayHit struct {
+	Point    geometry.Point   // first point of the shape on the ray
+	Normal   geometry.Vector2 // surface normal at Point, facing the ray origin
+	Distance float64          // distance from the ray origin to Point
+}
+
+// RaycastShape intersects ray with shape. A ray starting inside a solid shape
+// hits it at distance 0 with the normal opposing the ray.
+func RaycastShape(ray geometry.Line, shape geometry.Shape) (*RayHit, bool) {
+	d := sub(ray.End, ray.Start)
+	length := math.Sqrt(dot(d, d))
+	if length == 0 {
+		return nil, false
+	}
+
+	switch s := shape.(type) {
+	case *geometry.Circle:
+		if m := sub(ray.Start, s.Center); dot(m, m) <= s.Radius*s.Radius {
+			return insideHit(ray.Start, d, length), true
+		}
+		t, ok := rayCircle(ray.Start, d, s.Center, s.Radius)
+		if !ok {
+			return nil, false
+		}
+		point := addScaled(ray.Start, d, t)
+		return &RayHit{Point: point, Normal: normalize(sub(point, s.Center)), Distance: t * length}, true
+	case *geometry.Capsule:
+		if s.ContainsPoint(&ray.Start) {
+			return insideHit(ray.Start, d, length), true
+		}
+		// A capsule is its segment rounded by Radius, which a circle of the
+		// same radius swept along the ray touches exactly when the ray
+		// touches the capsule.
+		impact, ok := sweepCircleVertices(ray.Start, d, s.Radius, capsuleVertices(s))
+		if !ok {
+			return nil, false
+		}
+		return &RayHit{Point: impact.Position, Normal: impact.Normal, Distance: impact.Time * length}, true
+	case geometry.ConvexShape:
+		return raycastVertices(ray, d, length, counterClockwise(s.Vertices()))
+	}
+	return nil, false
+}
+
+// raycastVertices intersects the ray with every edge of a convex vertex list
+// in counter-clockwise order. Two-vertex lists are treated as segments.
+func raycastVertices(ray geometry.Line, d geometry.Vector2, length float64, verts []geometry.Point) (*RayHit, bool) {
+	if len(verts) > 2 && containsPoint(verts, ray.Start) {
+		return insideHit(ray.Start, d, length), true
+	}
+
+	var best *RayHit
+	for i := range verts {
+		edge := geometry.Line{Start: verts[i], End: verts[(i+1)%len(verts)]}
+		point, t, ok := ray.Intersection(&edge)
+		if !ok || (best != nil && t*length >= best.Distance) {
+			continue
+		}
+		normal := outwardNormal(edge.Start, edge.End)
+		if dot(normal, d) > 0 {
+			normal = negate(normal) // the far side of a segment
+		}
+		if normal.X == 0 && normal.Y == 0 {
+			normal = normalize(negate(d)) // degenerate edge, a single point
+		}
+		best = &RayHit{Point: point, Normal: normal, Distance: t * length}
+	}
+	return best, best != nil
+}
+
+func insideHit(origin geometry.Point, d geometry.Vector2, length float64) *RayHit {
+	return &RayHit{Point: origin, Normal: geometry.Vector2{X: -d.X / length, Y: -d.Y / length}}
+}
+
+// containsPoint reports whether p lies inside or on a counter-clockwise
+// convex polygon.
+func containsPoint(verts []geometry.Point, p geometry.Point) bool {
+	for i := range verts {
+		p1, p2 := verts[i], verts[(i+1)%len(verts)]
+		if cross(sub(p2, p1), sub(p, p1)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RaycastCollider returns the nearest hit of ray against any world-space
+// shape of c.
+func RaycastCollider(ray geometry.Line, c *collider.Collider) (*RayHit, bool) {
+	var best *RayHit
+	for _, shape := range c.GetWorldSpaceShapes() {
+		if hit, ok := RaycastShape(ray, shape); ok && (best == nil || hit.Distance < best.Distance) {
+			best = hit
+		}
+	}
+	return best, best != nil
+}
+
+// CircleCastCollider sweeps a circle of the given radius along ray and returns
+// its first contact with any world-space shape of c. Point is the contact
+// point on c and Distance how far the circle center travelled.
+func CircleCastCollider(ray geometry.Line, radius float64, c *collider.Collider) (*RayHit, bool) {
+	d := sub(ray.End, ray.Start)
+	length := math.Sqrt(dot(d, d))
+
+	var best *RayHit
+	for _, shape := range c.GetWorldSpaceShapes() {
+		impact, ok := SweepCircle(ray.Start, ray.End, radius, shape)
+		if !ok {
+			continue
+		}
+		if distance := impact.Time * length; best == nil || distance < best.Distance {
+			best = &RayHit{Point: impact.Contact, Normal: impact.Normal, Distance: distance}
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,127 @@
+package collision
+
+import (
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+func TestRaycastShape(t *testing.T) {
+	box := &geometry.Rectangle{Center: geometry.Point{X: 10, Y: 0}, Width: 4, Height: 4}
+	wall := &geometry.Line{Start: geometry.Point{X: 5, Y: -5}, End: geometry.Point{X: 5, Y: 5}}
+	capsule := geometry.NewCapsule(geometry.Point{X: 10, Y: 0}, 4, 1, 0)
+
+	tests := []struct {
+		name     string
+		ray      geometry.Line
+		shape    geometry.Shape
+		hit      bool
+		point    geometry.Point
+		normal   geometry.Vector2
+		distance float64
+	}{
+		{
+			name:     "Rectangle near face",
+			ray:      geometry.Line{Start: geometry.Point{X: 0, Y: 1}, End: geometry.Point{X: 20, Y: 1}},
+			shape:    box,
+			hit:      true,
+			point:    geometry.Point{X: 8, Y: 1},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+			distance: 8,
+		},
+		{
+			name:     "Rectangle from inside",
+			ray:      geometry.Line{Start: geometry.Point{X: 10, Y: 0}, End: geometry.Point{X: 10, Y: 20}},
+			shape:    box,
+			hit:      true,
+			point:    geometry.Point{X: 10, Y: 0},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			distance: 0,
+		},
+		{
+			name:     "Segment from the left",
+			ray:      geometry.Line{Start: geometry.Point{X: 0, Y: 0}, End: geometry.Point{X: 10, Y: 0}},
+			shape:    wall,
+			hit:      true,
+			point:    geometry.Point{X: 5, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+			distance: 5,
+		},
+		{
+			name:     "Segment from the right",
+			ray:      geometry.Line{Start: geometry.Point{X: 10, Y: 0}, End: geometry.Point{X: 0, Y: 0}},
+			shape:    wall,
+			hit:      true,
+			point:    geometry.Point{X: 5, Y: 0},
+			normal:   geometry.Vector2{X: 1, Y: 0},
+			distance: 5,
+		},
+		{
+			name:     "Circle",
+			ray:      geometry.Line{Start: geometry.Point{X: 0, Y: 10}, End: geometry.Point{X: 0, Y: 0}},
+			shape:    &geometry.Circle{Center: geometry.Point{X: 0, Y: 0}, Radius: 3},
+			hit:      true,
+			point:    geometry.Point{X: 0, Y: 3},
+			normal:   geometry.Vector2{X: 0, Y: 1},
+			distance: 7,
+		},
+		{
+			name:     "Capsule end cap",
+			ray:      geometry.Line{Start: geometry.Point{X: 0, Y: 0}, End: geometry.Point{X: 20, Y: 0}},
+			shape:    capsule,
+			hit:      true,
+			point:    geometry.Point{X: 7, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+			distance: 7,
+		},
+		{
+			name:     "Capsule side",
+			ray:      geometry.Line{Start: geometry.Point{X: 11, Y: 10}, End: geometry.Point{X: 11, Y: -10}},
+			shape:    capsule,
+			hit:      true,
+			point:    geometry.Point{X: 11, Y: 1},
+			normal:   geometry.Vector2{X: 0, Y: 1},
+			distance: 9,
+		},
+		{
+			name:     "Capsule from inside",
+			ray:      geometry.Line{Start: geometry.Point{X: 9, Y: 0.5}, End: geometry.Point{X: 9, Y: 10}},
+			shape:    capsule,
+			hit:      true,
+			point:    geometry.Point{X: 9, Y: 0.5},
+			normal:   geometry.Vector2{X: 0, Y: -1},
+			distance: 0,
+		},
+		{
+			name:  "Capsule missed",
+			ray:   geometry.Line{Start: geometry.Point{X: 0, Y: 1.5}, End: geometry.Point{X: 20, Y: 1.5}},
+			shape: capsule,
+		},
+		{
+			name:  "Ray stops short",
+			ray:   geometry.Line{Start: geometry.Point{X: 0, Y: 0}, End: geometry.Point{X: 4, Y: 0}},
+			shape: wall,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, ok := RaycastShape(tt.ray, tt.shape)
+			if ok != tt.hit {
+				t.Fatalf("RaycastShape() hit = %v, expected %v", ok, tt.hit)
+			}
+			if !tt.hit {
+				return
+			}
+			if !almostEqual(hit.Point.X, tt.point.X) || !almostEqual(hit.Point.Y, tt.point.Y) {
+				t.Errorf("Point = %+v, expected %+v", hit.Point, tt.point)
+			}
+			if !almostEqual(hit.Normal.X, tt.normal.X) || !almostEqual(hit.Normal.Y, tt.normal.Y) {
+				t.Errorf("Normal = %+v, expected %+v", hit.Normal, tt.normal)
+			}
+			if !almostEqual(hit.Distance, tt.distance) {
+				t.Errorf("Distance = %v, expected %v", hit.Distance, tt.distance)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package collision
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// BodyKind mirrors the body types of the physics component without making
+// this package depend on components.
+type BodyKind int
+
+const (
+	Static    BodyKind = iota // never moves
+	Kinematic                 // moved by game logic, slides along obstacles
+	Rigid                     // moved by forces and impulses
+)
+
+// Body is the resolver's view of a physics body. Position and Velocity point
+// into the owning component, so resolving a contact moves the entity itself.
+type Body struct {
+	Kind        BodyKind
+	Mass        float64
+	Restitution float64
+	Friction    float64
+	Position    *geometry.Point
+	Velocity    *geometry.Vector2
+}
+
+// inverseMass returns 0 for bodies that other bodies cannot push.
+func (b *Body) inverseMass() float64 {
+	if b.Kind != Rigid || b.Mass <= 0 {
+		return 0
+	}
+	return 1 / b.Mass
+}
+
+func (b *Body) velocity() geometry.Vector2 {
+	if b.Kind == Static || b.Velocity == nil {
+		return geometry.Vector2{}
+	}
+	return *b.Velocity
+}
+
+// Contact pairs two bodies with the manifold found between them. The
+// manifold normal points from A to B.
+type Contact struct {
+	A        *Body
+	B        *Body
+	Manifold *Manifold
+}
+
+// Default resolver settings.
+const (
+	DefaultResolverIterations = 4
+	DefaultPenetrationSlop    = 0.01
+	DefaultCorrectionPercent  = 0.8
+)
+
+// Resolver separates overlapping bodies and exchanges impulses between them.
+//
+//   - Static bodies never move.
+//   - Kinematic bodies are pushed fully out of static and kinematic bodies and
+//     lose the velocity component pointing into the obstacle, so they slide.
+//   - Rigid bodies exchange impulses using Mass, Restitution and Friction;
+//     against static or kinematic bodies they behave as if hitting a wall.
+//
+// Rotation is not simulated: contacts only affect linear velocity.
+type Resolver struct {
+	Iterations        int     // Passes over all contacts, helps bodies touching several obstacles
+	PenetrationSlop   float64 // Penetration left untouched for rigid pairs to avoid jitter
+	CorrectionPercent float64 // Fraction of the remaining rigid penetration removed per pass
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{
+		Iterations:        DefaultResolverIterations,
+		PenetrationSlop:   DefaultPenetrationSlop,
+		CorrectionPercent: DefaultCorrectionPercent,
+	}
+}
+
+// Resolve applies impulses once per contact and then runs the positional
+// passes, deepest contact first. Manifolds are computed before resolution
+// starts, so the resolver tracks how far each body has already been moved and
+// only removes the penetration that is left. A body resting on the seam of two
+// wall tiles is pushed out by the face contact and the shallower corner
+// contact of the neighbouring tile is then skipped instead of snagging it.
+func (r *Resolver) Resolve(contacts []Contact) {
+	ordered := make([]Contact, len(contacts))
+	copy(ordered, contacts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Manifold.Depth > ordered[j].Manifold.Depth
+	})
+
+	for _, c := range ordered {
+		r.applyImpulse(c)
+	}
+
+	moved := make(map[*Body]geometry.Vector2)
+	for i := 0; i < max(r.Iterations, 1); i++ {
+		for _, c := range ordered {
+			r.correctPosition(c, moved)
+		}
+	}
+}
+
+func (r *Resolver) applyImpulse(c Contact) {
+	a, b, n := c.A, c.B, c.Manifold.Normal
+	if a.Kind == Static && b.Kind == Static {
+		return
+	}
+
+	invA, invB := a.inverseMass(), b.inverseMass()
+	if invA+invB == 0 {
+		return // no rigid body, handled by correctPosition
+	}
+
+	va, vb := a.velocity(), b.velocity()
+	rv := geometry.Vector2{X: vb.X - va.X, Y: vb.Y - va.Y}
+	velAlongNormal := dot(rv, n)
+	if velAlongNormal > 0 {
+		return // already separating
+	}
+
+	e := math.Min(a.Restitution, b.Restitution)
+	j := -(1 + e) * velAlongNormal / (invA + invB)
+	applyVelocity(a, n, -j*invA)
+	applyVelocity(b, n, j*invB)
+
+	// Coulomb friction along the contact tangent.
+	va, vb = a.velocity(), b.velocity()
+	rv = geometry.Vector2{X: vb.X - va.X, Y: vb.Y - va.Y}
+	tangent := normalize(geometry.Vector2{X: rv.X - n.X*dot(rv, n), Y: rv.Y - n.Y*dot(rv, n)})
+	if tangent.X == 0 && tangent.Y == 0 {
+		return
+	}
+	jt := -dot(rv, tangent) / (invA + invB)
+	mu := math.Sqrt(math.Max(a.Friction, 0) * math.Max(b.Friction, 0))
+	jt = math.Max(-j*mu, math.Min(j*mu, jt))
+	applyVelocity(a, tangent, -jt*invA)
+	applyVelocity(b, tangent, jt*invB)
+}
+
+func (r *Resolver) correctPosition(c Contact, moved map[*Body]geometry.Vector2) {
+	a, b, n := c.A, c.B, c.Manifold.Normal
+	if a.Kind == Static && b.Kind == Static {
+		return
+	}
+
+	// Penetration still left after earlier corrections of either body.
+	ma, mb := moved[a], moved[b]
+	depth := c.Manifold.Depth - dot(geometry.Vector2{X: mb.X - ma.X, Y: mb.Y - ma.Y}, n)
+	if depth <= 0 {
+		return
+	}
+
+	shareA, shareB := r.pushShares(a, b, depth)
+	translate(a, n, -shareA, moved)
+	translate(b, n, shareB, moved)
+
+	// Kinematic bodies that were pushed out of an obstacle stop moving into
+	// it and keep the tangential part of their velocity.
+	if a.inverseMass()+b.inverseMass() == 0 {
+		if shareA > 0 {
+			slide(a, n)
+		}
+		if shareB > 0 {
+			slide(b, negate(n))
+		}
+	}
+}
+
+// pushShares splits depth between the two bodies.
+func (r *Resolver) pushShares(a, b *Body, depth float64) (float64, float64) {
+	invA, invB := a.inverseMass(), b.inverseMass()
+
+	if invA+invB > 0 {
+		// At least one rigid body: share by inverse mass with slop and
+		// Baumgarte-style softening.
+		correction := math.Max(depth-r.PenetrationSlop, 0) * r.CorrectionPercent
+		if correction == 0 {
+			return 0, 0
+		}
+		return correction * invA / (invA + invB), correction * invB / (invA + invB)
+	}
+
+	// Static and kinematic bodies are separated completely.
+	switch {
+	case a.Kind == Kinematic && b.Kind == Kinematic:
+		return depth / 2, depth / 2
+	case a.Kind == Kinematic:
+		return depth, 0
+	case b.Kind == Kinematic:
+		return 0, depth
+	}
+	return 0, 0
+}
+
+// slide removes the part of body's velocity moving along n.
+func slide(body *Body, n geometry.Vector2) {
+	if body.Kind != Kinematic || body.Velocity == nil {
+		return
+	}
+	if into := dot(*body.Velocity, n); into > 0 {
+		body.Velocity.X -= n.X * into
+		body.Velocity.Y -= n.Y * into
+	}
+}
+
+func applyVelocity(body *Body, dir geometry.Vector2, amount float64) {
+	if body.Kind != Rigid || body.Velocity == nil || amount == 0 {
+		return
+	}
+	body.Velocity.X += dir.X * amount
+	body.Velocity.Y += dir.Y * amount
+}
+
+func translate(body *Body, dir geometry.Vector2, amount float64, moved map[*Body]geometry.Vector2) {
+	if body.Kind == Static || body.Position == nil || amount == 0 {
+		return
+	}
+	body.Position.X += dir.X * amount
+	body.Position.Y += dir.Y * amount
+
+	m := moved[body]
+	moved[body] = geometry.Vector2{X: m.X + dir.X*amount, Y: m.Y + dir.Y*amount}
+}
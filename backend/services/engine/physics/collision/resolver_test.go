@@ -0,0 +1,162 @@
+package collision
+
+import (
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// --- Test Helpers ---
+
+func newBody(kind BodyKind, x, y, vx, vy float64) *Body {
+	return &Body{
+		Kind:     kind,
+		Mass:     1,
+		Position: geometry.NewPoint(x, y),
+		Velocity: geometry.NewVector2(vx, vy),
+	}
+}
+
+func newContact(a, b *Body, nx, ny, depth float64) Contact {
+	return Contact{A: a, B: b, Manifold: &Manifold{Normal: geometry.Vector2{X: nx, Y: ny}, Depth: depth}}
+}
+
+// --- Tests ---
+
+func TestResolver_StaticNeverMoves(t *testing.T) {
+	wall := newBody(Static, 0, 0, 0, 0)
+	ball := newBody(Rigid, 0, 4, 0, -10)
+
+	NewResolver().Resolve([]Contact{newContact(ball, wall, 0, -1, 1)})
+
+	if wall.Position.X != 0 || wall.Position.Y != 0 {
+		t.Errorf("static Position = %+v, expected (0, 0)", *wall.Position)
+	}
+	if wall.Velocity.X != 0 || wall.Velocity.Y != 0 {
+		t.Errorf("static Velocity = %+v, expected zero", *wall.Velocity)
+	}
+	if ball.Position.Y <= 4 {
+		t.Errorf("rigid Position.Y = %v, expected it to be pushed above 4", ball.Position.Y)
+	}
+	if ball.Velocity.Y < 0 {
+		t.Errorf("rigid Velocity.Y = %v, expected it to stop moving into the wall", ball.Velocity.Y)
+	}
+}
+
+func TestResolver_KinematicSlidesAlongStatic(t *testing.T) {
+	player := newBody(Kinematic, 0, 0, 3, 4)
+	wall := newBody(Static, 0, 10, 0, 0)
+
+	NewResolver().Resolve([]Contact{newContact(player, wall, 0, 1, 0.5)})
+
+	if !almostEqual(player.Position.Y, -0.5) || !almostEqual(player.Position.X, 0) {
+		t.Errorf("Position = %+v, expected (0, -0.5)", *player.Position)
+	}
+	if !almostEqual(player.Velocity.X, 3) || !almostEqual(player.Velocity.Y, 0) {
+		t.Errorf("Velocity = %+v, expected (3, 0)", *player.Velocity)
+	}
+}
+
+func TestResolver_KinematicPairSplitsDepth(t *testing.T) {
+	a := newBody(Kinematic, 0, 0, 0, 0)
+	b := newBody(Kinematic, 1, 0, 0, 0)
+
+	NewResolver().Resolve([]Contact{newContact(a, b, 1, 0, 2)})
+
+	if !almostEqual(a.Position.X, -1) || !almostEqual(b.Position.X, 2) {
+		t.Errorf("Positions = %v, %v, expected -1 and 2", a.Position.X, b.Position.X)
+	}
+}
+
+func TestResolver_RigidImpulse(t *testing.T) {
+	tests := []struct {
+		name        string
+		restitution float64
+		friction    float64
+		vA, vB      geometry.Vector2
+		expectA     geometry.Vector2
+		expectB     geometry.Vector2
+	}{
+		{
+			name:        "Elastic equal masses swap velocities",
+			restitution: 1,
+			vA:          geometry.Vector2{X: 2, Y: 0},
+			vB:          geometry.Vector2{X: -1, Y: 0},
+			expectA:     geometry.Vector2{X: -1, Y: 0},
+			expectB:     geometry.Vector2{X: 2, Y: 0},
+		},
+		{
+			name:    "Inelastic equal masses move together",
+			vA:      geometry.Vector2{X: 2, Y: 0},
+			expectA: geometry.Vector2{X: 1, Y: 0},
+			expectB: geometry.Vector2{X: 1, Y: 0},
+		},
+		{
+			name:     "Friction removes tangential sliding",
+			friction: 1,
+			vA:       geometry.Vector2{X: 2, Y: 2},
+			expectA:  geometry.Vector2{X: 1, Y: 1},
+			expectB:  geometry.Vector2{X: 1, Y: 1},
+		},
+		{
+			name:    "Separating bodies are left alone",
+			vA:      geometry.Vector2{X: -1, Y: 0},
+			vB:      geometry.Vector2{X: 1, Y: 0},
+			expectA: geometry.Vector2{X: -1, Y: 0},
+			expectB: geometry.Vector2{X: 1, Y: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newBody(Rigid, 0, 0, tt.vA.X, tt.vA.Y)
+			b := newBody(Rigid, 1, 0, tt.vB.X, tt.vB.Y)
+			a.Restitution, b.Restitution = tt.restitution, tt.restitution
+			a.Friction, b.Friction = tt.friction, tt.friction
+
+			NewResolver().Resolve([]Contact{newContact(a, b, 1, 0, 0.5)})
+
+			if !almostEqual(a.Velocity.X, tt.expectA.X) || !almostEqual(a.Velocity.Y, tt.expectA.Y) {
+				t.Errorf("A Velocity = %+v, expected %+v", *a.Velocity, tt.expectA)
+			}
+			if !almostEqual(b.Velocity.X, tt.expectB.X) || !almostEqual(b.Velocity.Y, tt.expectB.Y) {
+				t.Errorf("B Velocity = %+v, expected %+v", *b.Velocity, tt.expectB)
+			}
+			if b.Position.X-a.Position.X <= 1 {
+				t.Errorf("bodies were not pushed apart: A=%v B=%v", a.Position.X, b.Position.X)
+			}
+		})
+	}
+}
+
+func TestResolver_HeavierBodyMovesLess(t *testing.T) {
+	light := newBody(Rigid, 0, 0, 0, 0)
+	heavy := newBody(Rigid, 1, 0, 0, 0)
+	heavy.Mass = 3
+
+	NewResolver().Resolve([]Contact{newContact(light, heavy, 1, 0, 1)})
+
+	if moved := -light.Position.X; !almostEqual(moved, 3*(heavy.Position.X-1)) {
+		t.Errorf("light moved %v, heavy moved %v, expected a 3:1 ratio", moved, heavy.Position.X-1)
+	}
+}
+
+func TestResolver_TileSeamDoesNotOvershoot(t *testing.T) {
+	// The player overlaps the face of one tile and, more shallowly, the
+	// corner of the next one. Both are computed before any correction.
+	player := newBody(Kinematic, 0, 0, 5, 5)
+	tileA := newBody(Static, 0, 10, 0, 0)
+	tileB := newBody(Static, 16, 10, 0, 0)
+
+	NewResolver().Resolve([]Contact{
+		newContact(player, tileB, 0.6, 0.8, 0.5),
+		newContact(player, tileA, 0, 1, 1),
+	})
+
+	if !almostEqual(player.Position.Y, -1) || !almostEqual(player.Position.X, 0) {
+		t.Errorf("Position = %+v, expected (0, -1)", *player.Position)
+	}
+	if !almostEqual(player.Velocity.X, 5) {
+		t.Errorf("Velocity.X = %v, expected the seam not to slow the slide", player.Velocity.X)
+	}
+}
@@ -0,0 +1,168 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Capsule is a segment swept by a circle: the set of points within Radius of
+// the segment of the given Length centered on Center. At Rotation 0 the
+// segment lies along the x axis; Rotation turns it counter-clockwise. Its
+// rounded ends let characters slide around tile corners instead of catching
+// on them.
+type Capsule struct {
+	Center   Point
+	Length   float64
+	Radius   float64
+	Rotation float64
+}
+
+func NewCapsule(center Point, length, radius, rotation float64) *Capsule {
+	return &Capsule{
+		Center:   center,
+		Length:   length,
+		Radius:   radius,
+		Rotation: rotation,
+	}
+}
+
+func (c *Capsule) GetType() string {
+	return CapsuleType
+}
+
+func (c *Capsule) GetCenter() Point {
+	return c.Center
+}
+
+func (c *Capsule) SetCenter(center Point) {
+	c.Center = center
+}
+
+// Clone returns an independent copy of the capsule.
+func (c *Capsule) Clone() Shape {
+	clone := *c
+	return &clone
+}
+
+func (c *Capsule) GetRotation() float64 {
+	return c.Rotation
+}
+
+func (c *Capsule) SetRotation(rotation float64) {
+	c.Rotation = rotation
+}
+
+// Segment returns the world-space segment joining the centers of the two
+// rounded ends.
+func (c *Capsule) Segment() Line {
+	hx := math.Cos(c.Rotation) * c.Length / 2
+	hy := math.Sin(c.Rotation) * c.Length / 2
+	return Line{
+		Start: Point{X: c.Center.X - hx, Y: c.Center.Y - hy},
+		End:   Point{X: c.Center.X + hx, Y: c.Center.Y + hy},
+	}
+}
+
+func (c *Capsule) GetBounds() Bounds {
+	segment := c.Segment()
+	b := segment.GetBounds()
+	return Bounds{
+		MinX: b.MinX - c.Radius,
+		MinY: b.MinY - c.Radius,
+		MaxX: b.MaxX + c.Radius,
+		MaxY: b.MaxY + c.Radius,
+	}
+}
+
+// distanceTo returns the distance from point to the capsule's segment.
+func (c *Capsule) distanceTo(point Point) float64 {
+	s := c.Segment()
+	q := closestOnSegment(point, s.Start, s.End)
+	return math.Hypot(point.X-q.X, point.Y-q.Y)
+}
+
+// Like circles, capsules that only touch another shape intersect it.
+
+func (c *Capsule) IntersectsCapsule(other *Capsule) bool {
+	return segmentDistance(c.Segment(), other.Segment()) <= c.Radius+other.Radius
+}
+
+func (c *Capsule) IntersectsOrientedRectangle(other *OrientedRectangle) bool {
+	return verticesDistance(c.Segment(), other.Vertices()) <= c.Radius
+}
+
+func (c *Capsule) IntersectsPolygon(other *Polygon) bool {
+	return verticesDistance(c.Segment(), other.Vertices()) <= c.Radius
+}
+
+func (c *Capsule) IntersectsRectangle(other *Rectangle) bool {
+	return verticesDistance(c.Segment(), other.Vertices()) <= c.Radius
+}
+
+func (c *Capsule) IntersectsCircle(other *Circle) bool {
+	return c.distanceTo(other.Center) <= c.Radius+other.Radius
+}
+
+func (c *Capsule) IntersectsLine(line *Line) bool {
+	return segmentDistance(c.Segment(), *line) <= c.Radius
+}
+
+func (c *Capsule) IntersectsPoint(point *Point) bool {
+	return c.ContainsPoint(point)
+}
+
+// The capsule is convex, so it contains a polygon or segment exactly when it
+// contains all of its vertices.
+
+func (c *Capsule) ContainsRectangle(other *Rectangle) bool {
+	for _, v := range other.Vertices() {
+		if !c.ContainsPoint(&v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Capsule) ContainsCircle(other *Circle) bool {
+	return c.distanceTo(other.Center)+other.Radius <= c.Radius
+}
+
+func (c *Capsule) ContainsLine(line *Line) bool {
+	return c.ContainsPoint(&line.Start) && c.ContainsPoint(&line.End)
+}
+
+func (c *Capsule) ContainsPoint(point *Point) bool {
+	return c.distanceTo(*point) <= c.Radius
+}
+
+// segmentDistance returns the smallest distance between two segments, 0 when
+// they cross or touch.
+func segmentDistance(a, b Line) float64 {
+	if a.IntersectsLine(&b) {
+		return 0
+	}
+	distance := math.Inf(1)
+	for _, pair := range [][3]Point{
+		{a.Start, b.Start, b.End},
+		{a.End, b.Start, b.End},
+		{b.Start, a.Start, a.End},
+		{b.End, a.Start, a.End},
+	} {
+		q := closestOnSegment(pair[0], pair[1], pair[2])
+		distance = math.Min(distance, math.Hypot(pair[0].X-q.X, pair[0].Y-q.Y))
+	}
+	return distance
+}
+
+// verticesDistance returns the smallest distance between a segment and a
+// convex vertex list, 0 when they overlap.
+func verticesDistance(segment Line, verts []Point) float64 {
+	if verticesOverlap(verts, []Point{segment.Start, segment.End}, true) {
+		return 0
+	}
+	distance := math.Inf(1)
+	for i := range verts {
+		edge := Line{Start: verts[i], End: verts[(i+1)%len(verts)]}
+		distance = math.Min(distance, segmentDistance(segment, edge))
+	}
+	return distance
+}
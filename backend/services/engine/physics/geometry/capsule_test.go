@@ -0,0 +1,170 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_Capsule_Implements_Shape_Runtime(t *testing.T) {
+	var s any = &Capsule{}
+
+	if _, ok := s.(Shape); !ok {
+		t.Fatalf("Capsule does not implement Shape")
+	}
+}
+
+func TestCapsule_SegmentAndBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		capsule  *Capsule
+		segment  Line
+		expected Bounds
+	}{
+		{
+			name:     "Horizontal",
+			capsule:  NewCapsule(Point{1, 1}, 4, 1, 0),
+			segment:  Line{Start: Point{-1, 1}, End: Point{3, 1}},
+			expected: Bounds{MinX: -2, MinY: 0, MaxX: 4, MaxY: 2},
+		},
+		{
+			name:     "Quarter turn stands upright",
+			capsule:  NewCapsule(Point{0, 0}, 4, 1, math.Pi/2),
+			segment:  Line{Start: Point{0, -2}, End: Point{0, 2}},
+			expected: Bounds{MinX: -1, MinY: -3, MaxX: 1, MaxY: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.capsule.Segment()
+			if math.Abs(s.Start.X-tt.segment.Start.X) > 1e-9 || math.Abs(s.Start.Y-tt.segment.Start.Y) > 1e-9 ||
+				math.Abs(s.End.X-tt.segment.End.X) > 1e-9 || math.Abs(s.End.Y-tt.segment.End.Y) > 1e-9 {
+				t.Errorf("Segment() = %+v, expected %+v", s, tt.segment)
+			}
+			got := tt.capsule.GetBounds()
+			if math.Abs(got.MinX-tt.expected.MinX) > 1e-9 || math.Abs(got.MinY-tt.expected.MinY) > 1e-9 ||
+				math.Abs(got.MaxX-tt.expected.MaxX) > 1e-9 || math.Abs(got.MaxY-tt.expected.MaxY) > 1e-9 {
+				t.Errorf("GetBounds() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCapsule_Intersects(t *testing.T) {
+	// Segment from (-2, 0) to (2, 0) with rounded ends of radius 1.
+	capsule := NewCapsule(Point{0, 0}, 4, 1, 0)
+
+	tests := []struct {
+		name     string
+		other    Shape
+		expected bool
+	}{
+		{
+			name:     "Rectangle in the bounding box corner only",
+			other:    &Rectangle{Center: Point{3, 1}, Width: 0.5, Height: 0.5},
+			expected: false,
+		},
+		{
+			name:     "Rectangle touching the flat side",
+			other:    &Rectangle{Center: Point{0, 2}, Width: 2, Height: 2},
+			expected: true,
+		},
+		{
+			name:     "Circle beside the rounded end",
+			other:    &Circle{Center: Point{3.5, 0}, Radius: 0.5},
+			expected: true,
+		},
+		{
+			name:     "Circle off the corner",
+			other:    &Circle{Center: Point{3, 1}, Radius: 0.3},
+			expected: false,
+		},
+		{
+			name:     "Line crossing the core",
+			other:    &Line{Start: Point{0, -5}, End: Point{0, 5}},
+			expected: true,
+		},
+		{
+			name:     "Line passing above",
+			other:    &Line{Start: Point{-5, 1.5}, End: Point{5, 1.5}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			switch o := tt.other.(type) {
+			case *Rectangle:
+				got = capsule.IntersectsRectangle(o)
+			case *Circle:
+				got = capsule.IntersectsCircle(o)
+			case *Line:
+				got = capsule.IntersectsLine(o)
+			}
+			if got != tt.expected {
+				t.Errorf("Intersects(%s) = %v, expected %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+
+	if !capsule.IntersectsPoint(&Point{2.5, 0.5}) {
+		t.Errorf("IntersectsPoint() = false, expected a point in the rounded end to hit")
+	}
+	if capsule.IntersectsPoint(&Point{2.9, 0.9}) {
+		t.Errorf("IntersectsPoint() = true, expected the bounding box corner to miss")
+	}
+}
+
+func TestCapsule_IntersectsRotatedShapes(t *testing.T) {
+	capsule := NewCapsule(Point{0, 0}, 4, 1, math.Pi/2)
+
+	if !capsule.IntersectsCapsule(NewCapsule(Point{1.5, 2}, 2, 0.5, 0)) {
+		t.Errorf("IntersectsCapsule() = false, expected capsules within their radii to hit")
+	}
+	if capsule.IntersectsCapsule(NewCapsule(Point{3, 0}, 2, 0.5, math.Pi/2)) {
+		t.Errorf("IntersectsCapsule() = true, expected parallel capsules 3 apart to miss")
+	}
+
+	diamond := NewOrientedRectangle(Point{1 + math.Sqrt2, 0}, 2, 2, math.Pi/4)
+	if !capsule.IntersectsOrientedRectangle(diamond) {
+		t.Errorf("IntersectsOrientedRectangle() = false, expected the diamond tip to touch")
+	}
+
+	triangle := triangle(t)
+	triangle.SetCenter(Point{4, 4})
+	if capsule.IntersectsPolygon(triangle) {
+		t.Errorf("IntersectsPolygon() = true, expected a far triangle to miss")
+	}
+}
+
+func TestCapsule_Contains(t *testing.T) {
+	capsule := NewCapsule(Point{0, 0}, 4, 1, 0)
+
+	if !capsule.ContainsRectangle(&Rectangle{Center: Point{0, 0}, Width: 4, Height: 1}) {
+		t.Errorf("ContainsRectangle() = false, expected a rectangle around the core to fit")
+	}
+	if capsule.ContainsRectangle(&Rectangle{Center: Point{0, 0}, Width: 6, Height: 1}) {
+		t.Errorf("ContainsRectangle() = true, expected corners poking out of the rounded ends")
+	}
+	if !capsule.ContainsCircle(&Circle{Center: Point{2, 0}, Radius: 1}) {
+		t.Errorf("ContainsCircle() = false, expected the end cap circle to fit")
+	}
+	if capsule.ContainsCircle(&Circle{Center: Point{2.5, 0}, Radius: 1}) {
+		t.Errorf("ContainsCircle() = true, expected a circle past the end cap to stick out")
+	}
+	if !capsule.ContainsLine(&Line{Start: Point{-2.9, 0}, End: Point{2.9, 0}}) {
+		t.Errorf("ContainsLine() = false, expected the long axis to fit")
+	}
+}
+
+func TestCapsule_Clone(t *testing.T) {
+	capsule := NewCapsule(Point{1, 2}, 4, 1, 0)
+	clone := capsule.Clone().(*Capsule)
+	clone.SetCenter(Point{5, 5})
+	clone.SetRotation(math.Pi)
+
+	if capsule.GetCenter() != (Point{1, 2}) || capsule.GetRotation() != 0 {
+		t.Errorf("Clone() shares state with the original: %+v", capsule)
+	}
+}
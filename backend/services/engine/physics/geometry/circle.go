@@ -25,6 +25,12 @@ func (c *Circle) SetCenter(center Point) {
 	c.Center = center
 }
 
+// Clone returns an independent copy of the circle.
+func (c *Circle) Clone() Shape {
+	clone := *c
+	return &clone
+}
+
 func (c *Circle) GetRadius() float64 {
 	return c.Radius
 }
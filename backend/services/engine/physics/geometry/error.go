@@ -0,0 +1,17 @@
+package geometry
+
+import (
+	"fmt"
+)
+
+type GeometryError struct {
+	Message string
+}
+
+func (e *GeometryError) Error() string {
+	return fmt.Sprintf("Geometry Error: %s", e.Message)
+}
+
+func NewGeometryError(message string) *GeometryError {
+	return &GeometryError{Message: message}
+}
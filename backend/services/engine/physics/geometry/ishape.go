@@ -3,6 +3,11 @@ package geometry
 const (
 	CircleType    = "circle"
 	RectangleType = "rectangle"
+	LineType      = "Line"
+
+	OrientedRectangleType = "oriented_rectangle"
+	PolygonType           = "polygon"
+	CapsuleType           = "capsule"
 )
 
 type Bounds struct {
@@ -17,12 +22,21 @@ func (b Bounds) Intersects(other Bounds) bool {
 		b.MaxY < other.MinY || b.MinY > other.MaxY)
 }
 
+// ConvexShape is implemented by shapes that can be described by a convex list
+// of world-space vertices in counter-clockwise order. The narrow phase uses it
+// to run SAT on any pair of such shapes.
+type ConvexShape interface {
+	Shape
+	Vertices() []Point
+}
+
 type Shape interface {
 	GetType() string
 	GetCenter() Point
 	SetCenter(center Point)
 
 	GetBounds() Bounds
+	Clone() Shape
 
 	IntersectsRectangle(other *Rectangle) bool
 	IntersectsCircle(other *Circle) bool
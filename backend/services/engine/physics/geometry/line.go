@@ -45,6 +45,39 @@ func (l *Line) IntersectsLine(other *Line) bool {
 	return false
 }
 
+// Intersection returns the first point of l that also lies on other, and how
+// far along l it is as a fraction of l's length in [0, 1]. Collinear
+// overlapping segments report the start of the overlap.
+func (l *Line) Intersection(other *Line) (Point, float64, bool) {
+	rx, ry := l.End.X-l.Start.X, l.End.Y-l.Start.Y
+	sx, sy := other.End.X-other.Start.X, other.End.Y-other.Start.Y
+	qx, qy := other.Start.X-l.Start.X, other.Start.Y-l.Start.Y
+
+	denom := rx*sy - ry*sx
+	if denom == 0 {
+		// Parallel: only collinear segments can share points.
+		lengthSq := rx*rx + ry*ry
+		if qx*ry-qy*rx != 0 || lengthSq == 0 {
+			return Point{}, 0, false
+		}
+		t0 := (qx*rx + qy*ry) / lengthSq
+		t1 := t0 + (sx*rx+sy*ry)/lengthSq
+		lo, hi := min(t0, t1), max(t0, t1)
+		if hi < 0 || lo > 1 {
+			return Point{}, 0, false
+		}
+		t := max(lo, 0)
+		return Point{X: l.Start.X + rx*t, Y: l.Start.Y + ry*t}, t, true
+	}
+
+	t := (qx*sy - qy*sx) / denom
+	u := (qx*ry - qy*rx) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Point{}, 0, false
+	}
+	return Point{X: l.Start.X + rx*t, Y: l.Start.Y + ry*t}, t, true
+}
+
 func (l *Line) GetBounds() Bounds {
 	return Bounds{
 		MinX: min(l.Start.X, l.End.X),
@@ -82,7 +115,12 @@ func max(a, b float64) float64 {
 
 // GetType returns the type name of the shape.
 func (l *Line) GetType() string {
-	return "Line"
+	return LineType
+}
+
+// Vertices returns the two endpoints of the segment.
+func (l *Line) Vertices() []Point {
+	return []Point{l.Start, l.End}
 }
 
 // GetCenter returns the midpoint of the line segment.
@@ -93,11 +131,21 @@ func (l *Line) GetCenter() Point {
 	}
 }
 
+// Clone returns an independent copy of the line segment.
+func (l *Line) Clone() Shape {
+	clone := *l
+	return &clone
+}
+
+// SetCenter moves the segment so that its midpoint lies on center while
+// keeping its direction and length.
 func (l *Line) SetCenter(center Point) {
-	l.Start.X = center.X - (l.End.X-l.Start.X)/2
-	l.Start.Y = center.Y - (l.End.Y-l.Start.Y)/2
-	l.End.X = center.X + (l.End.X-l.Start.X)/2
-	l.End.Y = center.Y + (l.End.Y-l.Start.Y)/2
+	halfX := (l.End.X - l.Start.X) / 2
+	halfY := (l.End.Y - l.Start.Y) / 2
+	l.Start.X = center.X - halfX
+	l.Start.Y = center.Y - halfY
+	l.End.X = center.X + halfX
+	l.End.Y = center.Y + halfY
 }
 
 // IntersectsRectangle checks if the line intersects with a rectangle.
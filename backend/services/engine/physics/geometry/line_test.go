@@ -130,6 +130,75 @@ func TestLine_IntersectsCircle(t *testing.T) {
 	}
 }
 
+func TestLine_Intersection(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     Line
+		other    Line
+		expected bool
+		point    Point
+		fraction float64
+	}{
+		{
+			name:     "Crossing lines",
+			line:     Line{Start: Point{0, 0}, End: Point{10, 0}},
+			other:    Line{Start: Point{4, -5}, End: Point{4, 5}},
+			expected: true,
+			point:    Point{4, 0},
+			fraction: 0.4,
+		},
+		{
+			name:     "Touching at endpoint",
+			line:     Line{Start: Point{0, 0}, End: Point{10, 10}},
+			other:    Line{Start: Point{10, 10}, End: Point{20, 0}},
+			expected: true,
+			point:    Point{10, 10},
+			fraction: 1,
+		},
+		{
+			name:  "Lines stop short of each other",
+			line:  Line{Start: Point{0, 0}, End: Point{3, 0}},
+			other: Line{Start: Point{4, -5}, End: Point{4, 5}},
+		},
+		{
+			name:  "Parallel lines",
+			line:  Line{Start: Point{0, 0}, End: Point{10, 0}},
+			other: Line{Start: Point{0, 1}, End: Point{10, 1}},
+		},
+		{
+			name:     "Collinear overlap reports first shared point",
+			line:     Line{Start: Point{0, 0}, End: Point{10, 0}},
+			other:    Line{Start: Point{12, 0}, End: Point{6, 0}},
+			expected: true,
+			point:    Point{6, 0},
+			fraction: 0.6,
+		},
+		{
+			name:  "Collinear without overlap",
+			line:  Line{Start: Point{0, 0}, End: Point{10, 0}},
+			other: Line{Start: Point{11, 0}, End: Point{15, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point, fraction, ok := tt.line.Intersection(&tt.other)
+			if ok != tt.expected {
+				t.Fatalf("Intersection() ok = %v, expected %v", ok, tt.expected)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(point.X-tt.point.X) > 1e-9 || math.Abs(point.Y-tt.point.Y) > 1e-9 {
+				t.Errorf("Intersection() point = %v, expected %v", point, tt.point)
+			}
+			if math.Abs(fraction-tt.fraction) > 1e-9 {
+				t.Errorf("Intersection() fraction = %v, expected %v", fraction, tt.fraction)
+			}
+		})
+	}
+}
+
 func TestLine_IntersectsPoint(t *testing.T) {
 	tests := []struct {
 		name     string
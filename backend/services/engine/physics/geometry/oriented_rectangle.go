@@ -0,0 +1,175 @@
+package geometry
+
+import (
+	"math"
+)
+
+// OrientedRectangle is a rectangle rotated by Rotation radians
+// (counter-clockwise) around its center. Intersections use the separating
+// axis theorem.
+type OrientedRectangle struct {
+	Center   Point
+	Width    float64
+	Height   float64
+	Rotation float64
+}
+
+func NewOrientedRectangle(center Point, width, height, rotation float64) *OrientedRectangle {
+	return &OrientedRectangle{
+		Center:   center,
+		Width:    width,
+		Height:   height,
+		Rotation: rotation,
+	}
+}
+
+func (r *OrientedRectangle) GetType() string {
+	return OrientedRectangleType
+}
+
+func (r *OrientedRectangle) GetCenter() Point {
+	return r.Center
+}
+
+func (r *OrientedRectangle) SetCenter(center Point) {
+	r.Center = center
+}
+
+// Clone returns an independent copy of the rectangle.
+func (r *OrientedRectangle) Clone() Shape {
+	clone := *r
+	return &clone
+}
+
+func (r *OrientedRectangle) GetRotation() float64 {
+	return r.Rotation
+}
+
+func (r *OrientedRectangle) SetRotation(rotation float64) {
+	r.Rotation = rotation
+}
+
+// axes returns the rectangle's local x and y axes in world space.
+func (r *OrientedRectangle) axes() (Vector2, Vector2) {
+	cos, sin := math.Cos(r.Rotation), math.Sin(r.Rotation)
+	return Vector2{X: cos, Y: sin}, Vector2{X: -sin, Y: cos}
+}
+
+// toLocal expresses a world-space point in the rectangle's frame, with the
+// center at the origin.
+func (r *OrientedRectangle) toLocal(p Point) Point {
+	ax, ay := r.axes()
+	dx, dy := p.X-r.Center.X, p.Y-r.Center.Y
+	return Point{X: dx*ax.X + dy*ax.Y, Y: dx*ay.X + dy*ay.Y}
+}
+
+// GetBounds returns the axis-aligned box enclosing the rotated rectangle.
+func (r *OrientedRectangle) GetBounds() Bounds {
+	cos, sin := math.Abs(math.Cos(r.Rotation)), math.Abs(math.Sin(r.Rotation))
+	halfX := r.Width/2*cos + r.Height/2*sin
+	halfY := r.Width/2*sin + r.Height/2*cos
+	return Bounds{
+		MinX: r.Center.X - halfX,
+		MinY: r.Center.Y - halfY,
+		MaxX: r.Center.X + halfX,
+		MaxY: r.Center.Y + halfY,
+	}
+}
+
+// Vertices returns the four corners in counter-clockwise order, starting at
+// the corner that is the minimum one when Rotation is 0.
+func (r *OrientedRectangle) Vertices() []Point {
+	ax, ay := r.axes()
+	hw, hh := r.Width/2, r.Height/2
+	corner := func(sx, sy float64) Point {
+		return Point{
+			X: r.Center.X + ax.X*hw*sx + ay.X*hh*sy,
+			Y: r.Center.Y + ax.Y*hw*sx + ay.Y*hh*sy,
+		}
+	}
+	return []Point{corner(-1, -1), corner(1, -1), corner(1, 1), corner(-1, 1)}
+}
+
+// IntersectsOrientedRectangle, like Rectangle.IntersectsRectangle, does not
+// count rectangles that only touch as intersecting.
+func (r *OrientedRectangle) IntersectsOrientedRectangle(other *OrientedRectangle) bool {
+	return verticesOverlap(r.Vertices(), other.Vertices(), false)
+}
+
+func (r *OrientedRectangle) IntersectsRectangle(other *Rectangle) bool {
+	return verticesOverlap(r.Vertices(), other.Vertices(), false)
+}
+
+func (r *OrientedRectangle) IntersectsCircle(other *Circle) bool {
+	// Clamp the circle center to the rectangle in local space.
+	local := r.toLocal(other.Center)
+	closestX := math.Max(-r.Width/2, math.Min(r.Width/2, local.X))
+	closestY := math.Max(-r.Height/2, math.Min(r.Height/2, local.Y))
+	dx, dy := local.X-closestX, local.Y-closestY
+	return dx*dx+dy*dy <= other.Radius*other.Radius
+}
+
+func (r *OrientedRectangle) IntersectsLine(line *Line) bool {
+	return verticesOverlap(r.Vertices(), []Point{line.Start, line.End}, true)
+}
+
+func (r *OrientedRectangle) IntersectsPoint(point *Point) bool {
+	return r.ContainsPoint(point)
+}
+
+func (r *OrientedRectangle) ContainsRectangle(other *Rectangle) bool {
+	for _, v := range other.Vertices() {
+		if !r.ContainsPoint(&v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *OrientedRectangle) ContainsCircle(other *Circle) bool {
+	local := r.toLocal(other.Center)
+	return math.Abs(local.X)+other.Radius <= r.Width/2 && math.Abs(local.Y)+other.Radius <= r.Height/2
+}
+
+func (r *OrientedRectangle) ContainsLine(line *Line) bool {
+	return r.ContainsPoint(&line.Start) && r.ContainsPoint(&line.End)
+}
+
+func (r *OrientedRectangle) ContainsPoint(point *Point) bool {
+	local := r.toLocal(*point)
+	return math.Abs(local.X) <= r.Width/2 && math.Abs(local.Y) <= r.Height/2
+}
+
+// verticesOverlap runs the separating axis test on two convex vertex lists.
+// touching selects whether lists that only share boundary points overlap.
+// Two-vertex lists are segments and also contribute their direction as an
+// axis so collinear segments separate.
+func verticesOverlap(a, b []Point, touching bool) bool {
+	for _, verts := range [][]Point{a, b} {
+		for i := range verts {
+			p1, p2 := verts[i], verts[(i+1)%len(verts)]
+			edge := Vector2{X: p2.X - p1.X, Y: p2.Y - p1.Y}
+			if edge.X == 0 && edge.Y == 0 {
+				continue
+			}
+			for _, axis := range []Vector2{{X: -edge.Y, Y: edge.X}, edge} {
+				minA, maxA := projectPoints(a, axis)
+				minB, maxB := projectPoints(b, axis)
+				if maxA < minB || maxB < minA || (!touching && (maxA == minB || maxB == minA)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func projectPoints(verts []Point, axis Vector2) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range verts {
+		d := v.X*axis.X + v.Y*axis.Y
+		lo = math.Min(lo, d)
+		hi = math.Max(hi, d)
+	}
+	return lo, hi
+}
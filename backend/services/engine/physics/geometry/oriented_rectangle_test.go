@@ -0,0 +1,158 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOrientedRectangle_Implements_ConvexShape(t *testing.T) {
+	var s any = NewOrientedRectangle(Point{0, 0}, 2, 2, 0)
+
+	if _, ok := s.(ConvexShape); !ok {
+		t.Fatalf("OrientedRectangle does not implement ConvexShape")
+	}
+}
+
+func TestOrientedRectangle_GetBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		rect     OrientedRectangle
+		expected Bounds
+	}{
+		{
+			name:     "Unrotated",
+			rect:     OrientedRectangle{Center: Point{1, 1}, Width: 4, Height: 2},
+			expected: Bounds{MinX: -1, MinY: 0, MaxX: 3, MaxY: 2},
+		},
+		{
+			name:     "Quarter turn swaps extents",
+			rect:     OrientedRectangle{Center: Point{0, 0}, Width: 4, Height: 2, Rotation: math.Pi / 2},
+			expected: Bounds{MinX: -1, MinY: -2, MaxX: 1, MaxY: 2},
+		},
+		{
+			name:     "45 degree square",
+			rect:     OrientedRectangle{Center: Point{0, 0}, Width: 2, Height: 2, Rotation: math.Pi / 4},
+			expected: Bounds{MinX: -math.Sqrt2, MinY: -math.Sqrt2, MaxX: math.Sqrt2, MaxY: math.Sqrt2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rect.GetBounds()
+			if math.Abs(got.MinX-tt.expected.MinX) > 1e-9 || math.Abs(got.MinY-tt.expected.MinY) > 1e-9 ||
+				math.Abs(got.MaxX-tt.expected.MaxX) > 1e-9 || math.Abs(got.MaxY-tt.expected.MaxY) > 1e-9 {
+				t.Errorf("GetBounds() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrientedRectangle_Intersects(t *testing.T) {
+	// A 45° diamond at the origin reaching sqrt(2) along both axes.
+	diamond := OrientedRectangle{Center: Point{0, 0}, Width: 2, Height: 2, Rotation: math.Pi / 4}
+
+	tests := []struct {
+		name     string
+		other    Shape
+		expected bool
+	}{
+		{
+			name:     "Rectangle in the bounding box corner only",
+			other:    &Rectangle{Center: Point{1.3, 1.3}, Width: 0.4, Height: 0.4},
+			expected: false,
+		},
+		{
+			name:     "Rectangle touching the tip",
+			other:    &Rectangle{Center: Point{2, 0}, Width: 1.2, Height: 1},
+			expected: true,
+		},
+		{
+			name:     "Oriented rectangle crossing it",
+			other:    &OrientedRectangle{Center: Point{2, 0}, Width: 4, Height: 0.2, Rotation: math.Pi / 2},
+			expected: false,
+		},
+		{
+			name:     "Oriented rectangle overlapping a face",
+			other:    &OrientedRectangle{Center: Point{0.75, 0.75}, Width: 2, Height: 0.2, Rotation: -math.Pi / 4},
+			expected: true,
+		},
+		{
+			name:     "Circle in the bounding box corner only",
+			other:    &Circle{Center: Point{1.2, 1.2}, Radius: 0.2},
+			expected: false,
+		},
+		{
+			name:     "Circle on a face",
+			other:    &Circle{Center: Point{1, 1}, Radius: 0.5},
+			expected: true,
+		},
+		{
+			name:     "Line through the bounding box corner only",
+			other:    &Line{Start: Point{1.1, 1.4}, End: Point{1.4, 1.1}},
+			expected: false,
+		},
+		{
+			name:     "Line crossing it",
+			other:    &Line{Start: Point{-2, 0.5}, End: Point{2, 0.5}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			switch other := tt.other.(type) {
+			case *Rectangle:
+				got = diamond.IntersectsRectangle(other)
+			case *OrientedRectangle:
+				got = diamond.IntersectsOrientedRectangle(other)
+			case *Circle:
+				got = diamond.IntersectsCircle(other)
+			case *Line:
+				got = diamond.IntersectsLine(other)
+			}
+			if got != tt.expected {
+				t.Errorf("Intersects() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrientedRectangle_MatchesRectangleWhenUnrotated(t *testing.T) {
+	rect := Rectangle{Center: Point{0, 0}, Width: 2, Height: 2}
+	obb := OrientedRectangle{Center: Point{0, 0}, Width: 2, Height: 2}
+
+	others := []Rectangle{
+		{Center: Point{2, 2}, Width: 2, Height: 2}, // touching at corner
+		{Center: Point{1, 0}, Width: 2, Height: 2},
+		{Center: Point{3, 0}, Width: 2, Height: 2},
+	}
+	for _, other := range others {
+		if got, expected := obb.IntersectsRectangle(&other), rect.IntersectsRectangle(&other); got != expected {
+			t.Errorf("IntersectsRectangle(%+v) = %v, expected %v", other, got, expected)
+		}
+	}
+}
+
+func TestOrientedRectangle_Contains(t *testing.T) {
+	diamond := OrientedRectangle{Center: Point{0, 0}, Width: 2, Height: 2, Rotation: math.Pi / 4}
+
+	if !diamond.ContainsPoint(&Point{1.3, 0}) {
+		t.Errorf("ContainsPoint() = false, expected the point near the tip to be inside")
+	}
+	if diamond.ContainsPoint(&Point{0.9, 0.9}) {
+		t.Errorf("ContainsPoint() = true, expected the bounding box corner to be outside")
+	}
+	if !diamond.ContainsCircle(&Circle{Center: Point{0, 0}, Radius: 1}) {
+		t.Errorf("ContainsCircle() = false, expected the inscribed circle to fit")
+	}
+	if diamond.ContainsCircle(&Circle{Center: Point{0.5, 0}, Radius: 1}) {
+		t.Errorf("ContainsCircle() = true, expected a shifted circle to stick out")
+	}
+	if diamond.ContainsRectangle(&Rectangle{Center: Point{0, 0}, Width: 2, Height: 2}) {
+		t.Errorf("ContainsRectangle() = true, expected the unrotated square to stick out")
+	}
+	if !diamond.ContainsLine(&Line{Start: Point{-1.2, 0}, End: Point{1.2, 0}}) {
+		t.Errorf("ContainsLine() = false, expected the horizontal diagonal to fit")
+	}
+}
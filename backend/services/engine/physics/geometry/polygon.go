@@ -0,0 +1,227 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Polygon is a convex polygon. Points are stored counter-clockwise relative
+// to Center, which is the polygon's centroid, and are turned by Rotation
+// radians (counter-clockwise) around it. Use NewPolygon to build one so the
+// vertex list is validated.
+type Polygon struct {
+	Center   Point
+	Points   []Point
+	Rotation float64
+}
+
+// NewPolygon builds a convex polygon from world-space vertices given in either
+// winding order. It returns a GeometryError when there are fewer than three
+// vertices, when consecutive vertices repeat or when the outline is not
+// strictly convex (collinear or reflex corners).
+func NewPolygon(vertices []Point) (*Polygon, error) {
+	n := len(vertices)
+	if n < 3 {
+		return nil, NewGeometryError("polygon needs at least 3 vertices")
+	}
+
+	sign := 0.0
+	for i := range vertices {
+		a, b, c := vertices[i], vertices[(i+1)%n], vertices[(i+2)%n]
+		if a == b {
+			return nil, NewGeometryError("polygon has repeated vertices")
+		}
+		turn := orientation(a, b, c)
+		if turn == 0 {
+			return nil, NewGeometryError("polygon has collinear vertices")
+		}
+		if sign == 0 {
+			sign = turn
+		} else if sign*turn < 0 {
+			return nil, NewGeometryError("polygon is not convex")
+		}
+	}
+
+	// Consistent turns can still wind around more than once (a pentagram).
+	winding := 0.0
+	for i := range vertices {
+		a, b, c := vertices[i], vertices[(i+1)%n], vertices[(i+2)%n]
+		winding += math.Atan2(orientation(a, b, c), (b.X-a.X)*(c.X-b.X)+(b.Y-a.Y)*(c.Y-b.Y))
+	}
+	if math.Abs(winding) > 2*math.Pi+1e-9 {
+		return nil, NewGeometryError("polygon is self-intersecting")
+	}
+
+	ordered := make([]Point, n)
+	for i, v := range vertices {
+		if sign < 0 {
+			v = vertices[n-1-i]
+		}
+		ordered[i] = v
+	}
+
+	center := centroid(ordered)
+	points := make([]Point, n)
+	for i, v := range ordered {
+		points[i] = Point{X: v.X - center.X, Y: v.Y - center.Y}
+	}
+	return &Polygon{Center: center, Points: points}, nil
+}
+
+// centroid returns the area centroid of a simple polygon.
+func centroid(verts []Point) Point {
+	var area, cx, cy float64
+	for i := range verts {
+		a, b := verts[i], verts[(i+1)%len(verts)]
+		cross := a.X*b.Y - b.X*a.Y
+		area += cross
+		cx += (a.X + b.X) * cross
+		cy += (a.Y + b.Y) * cross
+	}
+	area *= 3
+	return Point{X: cx / area, Y: cy / area}
+}
+
+func (p *Polygon) GetType() string {
+	return PolygonType
+}
+
+func (p *Polygon) GetCenter() Point {
+	return p.Center
+}
+
+func (p *Polygon) SetCenter(center Point) {
+	p.Center = center
+}
+
+// Clone returns an independent copy of the polygon.
+func (p *Polygon) Clone() Shape {
+	clone := *p
+	clone.Points = make([]Point, len(p.Points))
+	copy(clone.Points, p.Points)
+	return &clone
+}
+
+func (p *Polygon) GetRotation() float64 {
+	return p.Rotation
+}
+
+func (p *Polygon) SetRotation(rotation float64) {
+	p.Rotation = rotation
+}
+
+// Vertices returns the world-space vertices in counter-clockwise order.
+func (p *Polygon) Vertices() []Point {
+	cos, sin := math.Cos(p.Rotation), math.Sin(p.Rotation)
+	verts := make([]Point, len(p.Points))
+	for i, v := range p.Points {
+		verts[i] = Point{
+			X: p.Center.X + v.X*cos - v.Y*sin,
+			Y: p.Center.Y + v.X*sin + v.Y*cos,
+		}
+	}
+	return verts
+}
+
+func (p *Polygon) GetBounds() Bounds {
+	verts := p.Vertices()
+	b := Bounds{MinX: verts[0].X, MinY: verts[0].Y, MaxX: verts[0].X, MaxY: verts[0].Y}
+	for _, v := range verts[1:] {
+		b.MinX, b.MinY = min(b.MinX, v.X), min(b.MinY, v.Y)
+		b.MaxX, b.MaxY = max(b.MaxX, v.X), max(b.MaxY, v.Y)
+	}
+	return b
+}
+
+// IntersectsPolygon, like Rectangle.IntersectsRectangle, does not count
+// polygons that only touch as intersecting.
+func (p *Polygon) IntersectsPolygon(other *Polygon) bool {
+	return verticesOverlap(p.Vertices(), other.Vertices(), false)
+}
+
+func (p *Polygon) IntersectsOrientedRectangle(other *OrientedRectangle) bool {
+	return verticesOverlap(p.Vertices(), other.Vertices(), false)
+}
+
+func (p *Polygon) IntersectsRectangle(other *Rectangle) bool {
+	return verticesOverlap(p.Vertices(), other.Vertices(), false)
+}
+
+func (p *Polygon) IntersectsCircle(other *Circle) bool {
+	verts := p.Vertices()
+	if containsVertex(verts, other.Center) {
+		return true
+	}
+	for i := range verts {
+		q := closestOnSegment(other.Center, verts[i], verts[(i+1)%len(verts)])
+		dx, dy := other.Center.X-q.X, other.Center.Y-q.Y
+		if dx*dx+dy*dy <= other.Radius*other.Radius {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Polygon) IntersectsLine(line *Line) bool {
+	return verticesOverlap(p.Vertices(), []Point{line.Start, line.End}, true)
+}
+
+func (p *Polygon) IntersectsPoint(point *Point) bool {
+	return p.ContainsPoint(point)
+}
+
+func (p *Polygon) ContainsRectangle(other *Rectangle) bool {
+	verts := p.Vertices()
+	for _, v := range other.Vertices() {
+		if !containsVertex(verts, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Polygon) ContainsCircle(other *Circle) bool {
+	verts := p.Vertices()
+	if !containsVertex(verts, other.Center) {
+		return false
+	}
+	// The center must be at least one radius inside every edge.
+	for i := range verts {
+		a, b := verts[i], verts[(i+1)%len(verts)]
+		length := math.Hypot(b.X-a.X, b.Y-a.Y)
+		if orientation(a, b, other.Center)/length < other.Radius {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Polygon) ContainsLine(line *Line) bool {
+	verts := p.Vertices()
+	return containsVertex(verts, line.Start) && containsVertex(verts, line.End)
+}
+
+func (p *Polygon) ContainsPoint(point *Point) bool {
+	return containsVertex(p.Vertices(), *point)
+}
+
+// containsVertex reports whether point lies inside or on a counter-clockwise
+// convex vertex list.
+func containsVertex(verts []Point, point Point) bool {
+	for i := range verts {
+		if orientation(verts[i], verts[(i+1)%len(verts)], point) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func closestOnSegment(p, a, b Point) Point {
+	abX, abY := b.X-a.X, b.Y-a.Y
+	lengthSq := abX*abX + abY*abY
+	if lengthSq == 0 {
+		return a
+	}
+	t := ((p.X-a.X)*abX + (p.Y-a.Y)*abY) / lengthSq
+	t = max(0, min(1, t))
+	return Point{X: a.X + abX*t, Y: a.Y + abY*t}
+}
@@ -0,0 +1,412 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// triangle returns the right triangle (0,0), (6,0), (0,6); its hypotenuse
+// lies on x+y=6.
+func triangle(t *testing.T) *Polygon {
+	t.Helper()
+	p, err := NewPolygon([]Point{{0, 0}, {6, 0}, {0, 6}})
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+	return p
+}
+
+func Test_Polygon_Implements_Shape_Runtime(t *testing.T) {
+	var s any = &Polygon{}
+
+	if _, ok := s.(ConvexShape); !ok {
+		t.Fatalf("Polygon does not implement ConvexShape")
+	}
+}
+
+func TestNewPolygon_Validation(t *testing.T) {
+	// Visiting a pentagon's corners two at a time draws a pentagram.
+	pentagram := make([]Point, 5)
+	for i := range pentagram {
+		angle := math.Pi/2 + float64(i)*4*math.Pi/5
+		pentagram[i] = Point{math.Cos(angle), math.Sin(angle)}
+	}
+
+	tests := []struct {
+		name     string
+		vertices []Point
+		valid    bool
+	}{
+		{
+			name:     "Counter-clockwise triangle",
+			vertices: []Point{{0, 0}, {6, 0}, {0, 6}},
+			valid:    true,
+		},
+		{
+			name:     "Clockwise square",
+			vertices: []Point{{0, 0}, {0, 2}, {2, 2}, {2, 0}},
+			valid:    true,
+		},
+		{
+			name:     "Too few vertices",
+			vertices: []Point{{0, 0}, {1, 0}},
+			valid:    false,
+		},
+		{
+			name:     "Repeated vertex",
+			vertices: []Point{{0, 0}, {1, 0}, {1, 0}, {0, 1}},
+			valid:    false,
+		},
+		{
+			name:     "Collinear vertices",
+			vertices: []Point{{0, 0}, {1, 0}, {2, 0}, {0, 2}},
+			valid:    false,
+		},
+		{
+			name:     "Concave arrow",
+			vertices: []Point{{0, 0}, {4, 2}, {0, 4}, {1, 2}},
+			valid:    false,
+		},
+		{
+			name:     "Self-intersecting pentagram",
+			vertices: pentagram,
+			valid:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPolygon(tt.vertices)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("NewPolygon() error = %v, expected a valid polygon", err)
+				}
+				if orientation(p.Points[0], p.Points[1], p.Points[2]) <= 0 {
+					t.Errorf("Points = %v, expected counter-clockwise order", p.Points)
+				}
+				return
+			}
+			var geometryErr *GeometryError
+			if !errors.As(err, &geometryErr) {
+				t.Errorf("NewPolygon() error = %v, expected a *GeometryError", err)
+			}
+		})
+	}
+}
+
+func TestPolygon_CenterAndVertices(t *testing.T) {
+	p := triangle(t)
+	if p.GetCenter() != (Point{2, 2}) {
+		t.Errorf("GetCenter() = %v, expected the centroid (2, 2)", p.GetCenter())
+	}
+
+	p.SetCenter(Point{12, 2})
+	expected := []Point{{10, 0}, {16, 0}, {10, 6}}
+	for i, v := range p.Vertices() {
+		if math.Abs(v.X-expected[i].X) > 1e-9 || math.Abs(v.Y-expected[i].Y) > 1e-9 {
+			t.Errorf("Vertices()[%d] = %v, expected %v", i, v, expected[i])
+		}
+	}
+
+	clone := p.Clone().(*Polygon)
+	clone.Points[0] = Point{100, 100}
+	if p.Points[0] == clone.Points[0] {
+		t.Errorf("Clone() shares its vertex slice with the original")
+	}
+}
+
+func TestPolygon_GetBounds(t *testing.T) {
+	square, err := NewPolygon([]Point{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}})
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rotation float64
+		expected Bounds
+	}{
+		{
+			name:     "Unrotated",
+			expected: Bounds{MinX: -1, MinY: -1, MaxX: 1, MaxY: 1},
+		},
+		{
+			name:     "Rotated 45 degrees",
+			rotation: math.Pi / 4,
+			expected: Bounds{MinX: -math.Sqrt2, MinY: -math.Sqrt2, MaxX: math.Sqrt2, MaxY: math.Sqrt2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			square.SetRotation(tt.rotation)
+			got := square.GetBounds()
+			if math.Abs(got.MinX-tt.expected.MinX) > 1e-9 || math.Abs(got.MaxY-tt.expected.MaxY) > 1e-9 {
+				t.Errorf("GetBounds() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygonIntersects_Detailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		rect     Rectangle
+		expected bool
+	}{
+		{
+			name:     "Touching the hypotenuse at a corner",
+			rect:     Rectangle{Point{4, 4}, 2, 2},
+			expected: false,
+		},
+		{
+			name:     "Crossing the hypotenuse",
+			rect:     Rectangle{Point{3.5, 3.5}, 2, 2},
+			expected: true,
+		},
+		{
+			name:     "Touching the vertical leg",
+			rect:     Rectangle{Point{-1, 3}, 2, 2},
+			expected: false,
+		},
+		{
+			name:     "Overlapping the vertical leg",
+			rect:     Rectangle{Point{-0.5, 3}, 2, 2},
+			expected: true,
+		},
+		{
+			name:     "Inside the bounding box but outside the triangle",
+			rect:     Rectangle{Point{5, 5}, 1, 1},
+			expected: false,
+		},
+	}
+
+	p := triangle(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.IntersectsRectangle(&tt.rect); got != tt.expected {
+				t.Errorf("IntersectsRectangle() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygon_IntersectsPolygon(t *testing.T) {
+	p := triangle(t)
+
+	// Mirrored through the hypotenuse the triangles share only that edge.
+	other, err := NewPolygon([]Point{{6, 6}, {0, 6}, {6, 0}})
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+	if p.IntersectsPolygon(other) {
+		t.Errorf("IntersectsPolygon() = true, expected triangles sharing an edge not to intersect")
+	}
+
+	other.SetCenter(Point{3.5, 3.5})
+	if !p.IntersectsPolygon(other) {
+		t.Errorf("IntersectsPolygon() = false, expected overlapping triangles to intersect")
+	}
+
+	obb := NewOrientedRectangle(Point{4, 4}, 4, 1, math.Pi/4)
+	if !p.IntersectsOrientedRectangle(obb) {
+		t.Errorf("IntersectsOrientedRectangle() = false, expected the slanted box across the hypotenuse to intersect")
+	}
+}
+
+func TestPolygonContains_Detailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		inner    Rectangle
+		expected bool
+	}{
+		{
+			name:     "Fully contained",
+			inner:    Rectangle{Point{1, 1}, 1, 1},
+			expected: true,
+		},
+		{
+			name:     "Corner on the hypotenuse",
+			inner:    Rectangle{Point{2.5, 2.5}, 1, 1},
+			expected: true,
+		},
+		{
+			name:     "Corner past the hypotenuse",
+			inner:    Rectangle{Point{3, 3}, 2, 2},
+			expected: false,
+		},
+	}
+
+	p := triangle(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ContainsRectangle(&tt.inner); got != tt.expected {
+				t.Errorf("Contains() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygon_IntersectsCircle(t *testing.T) {
+	tests := []struct {
+		name     string
+		circle   Circle
+		expected bool
+	}{
+		{
+			name:     "Circle short of the hypotenuse",
+			circle:   Circle{Point{4, 4}, 1},
+			expected: false,
+		},
+		{
+			name:     "Circle crossing the hypotenuse",
+			circle:   Circle{Point{4, 4}, 1.5},
+			expected: true,
+		},
+		{
+			name:     "Circle touching a vertex",
+			circle:   Circle{Point{7, -1}, math.Sqrt2},
+			expected: true,
+		},
+		{
+			name:     "Circle completely inside",
+			circle:   Circle{Point{1, 1}, 0.1},
+			expected: true,
+		},
+		{
+			name:     "Circle completely outside",
+			circle:   Circle{Point{10, 10}, 1},
+			expected: false,
+		},
+	}
+
+	p := triangle(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.IntersectsCircle(&tt.circle); got != tt.expected {
+				t.Errorf("IntersectsCircle() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygon_ContainsCircle(t *testing.T) {
+	tests := []struct {
+		name     string
+		circle   Circle
+		expected bool
+	}{
+		{
+			name:     "Circle close to the incircle",
+			circle:   Circle{Point{1.75, 1.75}, 1.7},
+			expected: true,
+		},
+		{
+			name:     "Circle larger than the incircle",
+			circle:   Circle{Point{1.75, 1.75}, 1.8},
+			expected: false,
+		},
+		{
+			name:     "Circle completely outside",
+			circle:   Circle{Point{10, 10}, 1},
+			expected: false,
+		},
+	}
+
+	p := triangle(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ContainsCircle(&tt.circle); got != tt.expected {
+				t.Errorf("ContainsCircle() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolygon_Lines(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       Line
+		intersects bool
+		contains   bool
+	}{
+		{
+			name:       "Line inside",
+			line:       Line{Start: Point{1, 1}, End: Point{2, 3}},
+			intersects: true,
+			contains:   true,
+		},
+		{
+			name:       "Line crossing the hypotenuse",
+			line:       Line{Start: Point{1, 1}, End: Point{5, 5}},
+			intersects: true,
+			contains:   false,
+		},
+		{
+			name:       "Line along the hypotenuse",
+			line:       Line{Start: Point{6, 0}, End: Point{0, 6}},
+			intersects: true,
+			contains:   true,
+		},
+		{
+			name:       "Line outside",
+			line:       Line{Start: Point{4, 4}, End: Point{8, 2}},
+			intersects: false,
+			contains:   false,
+		},
+	}
+
+	p := triangle(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.IntersectsLine(&tt.line); got != tt.intersects {
+				t.Errorf("IntersectsLine() = %v, expected %v", got, tt.intersects)
+			}
+			if got := p.ContainsLine(&tt.line); got != tt.contains {
+				t.Errorf("ContainsLine() = %v, expected %v", got, tt.contains)
+			}
+		})
+	}
+}
+
+func TestPolygon_ContainsPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		point    Point
+		expected bool
+	}{
+		{
+			name:     "Point at vertex",
+			point:    Point{0, 0},
+			expected: true,
+		},
+		{
+			name:     "Point on the hypotenuse",
+			point:    Point{3, 3},
+			expected: true,
+		},
+		{
+			name:     "Point just past the hypotenuse",
+			point:    Point{3.1, 3.1},
+			expected: false,
+		},
+		{
+			name:     "Point outside on X axis",
+			point:    Point{-0.1, 1},
+			expected: false,
+		},
+	}
+
+	p := triangle(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.ContainsPoint(&tt.point); got != tt.expected {
+				t.Errorf("ContainsPoint() = %v, expected %v", got, tt.expected)
+			}
+			if got := p.IntersectsPoint(&tt.point); got != tt.expected {
+				t.Errorf("IntersectsPoint() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -30,6 +30,12 @@ func (r *Rectangle) SetCenter(center Point) {
 	r.Center = center
 }
 
+// Clone returns an independent copy of the rectangle.
+func (r *Rectangle) Clone() Shape {
+	clone := *r
+	return &clone
+}
+
 func (r *Rectangle) GetWidth() float64 {
 	return r.Width
 }
@@ -47,6 +53,18 @@ func (r *Rectangle) GetBounds() Bounds {
 	}
 }
 
+// Vertices returns the four corners in counter-clockwise order, starting at
+// the minimum corner.
+func (r *Rectangle) Vertices() []Point {
+	b := r.GetBounds()
+	return []Point{
+		{X: b.MinX, Y: b.MinY},
+		{X: b.MaxX, Y: b.MinY},
+		{X: b.MaxX, Y: b.MaxY},
+		{X: b.MinX, Y: b.MaxY},
+	}
+}
+
 func (r *Rectangle) IntersectsRectangle(other *Rectangle) bool {
 	return !(r.Center.X+r.Width/2 <= other.Center.X-other.Width/2 ||
 		r.Center.X-r.Width/2 >= other.Center.X+other.Width/2 ||
@@ -32,6 +32,28 @@ func (v *Vector2) Length() float64 {
 	return math.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
+func (v *Vector2) LengthSquared() float64 {
+	return v.X*v.X + v.Y*v.Y
+}
+
+func (v *Vector2) Dot(other *Vector2) float64 {
+	return v.X*other.X + v.Y*other.Y
+}
+
+// Cross returns the z component of the 3D cross product of v and other.
+func (v *Vector2) Cross(other *Vector2) float64 {
+	return v.X*other.Y - v.Y*other.X
+}
+
+// Perpendicular returns v rotated by 90 degrees counter-clockwise.
+func (v *Vector2) Perpendicular() *Vector2 {
+	return &Vector2{X: -v.Y, Y: v.X}
+}
+
+func (v *Vector2) Negate() *Vector2 {
+	return &Vector2{X: -v.X, Y: -v.Y}
+}
+
 func (v *Vector2) Normalize() *Vector2 {
 	length := v.Length()
 	if length == 0 {
@@ -0,0 +1,292 @@
+package pathfinding
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+var (
+	ErrGoalBlocked = errors.New("goal is not walkable")
+	ErrNoPath      = errors.New("no path between start and goal")
+	ErrSearchLimit = errors.New("search gave up after MaxNodes expansions")
+)
+
+// Diagonal is the rule deciding when a diagonal step may be taken.
+type Diagonal int
+
+const (
+	// DiagonalNoCornerCutting allows a diagonal step only when both cells
+	// beside it are walkable, so agents never clip a wall's corner.
+	DiagonalNoCornerCutting Diagonal = iota
+	// DiagonalOneFree allows a diagonal step when at least one of the cells
+	// beside it is walkable.
+	DiagonalOneFree
+	// DiagonalAlways only needs the target cell to be walkable, letting
+	// agents squeeze between diagonally touching walls.
+	DiagonalAlways
+	// DiagonalNever restricts movement to the four orthogonal directions.
+	DiagonalNever
+)
+
+// Options tune a single search.
+type Options struct {
+	Diagonal    Diagonal // Corner-cutting rule, defaults to DiagonalNoCornerCutting
+	AgentRadius float64  // Walls are kept this far from the agent's center
+	NoSmoothing bool     // Return one waypoint per cell instead of a string-pulled path
+	MaxNodes    int      // Upper bound of expanded cells, 0 means unlimited
+}
+
+// Pathfinder searches paths on a walkability grid. Grids inflated for an
+// agent radius are built once and reused. A Pathfinder is safe for
+// concurrent use as long as the grid is not modified.
+type Pathfinder struct {
+	grid *Grid
+
+	mu       sync.Mutex
+	inflated map[float64]*Grid // by agent radius
+}
+
+// NewPathfinder creates a pathfinder searching grid.
+func NewPathfinder(grid *Grid) *Pathfinder {
+	return &Pathfinder{grid: grid, inflated: make(map[float64]*Grid)}
+}
+
+// Grid returns the walkability grid searched for agents of the given radius.
+func (p *Pathfinder) Grid(radius float64) *Grid {
+	if radius <= 0 {
+		return p.grid
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g, exists := p.inflated[radius]
+	if !exists {
+		g = p.grid.Inflate(radius)
+		p.inflated[radius] = g
+	}
+	return g
+}
+
+// FindPath returns world-space waypoints leading from start to goal, both
+// included. In between lie the centers of the cells on the path; unless
+// NoSmoothing is set, every waypoint that a straight walkable line can skip
+// is dropped. The start cell may be blocked, so an agent pushed against a wall can still
+// walk away from it; a blocked goal returns ErrGoalBlocked.
+func (p *Pathfinder) FindPath(start, goal geometry.Point, opts Options) ([]geometry.Point, error) {
+	g := p.Grid(opts.AgentRadius)
+	cells, err := search(g, g.Coords.WorldToCell(start), g.Coords.WorldToCell(goal), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make([]geometry.Point, 0, len(cells)+1)
+	path = append(path, start)
+	for _, c := range cells[1 : len(cells)-1] {
+		path = append(path, g.Coords.CellCenter(c))
+	}
+	path = append(path, goal)
+	if opts.NoSmoothing {
+		return path, nil
+	}
+	return smooth(g, path), nil
+}
+
+// FindCellPath returns the cells of the shortest path from start to goal,
+// both included.
+func (p *Pathfinder) FindCellPath(start, goal coordinate.Cell, opts Options) ([]coordinate.Cell, error) {
+	return search(p.Grid(opts.AgentRadius), start, goal, opts)
+}
+
+// smooth removes every waypoint the path can skip with a straight line
+// (string pulling).
+func smooth(g *Grid, path []geometry.Point) []geometry.Point {
+	if len(path) <= 2 {
+		return path
+	}
+	smoothed := []geometry.Point{path[0]}
+	anchor := 0
+	for anchor < len(path)-1 {
+		next := anchor + 1
+		for i := len(path) - 1; i > anchor+1; i-- {
+			if g.LineOfSight(path[anchor], path[i]) {
+				next = i
+				break
+			}
+		}
+		smoothed = append(smoothed, path[next])
+		anchor = next
+	}
+	return smoothed
+}
+
+// ---------------------------------------------------------------------------
+// A* search
+// ---------------------------------------------------------------------------
+
+const (
+	straightCost = 1.0
+	diagonalCost = math.Sqrt2
+)
+
+var directions = [8]coordinate.Cell{
+	{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1},
+	{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1},
+}
+
+func search(g *Grid, start, goal coordinate.Cell, opts Options) ([]coordinate.Cell, error) {
+	if g.IsBlocked(goal) {
+		return nil, ErrGoalBlocked
+	}
+	if !g.InBounds(start) {
+		return nil, ErrNoPath
+	}
+	if start == goal {
+		return []coordinate.Cell{start, goal}, nil
+	}
+
+	n := g.Width * g.Height
+	cost := make([]float64, n)
+	parent := make([]int32, n)
+	closed := make([]bool, n)
+	for i := range cost {
+		cost[i] = math.Inf(1)
+		parent[i] = -1
+	}
+
+	index := func(c coordinate.Cell) int { return c.Y*g.Width + c.X }
+	startIndex, goalIndex := index(start), index(goal)
+	cost[startIndex] = 0
+	open := make(openSet, 0, 64)
+	open.push(node{index: startIndex, f: octile(start, goal), h: octile(start, goal)})
+
+	neighbours := directions[:]
+	if opts.Diagonal == DiagonalNever {
+		neighbours = directions[:4]
+	}
+
+	expanded := 0
+	for len(open) > 0 {
+		current := open.pop()
+		if closed[current.index] {
+			continue
+		}
+		if current.index == goalIndex {
+			return reconstruct(g, parent, goalIndex), nil
+		}
+		closed[current.index] = true
+		if expanded++; opts.MaxNodes > 0 && expanded > opts.MaxNodes {
+			return nil, ErrSearchLimit
+		}
+
+		cell := coordinate.Cell{X: current.index % g.Width, Y: current.index / g.Width}
+		for _, d := range neighbours {
+			next := coordinate.Cell{X: cell.X + d.X, Y: cell.Y + d.Y}
+			if g.IsBlocked(next) || closed[index(next)] {
+				continue
+			}
+			step := straightCost
+			if d.X != 0 && d.Y != 0 {
+				if !canCutCorner(g, cell, d, opts.Diagonal) {
+					continue
+				}
+				step = diagonalCost
+			}
+			i := index(next)
+			if tentative := cost[current.index] + step; tentative < cost[i] {
+				cost[i] = tentative
+				parent[i] = int32(current.index)
+				h := octile(next, goal)
+				open.push(node{index: i, f: tentative + h, h: h})
+			}
+		}
+	}
+	return nil, ErrNoPath
+}
+
+func canCutCorner(g *Grid, from, d coordinate.Cell, rule Diagonal) bool {
+	sideX := !g.IsBlocked(coordinate.Cell{X: from.X + d.X, Y: from.Y})
+	sideY := !g.IsBlocked(coordinate.Cell{X: from.X, Y: from.Y + d.Y})
+	switch rule {
+	case DiagonalAlways:
+		return true
+	case DiagonalOneFree:
+		return sideX || sideY
+	default:
+		return sideX && sideY
+	}
+}
+
+// octile is the exact cost of an unobstructed 8-way path, an admissible
+// heuristic for every movement rule.
+func octile(a, b coordinate.Cell) float64 {
+	dx, dy := float64(abs(a.X-b.X)), float64(abs(a.Y-b.Y))
+	return math.Max(dx, dy) + (diagonalCost-1)*math.Min(dx, dy)
+}
+
+func reconstruct(g *Grid, parent []int32, goal int) []coordinate.Cell {
+	cells := make([]coordinate.Cell, 0)
+	for i := goal; i >= 0; i = int(parent[i]) {
+		cells = append(cells, coordinate.Cell{X: i % g.Width, Y: i / g.Width})
+	}
+	for l, r := 0, len(cells)-1; l < r; l, r = l+1, r-1 {
+		cells[l], cells[r] = cells[r], cells[l]
+	}
+	return cells
+}
+
+type node struct {
+	index int
+	f, h  float64
+}
+
+// openSet is a binary min-heap on f. Ties prefer the node closer to the
+// goal, which keeps A* from fanning out across open areas. It is typed rather
+// than built on container/heap to avoid boxing every pushed node.
+type openSet []node
+
+func (s openSet) less(i, j int) bool {
+	if s[i].f != s[j].f {
+		return s[i].f < s[j].f
+	}
+	return s[i].h < s[j].h
+}
+
+func (s *openSet) push(n node) {
+	*s = append(*s, n)
+	h := *s
+	for i := len(h) - 1; i > 0; {
+		up := (i - 1) / 2
+		if !h.less(i, up) {
+			break
+		}
+		h[i], h[up] = h[up], h[i]
+		i = up
+	}
+}
+
+func (s *openSet) pop() node {
+	h := *s
+	top := h[0]
+	last := len(h) - 1
+	h[0] = h[last]
+	h = h[:last]
+	for i := 0; ; {
+		smallest, l, r := i, 2*i+1, 2*i+2
+		if l < len(h) && h.less(l, smallest) {
+			smallest = l
+		}
+		if r < len(h) && h.less(r, smallest) {
+			smallest = r
+		}
+		if smallest == i {
+			break
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+	*s = h
+	return top
+}
@@ -0,0 +1,321 @@
+package pathfinding
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/data"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// --- Test Helpers ---
+
+// parseGrid builds a grid from rows of '.' (walkable) and '#' (blocked)
+// with 16px cells at the world origin.
+func parseGrid(t *testing.T, rows ...string) *Grid {
+	t.Helper()
+	cd := &data.CollisionData{Width: len(rows[0]), Height: len(rows)}
+	for _, row := range rows {
+		for _, r := range row {
+			if r == '#' {
+				cd.Collision = append(cd.Collision, 1)
+			} else {
+				cd.Collision = append(cd.Collision, 0)
+			}
+		}
+	}
+	g, err := NewGridFromCollisionData(cd, coordinate.NewGrid(16, 0, 0), nil)
+	if err != nil {
+		t.Fatalf("NewGridFromCollisionData() error = %v", err)
+	}
+	return g
+}
+
+func center(x, y int) geometry.Point {
+	return geometry.Point{X: float64(x)*16 + 8, Y: float64(y)*16 + 8}
+}
+
+func pathLength(path []geometry.Point) float64 {
+	length := 0.0
+	for i := 1; i < len(path); i++ {
+		length += math.Hypot(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+	}
+	return length
+}
+
+// randomMaze returns a size x size grid with roughly density blocked cells,
+// keeping the corners open.
+func randomMaze(size int, density float64, seed int64) *Grid {
+	rng := rand.New(rand.NewSource(seed))
+	g := NewGrid(size, size, coordinate.NewGrid(16, 0, 0))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			g.SetBlocked(coordinate.Cell{X: x, Y: y}, rng.Float64() < density)
+		}
+	}
+	g.SetBlocked(coordinate.Cell{X: 0, Y: 0}, false)
+	g.SetBlocked(coordinate.Cell{X: size - 1, Y: size - 1}, false)
+	return g
+}
+
+// --- Tests ---
+
+func TestFindPath_AroundWall(t *testing.T) {
+	g := parseGrid(t,
+		".....",
+		".###.",
+		".#...",
+		".#.#.",
+		".....",
+	)
+	p := NewPathfinder(g)
+
+	path, err := p.FindPath(center(2, 2), center(0, 0), Options{})
+	if err != nil {
+		t.Fatalf("FindPath() error = %v", err)
+	}
+	if path[0] != center(2, 2) || path[len(path)-1] != center(0, 0) {
+		t.Errorf("path = %v, expected it to start and end at the given points", path)
+	}
+	for i := 1; i < len(path); i++ {
+		if !g.LineOfSight(path[i-1], path[i]) {
+			t.Errorf("segment %v -> %v crosses a wall", path[i-1], path[i])
+		}
+	}
+
+	raw, err := p.FindPath(center(2, 2), center(0, 0), Options{NoSmoothing: true})
+	if err != nil {
+		t.Fatalf("FindPath() error = %v", err)
+	}
+	if len(path) >= len(raw) || pathLength(path) > pathLength(raw) {
+		t.Errorf("smoothed path %v is not shorter than %v", path, raw)
+	}
+}
+
+func TestFindCellPath_CornerCutting(t *testing.T) {
+	// The only way from the top-left to the bottom-right is through the
+	// corner where the two walls touch.
+	g := parseGrid(t,
+		".#",
+		"#.",
+	)
+	p := NewPathfinder(g)
+	start, goal := coordinate.Cell{X: 0, Y: 0}, coordinate.Cell{X: 1, Y: 1}
+
+	tests := []struct {
+		rule     Diagonal
+		expected error
+	}{
+		{DiagonalNoCornerCutting, ErrNoPath},
+		{DiagonalOneFree, ErrNoPath},
+		{DiagonalAlways, nil},
+		{DiagonalNever, ErrNoPath},
+	}
+	for _, tt := range tests {
+		if _, err := p.FindCellPath(start, goal, Options{Diagonal: tt.rule}); !errors.Is(err, tt.expected) {
+			t.Errorf("rule %d: error = %v, expected %v", tt.rule, err, tt.expected)
+		}
+	}
+
+	// With one side open, OneFree cuts the corner and NoCornerCutting walks
+	// around it.
+	g.SetBlocked(coordinate.Cell{X: 1, Y: 0}, false)
+	if cells, _ := p.FindCellPath(start, goal, Options{Diagonal: DiagonalOneFree}); len(cells) != 2 {
+		t.Errorf("OneFree path = %v, expected a single diagonal step", cells)
+	}
+	if cells, _ := p.FindCellPath(start, goal, Options{}); len(cells) != 3 {
+		t.Errorf("NoCornerCutting path = %v, expected to walk around the corner", cells)
+	}
+}
+
+func TestFindCellPath_Optimal(t *testing.T) {
+	g := parseGrid(t,
+		"..........",
+		"..........",
+		"..........",
+	)
+	p := NewPathfinder(g)
+
+	cells, err := p.FindCellPath(coordinate.Cell{X: 0, Y: 0}, coordinate.Cell{X: 9, Y: 2}, Options{})
+	if err != nil {
+		t.Fatalf("FindCellPath() error = %v", err)
+	}
+	// 2 diagonal and 7 straight steps.
+	if len(cells) != 10 {
+		t.Errorf("len(cells) = %d, expected 10", len(cells))
+	}
+
+	cells, err = p.FindCellPath(coordinate.Cell{X: 0, Y: 0}, coordinate.Cell{X: 9, Y: 2}, Options{Diagonal: DiagonalNever})
+	if err != nil {
+		t.Fatalf("FindCellPath() error = %v", err)
+	}
+	if len(cells) != 12 {
+		t.Errorf("4-way len(cells) = %d, expected 12", len(cells))
+	}
+}
+
+func TestFindPath_AgentRadius(t *testing.T) {
+	// A one cell wide gap in the middle wall.
+	g := parseGrid(t,
+		"..#..",
+		".....",
+		"..#..",
+		"..#..",
+		"..#..",
+	)
+	p := NewPathfinder(g)
+	start, goal := center(0, 4), center(4, 4)
+
+	if _, err := p.FindPath(start, goal, Options{AgentRadius: 6}); err != nil {
+		t.Errorf("small agent: FindPath() error = %v, expected to fit through the gap", err)
+	}
+	if _, err := p.FindPath(start, goal, Options{AgentRadius: 10}); !errors.Is(err, ErrNoPath) {
+		t.Errorf("large agent: FindPath() error = %v, expected ErrNoPath", err)
+	}
+	if p.Grid(10) != p.Grid(10) {
+		t.Errorf("inflated grid was rebuilt for the same radius")
+	}
+}
+
+func TestFindPath_Errors(t *testing.T) {
+	g := parseGrid(t,
+		"..#..",
+		"..#..",
+	)
+	p := NewPathfinder(g)
+
+	if _, err := p.FindPath(center(0, 0), center(2, 0), Options{}); !errors.Is(err, ErrGoalBlocked) {
+		t.Errorf("blocked goal: error = %v", err)
+	}
+	if _, err := p.FindPath(center(0, 0), center(4, 0), Options{}); !errors.Is(err, ErrNoPath) {
+		t.Errorf("walled off goal: error = %v", err)
+	}
+	if _, err := p.FindPath(center(0, 0), center(4, 0), Options{MaxNodes: 1}); !errors.Is(err, ErrSearchLimit) {
+		t.Errorf("MaxNodes: error = %v", err)
+	}
+	if path, err := p.FindPath(geometry.Point{X: 2, Y: 2}, geometry.Point{X: 10, Y: 4}, Options{}); err != nil || len(path) != 2 {
+		t.Errorf("same cell: path = %v, error = %v, expected start and goal", path, err)
+	}
+}
+
+func TestNewGridFromCollisionData_SizeMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []int
+	}{
+		{"Truncated", []int{0, 1, 0, 0, 1}},
+		{"Empty", nil},
+		{"Too long", make([]int, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cd := &data.CollisionData{Name: "Collisions", Width: 3, Height: 2, Collision: tt.cells}
+			g, err := NewGridFromCollisionData(cd, coordinate.NewGrid(16, 0, 0), nil)
+			if !errors.Is(err, ErrGridSize) || g != nil {
+				t.Errorf("NewGridFromCollisionData() = %v, %v, expected ErrGridSize", g, err)
+			}
+		})
+	}
+}
+
+func TestNewGridFromColliders(t *testing.T) {
+	wall := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{Width: 32, Height: 16}},
+		Transform: geometry.Vector2{X: 32, Y: 24},
+		Enabled:   true,
+	}
+	wall.LayerMask.SetBit(collider.LayerWall)
+	trigger := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{Width: 16, Height: 16}},
+		Transform: geometry.Vector2{X: 8, Y: 8},
+		Enabled:   true,
+		IsTrigger: true,
+	}
+	var mask collider.Bitmask
+	mask.SetBit(collider.LayerWall)
+
+	g := NewGridFromColliders([]*collider.Collider{wall, trigger}, geometry.Bounds{MaxX: 64, MaxY: 48}, 16, mask)
+
+	var rows []string
+	for y := 0; y < g.Height; y++ {
+		row := ""
+		for x := 0; x < g.Width; x++ {
+			if g.IsBlocked(coordinate.Cell{X: x, Y: y}) {
+				row += "#"
+			} else {
+				row += "."
+			}
+		}
+		rows = append(rows, row)
+	}
+	if got := strings.Join(rows, "/"); got != "..../.##./...." {
+		t.Errorf("grid = %s, expected the wall to cover two cells", got)
+	}
+}
+
+func TestGrid_LineOfSight(t *testing.T) {
+	g := parseGrid(t,
+		"....",
+		".#..",
+		"....",
+	)
+
+	tests := []struct {
+		name     string
+		a, b     geometry.Point
+		expected bool
+	}{
+		{"open row", center(0, 0), center(3, 0), true},
+		{"through the wall", center(0, 1), center(3, 1), false},
+		{"grazing the corner", geometry.Point{X: 0, Y: 0}, geometry.Point{X: 16, Y: 16}, false},
+		{"diagonal beside the wall", center(2, 0), center(3, 2), true},
+		{"into the wall", center(0, 0), center(1, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.LineOfSight(tt.a, tt.b); got != tt.expected {
+				t.Errorf("LineOfSight(%v, %v) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+// --- Benchmarks ---
+
+func benchmarkFindPath(b *testing.B, size int, opts Options) {
+	g := randomMaze(size, 0.2, 7)
+	p := NewPathfinder(g)
+	p.Grid(opts.AgentRadius) // inflate outside of the timer
+	start, goal := center(0, 0), center(size-1, size-1)
+	if _, err := p.FindPath(start, goal, opts); err != nil {
+		b.Fatalf("FindPath() error = %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.FindPath(start, goal, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindPath_128(b *testing.B)  { benchmarkFindPath(b, 128, Options{}) }
+func BenchmarkFindPath_512(b *testing.B)  { benchmarkFindPath(b, 512, Options{}) }
+func BenchmarkFindPath_1024(b *testing.B) { benchmarkFindPath(b, 1024, Options{}) }
+
+func BenchmarkFindPath_512_Unsmoothed(b *testing.B) {
+	benchmarkFindPath(b, 512, Options{NoSmoothing: true})
+}
+
+func BenchmarkGrid_Inflate_512(b *testing.B) {
+	g := randomMaze(512, 0.25, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Inflate(12)
+	}
+}
@@ -0,0 +1,7 @@
+// Package pathfinding finds paths for NPCs, either on walkability grids built
+// from IntGrid collision data or static colliders, or on hand-placed waypoint
+// graphs. Grid searches run A* with 8-way movement, keep agents of a given
+// radius clear of walls and return smoothed world-space waypoints; the
+// Scheduler spreads them over ticks. Swarms chasing one target share a
+// FlowField instead.
+package pathfinding
@@ -0,0 +1,194 @@
+package pathfinding
+
+import (
+	"math"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// FlowField steers any number of agents toward a single target, e.g. a swarm
+// of daemons chasing a player. It holds an integration field (the path cost
+// from every cell to the target) and a direction field (where to walk from
+// every cell), so agents only look up their cell instead of running A*.
+//
+// When the target moves to another cell the field is rebuilt incrementally:
+// Update integrates a bounded number of cells per call. Cells reached by the
+// new build switch to their new direction right away, always pointing into
+// the already integrated area around the new target, while the others keep
+// following the previous target, which lies close by. A FlowField is
+// not safe for concurrent use.
+type FlowField struct {
+	grid     *Grid
+	diagonal Diagonal
+
+	target    coordinate.Cell
+	goal      geometry.Point
+	hasTarget bool
+
+	cost       []float64          // integration field of the current build
+	reached    []uint32           // build generation cost was last written in
+	settled    []uint32           // build generation a cell was settled in
+	direction  []geometry.Vector2 // unit vector per cell, zero where there is no way
+	generation uint32
+	open       openSet
+	complete   bool
+}
+
+// NewFlowField creates a flow field over grid without a target. diagonal
+// decides whether agents may cut corners, see Diagonal.
+func NewFlowField(grid *Grid, diagonal Diagonal) *FlowField {
+	n := grid.Width * grid.Height
+	return &FlowField{
+		grid:      grid,
+		diagonal:  diagonal,
+		cost:      make([]float64, n),
+		reached:   make([]uint32, n),
+		settled:   make([]uint32, n),
+		direction: make([]geometry.Vector2, n),
+	}
+}
+
+// SetTarget moves the target. A new build only starts when the target
+// changes cell; it returns ErrGoalBlocked for targets on blocked cells, which
+// keeps the previous target.
+func (f *FlowField) SetTarget(p geometry.Point) error {
+	cell := f.grid.Coords.WorldToCell(p)
+	if f.grid.IsBlocked(cell) {
+		return ErrGoalBlocked
+	}
+	f.goal = p
+	if f.hasTarget && cell == f.target {
+		return nil
+	}
+
+	f.target, f.hasTarget = cell, true
+	f.generation++
+	f.complete = false
+	f.open = f.open[:0]
+	i := f.index(cell)
+	f.cost[i], f.reached[i] = 0, f.generation
+	f.open.push(node{index: i})
+	return nil
+}
+
+// Update integrates up to budget cells of the current build, all of them
+// when budget is 0. It reports whether the build is complete.
+func (f *FlowField) Update(budget int) bool {
+	if f.complete || !f.hasTarget {
+		return f.complete
+	}
+
+	neighbours := directions[:]
+	if f.diagonal == DiagonalNever {
+		neighbours = directions[:4]
+	}
+	for expanded := 0; len(f.open) > 0 && (budget <= 0 || expanded < budget); {
+		current := f.open.pop()
+		if f.settled[current.index] == f.generation || current.f > f.cost[current.index] {
+			continue // settled already, or a stale entry
+		}
+		f.settled[current.index] = f.generation
+		expanded++
+
+		cell := f.cell(current.index)
+		for _, d := range neighbours {
+			next := coordinate.Cell{X: cell.X + d.X, Y: cell.Y + d.Y}
+			if f.grid.IsBlocked(next) {
+				continue
+			}
+			step := straightCost
+			if d.X != 0 && d.Y != 0 {
+				// Agents walk from next to cell, so check the corner from there.
+				if !canCutCorner(f.grid, next, coordinate.Cell{X: -d.X, Y: -d.Y}, f.diagonal) {
+					continue
+				}
+				step = diagonalCost
+			}
+			i := f.index(next)
+			tentative := current.f + step
+			if f.settled[i] == f.generation || (f.reached[i] == f.generation && tentative >= f.cost[i]) {
+				continue
+			}
+			f.cost[i], f.reached[i] = tentative, f.generation
+			// Walking from next toward cell: the reverse of d.
+			f.direction[i] = geometry.Vector2{X: -float64(d.X), Y: -float64(d.Y)}
+			if d.X != 0 && d.Y != 0 {
+				f.direction[i] = geometry.Vector2{X: -float64(d.X) / math.Sqrt2, Y: -float64(d.Y) / math.Sqrt2}
+			}
+			f.open.push(node{index: i, f: tentative})
+		}
+	}
+
+	if len(f.open) == 0 {
+		// Cells the build never reached have no way to the target.
+		for i := range f.direction {
+			if f.settled[i] != f.generation {
+				f.direction[i] = geometry.Vector2{}
+			}
+		}
+		f.direction[f.index(f.target)] = geometry.Vector2{}
+		f.complete = true
+	}
+	return f.complete
+}
+
+// Complete reports whether the current build is finished.
+func (f *FlowField) Complete() bool {
+	return f.complete
+}
+
+// Cost returns the integration field at p: the path cost to the target in
+// cells. It is +Inf for cells not (yet) reached by the current build.
+func (f *FlowField) Cost(p geometry.Point) float64 {
+	cell := f.grid.Coords.WorldToCell(p)
+	if !f.grid.InBounds(cell) || f.settled[f.index(cell)] != f.generation || !f.hasTarget {
+		return math.Inf(1)
+	}
+	return f.cost[f.index(cell)]
+}
+
+// Direction returns the unit vector an agent at p should walk along. Inside
+// the target cell it points straight at the target; it is zero on the
+// target itself and where the target cannot be reached.
+func (f *FlowField) Direction(p geometry.Point) geometry.Vector2 {
+	cell := f.grid.Coords.WorldToCell(p)
+	if !f.hasTarget || !f.grid.InBounds(cell) {
+		return geometry.Vector2{}
+	}
+	if cell == f.target {
+		dx, dy := f.goal.X-p.X, f.goal.Y-p.Y
+		length := math.Hypot(dx, dy)
+		if length < 1e-9 {
+			return geometry.Vector2{}
+		}
+		return geometry.Vector2{X: dx / length, Y: dy / length}
+	}
+	return f.direction[f.index(cell)]
+}
+
+// DesiredVelocity is the steering input of an agent at p moving at maxSpeed.
+func (f *FlowField) DesiredVelocity(p geometry.Point, maxSpeed float64) geometry.Vector2 {
+	d := f.Direction(p)
+	return geometry.Vector2{X: d.X * maxSpeed, Y: d.Y * maxSpeed}
+}
+
+// Follow sets the velocity of body to its desired velocity. Bodies without a
+// transform are left alone.
+func (f *FlowField) Follow(body *components.PhysicComponent, maxSpeed float64) {
+	transform := body.GetTransform()
+	if transform == nil || transform.Position == nil {
+		return
+	}
+	v := f.DesiredVelocity(*transform.Position, maxSpeed)
+	body.SetVelocity(v.X, v.Y)
+}
+
+func (f *FlowField) index(c coordinate.Cell) int {
+	return c.Y*f.grid.Width + c.X
+}
+
+func (f *FlowField) cell(i int) coordinate.Cell {
+	return coordinate.Cell{X: i % f.grid.Width, Y: i / f.grid.Width}
+}
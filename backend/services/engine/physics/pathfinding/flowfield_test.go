@@ -0,0 +1,174 @@
+package pathfinding
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// walk follows the direction field cell by cell from start and returns the
+// cell it stops in, giving up after limit steps.
+func walk(f *FlowField, g *Grid, start coordinate.Cell, limit int) coordinate.Cell {
+	cell := start
+	for i := 0; i < limit; i++ {
+		d := f.Direction(g.Coords.CellCenter(cell))
+		if d.X == 0 && d.Y == 0 {
+			break
+		}
+		next := coordinate.Cell{X: cell.X + int(math.Round(d.X*math.Sqrt2)), Y: cell.Y + int(math.Round(d.Y*math.Sqrt2))}
+		if d.X == 0 || d.Y == 0 {
+			next = coordinate.Cell{X: cell.X + int(math.Round(d.X)), Y: cell.Y + int(math.Round(d.Y))}
+		}
+		if g.IsBlocked(next) {
+			return cell // walked into a wall
+		}
+		cell = next
+	}
+	return cell
+}
+
+func TestFlowField_LeadsEveryCellToTarget(t *testing.T) {
+	g := parseGrid(t,
+		"........",
+		".######.",
+		".#....#.",
+		".#.##.#.",
+		"...#....",
+		"########",
+		"........",
+	)
+	f := NewFlowField(g, DiagonalNoCornerCutting)
+	target := coordinate.Cell{X: 2, Y: 3}
+	if err := f.SetTarget(g.Coords.CellCenter(target)); err != nil {
+		t.Fatalf("SetTarget() error = %v", err)
+	}
+	if !f.Update(0) {
+		t.Fatalf("Update(0) did not complete the build")
+	}
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			cell := coordinate.Cell{X: x, Y: y}
+			if g.IsBlocked(cell) {
+				continue
+			}
+			reachable := !math.IsInf(f.Cost(g.Coords.CellCenter(cell)), 1)
+			if reachable != (y < 5) {
+				t.Errorf("cell %v reachable = %v", cell, reachable)
+			}
+			if got := walk(f, g, cell, 100); reachable && got != target {
+				t.Errorf("walking from %v ended in %v, expected %v", cell, got, target)
+			}
+		}
+	}
+
+	if f.Cost(g.Coords.CellCenter(target)) != 0 {
+		t.Errorf("Cost(target) = %v, expected 0", f.Cost(g.Coords.CellCenter(target)))
+	}
+	if d := f.Direction(g.Coords.CellCenter(coordinate.Cell{X: 0, Y: 6})); d.X != 0 || d.Y != 0 {
+		t.Errorf("Direction() = %v in an unreachable cell, expected zero", d)
+	}
+	if err := f.SetTarget(g.Coords.CellCenter(coordinate.Cell{X: 0, Y: 5})); !errors.Is(err, ErrGoalBlocked) {
+		t.Errorf("SetTarget() on a wall error = %v, expected ErrGoalBlocked", err)
+	}
+}
+
+func TestFlowField_IncrementalUpdate(t *testing.T) {
+	g := randomMaze(48, 0.2, 3)
+	f := NewFlowField(g, DiagonalNoCornerCutting)
+	f.SetTarget(g.Coords.CellCenter(coordinate.Cell{X: 0, Y: 0}))
+	f.Update(0)
+
+	moved := coordinate.Cell{X: 1, Y: 0}
+	if g.IsBlocked(moved) {
+		t.Fatalf("test maze blocks %v", moved)
+	}
+	f.SetTarget(g.Coords.CellCenter(moved))
+	if f.Update(20) {
+		t.Fatalf("Update(20) completed a build of the whole maze")
+	}
+
+	// Half way through the rebuild every agent still reaches the new target
+	// (or stops next to it in the old target's cell).
+	fresh := NewFlowField(g, DiagonalNoCornerCutting)
+	fresh.SetTarget(g.Coords.CellCenter(moved))
+	fresh.Update(0)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			cell := coordinate.Cell{X: x, Y: y}
+			if math.IsInf(fresh.Cost(g.Coords.CellCenter(cell)), 1) {
+				continue
+			}
+			if got := walk(f, g, cell, 10000); got != moved && got != (coordinate.Cell{}) {
+				t.Fatalf("walking from %v during the rebuild ended in %v", cell, got)
+			}
+		}
+	}
+
+	f.Update(0)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			p := g.Coords.CellCenter(coordinate.Cell{X: x, Y: y})
+			if got, expected := f.Cost(p), fresh.Cost(p); got != expected {
+				t.Fatalf("Cost(%v) = %v after the rebuild, expected %v", p, got, expected)
+			}
+		}
+	}
+
+	// Moving within the target cell does not start a new build.
+	f.SetTarget(geometry.Point{X: 18, Y: 2})
+	if !f.Complete() {
+		t.Errorf("moving inside the target cell restarted the build")
+	}
+}
+
+func TestFlowField_Follow(t *testing.T) {
+	g := parseGrid(t, "....")
+	f := NewFlowField(g, DiagonalNoCornerCutting)
+	f.SetTarget(center(3, 0))
+	f.Update(0)
+
+	transform := components.NewTransformComponent(geometry.NewPoint(8, 8), 0, 1)
+	body, err := components.NewPhysicComponent(components.KinematicBody, &collider.Collider{Enabled: true}, transform)
+	if err != nil {
+		t.Fatalf("NewPhysicComponent() error = %v", err)
+	}
+	f.Follow(body, 50)
+	if v := body.GetVelocity(); v.X != 50 || v.Y != 0 {
+		t.Errorf("velocity = %v, expected 50 toward the target", v)
+	}
+
+	// Inside the target cell the agent heads for the exact target point.
+	transform.SetPosition(56, 4)
+	f.Follow(body, 10)
+	if v := body.GetVelocity(); v.X != 0 || v.Y != 10 {
+		t.Errorf("velocity = %v, expected 10 straight down to the target", v)
+	}
+}
+
+func BenchmarkFlowField_Build_512(b *testing.B) {
+	g := randomMaze(512, 0.2, 7)
+	f := NewFlowField(g, DiagonalNoCornerCutting)
+	targets := []geometry.Point{center(0, 0), center(511, 511)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.SetTarget(targets[i%2])
+		f.Update(0)
+	}
+}
+
+func BenchmarkFlowField_Direction(b *testing.B) {
+	g := randomMaze(512, 0.2, 7)
+	f := NewFlowField(g, DiagonalNoCornerCutting)
+	f.SetTarget(center(0, 0))
+	f.Update(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Direction(center(i%512, (i/512)%512))
+	}
+}
@@ -0,0 +1,197 @@
+package pathfinding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/data"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// Grid is a walkability grid. Cells outside of it count as blocked.
+type Grid struct {
+	Width   int
+	Height  int
+	Coords  coordinate.Grid // Places the cells in the world
+	blocked []bool
+}
+
+// NewGrid creates a width x height grid with every cell walkable.
+func NewGrid(width, height int, coords coordinate.Grid) *Grid {
+	if coords.CellSize <= 0 {
+		coords.CellSize = coordinate.DefaultCellSize
+	}
+	return &Grid{Width: width, Height: height, Coords: coords, blocked: make([]bool, width*height)}
+}
+
+// ErrGridSize is returned for collision data whose cell count does not match
+// its width and height.
+var ErrGridSize = errors.New("collision data does not match its size")
+
+// NewGridFromCollisionData creates a grid from IntGrid collision data. solid
+// decides which values block movement; nil blocks every non-zero value. Data
+// holding fewer or more cells than Width*Height is rejected with ErrGridSize,
+// like the maploader rejects it when building colliders.
+func NewGridFromCollisionData(cd *data.CollisionData, coords coordinate.Grid, solid func(value int) bool) (*Grid, error) {
+	if cd.Width < 0 || cd.Height < 0 || len(cd.Collision) != cd.Width*cd.Height {
+		return nil, fmt.Errorf("%w: %q has %d cells, expected %dx%d", ErrGridSize, cd.Name, len(cd.Collision), cd.Width, cd.Height)
+	}
+	if solid == nil {
+		solid = func(value int) bool { return value != 0 }
+	}
+	g := NewGrid(cd.Width, cd.Height, coords)
+	for i, value := range cd.Collision {
+		g.blocked[i] = solid(value)
+	}
+	return g, nil
+}
+
+// NewGridFromColliders creates a grid covering bounds and blocks every cell
+// overlapped by an enabled, non-trigger collider on one of the mask layers.
+// A zero mask accepts every layer.
+func NewGridFromColliders(colliders []*collider.Collider, bounds geometry.Bounds, cellSize float64, mask collider.Bitmask) *Grid {
+	if cellSize <= 0 {
+		cellSize = coordinate.DefaultCellSize
+	}
+	coords := coordinate.NewGrid(cellSize, bounds.MinX, bounds.MinY)
+	_, last := coords.CellRange(bounds)
+	width, height := last.X+1, last.Y+1
+	g := NewGrid(width, height, coords)
+
+	for _, c := range colliders {
+		if c == nil || !c.Enabled || c.IsTrigger || (mask != 0 && !mask.HasAny(c.LayerMask)) {
+			continue
+		}
+		first, last := coords.CellRange(c.GetBounds())
+		for y := max(first.Y, 0); y <= min(last.Y, height-1); y++ {
+			for x := max(first.X, 0); x <= min(last.X, width-1); x++ {
+				g.blocked[y*width+x] = true
+			}
+		}
+	}
+	return g
+}
+
+// InBounds reports whether the cell lies inside the grid.
+func (g *Grid) InBounds(c coordinate.Cell) bool {
+	return c.X >= 0 && c.Y >= 0 && c.X < g.Width && c.Y < g.Height
+}
+
+// IsBlocked reports whether the cell blocks movement.
+func (g *Grid) IsBlocked(c coordinate.Cell) bool {
+	return !g.InBounds(c) || g.blocked[c.Y*g.Width+c.X]
+}
+
+// SetBlocked marks a cell as blocked or walkable. Cells outside of the grid
+// are ignored.
+func (g *Grid) SetBlocked(c coordinate.Cell, blocked bool) {
+	if g.InBounds(c) {
+		g.blocked[c.Y*g.Width+c.X] = blocked
+	}
+}
+
+// Inflate returns a copy of the grid in which every cell whose center lies
+// closer than radius to a blocked cell is blocked too. Searching the inflated
+// grid keeps a round agent of that radius from clipping walls. The edges of
+// the grid are not inflated.
+func (g *Grid) Inflate(radius float64) *Grid {
+	inflated := &Grid{Width: g.Width, Height: g.Height, Coords: g.Coords, blocked: make([]bool, len(g.blocked))}
+	copy(inflated.blocked, g.blocked)
+	if radius <= 0 {
+		return inflated
+	}
+
+	size := g.Coords.CellSize
+	reach := int(math.Ceil(radius/size + 0.5))
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			if !g.blocked[y*g.Width+x] {
+				continue
+			}
+			for ny := max(y-reach, 0); ny <= min(y+reach, g.Height-1); ny++ {
+				for nx := max(x-reach, 0); nx <= min(x+reach, g.Width-1); nx++ {
+					// Distance from the neighbour's center to the blocked square.
+					dx := math.Max(0, math.Abs(float64(nx-x))*size-size/2)
+					dy := math.Max(0, math.Abs(float64(ny-y))*size-size/2)
+					if math.Hypot(dx, dy) < radius {
+						inflated.blocked[ny*g.Width+nx] = true
+					}
+				}
+			}
+		}
+	}
+	return inflated
+}
+
+// LineOfSight reports whether the segment from a to b only crosses walkable
+// cells. A segment passing exactly through a cell corner needs both cells
+// beside the corner to be walkable.
+func (g *Grid) LineOfSight(a, b geometry.Point) bool {
+	size := g.Coords.CellSize
+	pa, pb := g.Coords.WorldToPixel(a), g.Coords.WorldToPixel(b)
+	x0, y0, x1, y1 := pa.X/size, pa.Y/size, pb.X/size, pb.Y/size
+
+	cell := coordinate.Cell{X: int(math.Floor(x0)), Y: int(math.Floor(y0))}
+	end := coordinate.Cell{X: int(math.Floor(x1)), Y: int(math.Floor(y1))}
+	if g.IsBlocked(cell) {
+		return false
+	}
+
+	// Amanatides-Woo traversal: step into whichever cell border is hit first.
+	dx, dy := x1-x0, y1-y0
+	stepX, stepY := sign(dx), sign(dy)
+	tDeltaX, tDeltaY := math.Inf(1), math.Inf(1)
+	tMaxX, tMaxY := math.Inf(1), math.Inf(1)
+	if dx != 0 {
+		tDeltaX = math.Abs(1 / dx)
+		tMaxX = (math.Floor(x0) + float64(max(stepX, 0)) - x0) / dx
+	}
+	if dy != 0 {
+		tDeltaY = math.Abs(1 / dy)
+		tMaxY = (math.Floor(y0) + float64(max(stepY, 0)) - y0) / dy
+	}
+
+	// Bounding the steps guards against float drift missing the end cell.
+	for steps := abs(end.X-cell.X) + abs(end.Y-cell.Y); steps > 0; steps-- {
+		switch {
+		case math.Abs(tMaxX-tMaxY) < 1e-9:
+			// Through a corner: both side cells must be free.
+			if g.IsBlocked(coordinate.Cell{X: cell.X + stepX, Y: cell.Y}) || g.IsBlocked(coordinate.Cell{X: cell.X, Y: cell.Y + stepY}) {
+				return false
+			}
+			cell.X, cell.Y = cell.X+stepX, cell.Y+stepY
+			tMaxX, tMaxY = tMaxX+tDeltaX, tMaxY+tDeltaY
+			steps--
+		case tMaxX < tMaxY:
+			cell.X += stepX
+			tMaxX += tDeltaX
+		default:
+			cell.Y += stepY
+			tMaxY += tDeltaY
+		}
+		if g.IsBlocked(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,191 @@
+package pathfinding
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+// DefaultTickBudget is the search time a Scheduler spends per tick when the
+// config does not say otherwise: a tenth of the default 20ms tick.
+const DefaultTickBudget = 2 * time.Millisecond
+
+// SchedulerConfig holds the settings of a Scheduler. Zero values are replaced
+// with defaults.
+type SchedulerConfig struct {
+	Budget  time.Duration             // Search time per Process call, defaults to DefaultTickBudget
+	Now     func() time.Time          // Source of time for the budget, defaults to time.Now
+	IsAlive func(agentID string) bool // Requests of agents it rejects are dropped, nil keeps every request
+}
+
+// Request asks for a path for one agent.
+type Request struct {
+	AgentID string
+	Start   geometry.Point
+	Goal    geometry.Point
+	Options Options
+}
+
+// Scheduler queues path requests and searches them in batches, so hundreds
+// of agents asking for paths in the same tick cannot stall the simulation.
+// Each Process call searches requests in submission order until its time
+// budget is spent and reports every result on Events. Submit and Cancel are
+// safe to call from any goroutine.
+type Scheduler struct {
+	pathfinder *Pathfinder
+	config     SchedulerConfig
+
+	mu      sync.Mutex
+	nextID  uint64
+	queue   []*pendingRequest
+	byAgent map[string]*pendingRequest
+
+	Events *eventsystem.PathEvent
+}
+
+type pendingRequest struct {
+	id        uint64
+	request   Request
+	cancelled bool
+}
+
+// searchKey identifies requests that share a result.
+type searchKey struct {
+	start, goal geometry.Point
+	options     Options
+}
+
+type searchResult struct {
+	path []geometry.Point
+	err  error
+}
+
+// NewScheduler creates a scheduler searching with pathfinder.
+func NewScheduler(pathfinder *Pathfinder, config SchedulerConfig) *Scheduler {
+	if config.Budget <= 0 {
+		config.Budget = DefaultTickBudget
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Scheduler{
+		pathfinder: pathfinder,
+		config:     config,
+		byAgent:    make(map[string]*pendingRequest),
+		Events:     eventsystem.NewPathEvent(),
+	}
+}
+
+// Submit queues a request and returns its ID. An agent has at most one
+// pending request: a new one replaces the previous request but keeps its
+// place in the queue, so agents re-planning every tick are not starved.
+func (s *Scheduler) Submit(r Request) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	if pending, exists := s.byAgent[r.AgentID]; exists {
+		pending.id, pending.request = s.nextID, r
+		return s.nextID
+	}
+	pending := &pendingRequest{id: s.nextID, request: r}
+	s.queue = append(s.queue, pending)
+	s.byAgent[r.AgentID] = pending
+	return s.nextID
+}
+
+// Cancel drops the pending request of an agent, e.g. because it died. It
+// reports whether a request was pending.
+func (s *Scheduler) Cancel(agentID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, exists := s.byAgent[agentID]
+	if !exists {
+		return false
+	}
+	pending.cancelled = true
+	delete(s.byAgent, agentID)
+	return true
+}
+
+// Pending returns the number of requests waiting to be searched.
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.byAgent)
+}
+
+// Process searches queued requests until the budget is spent and emits a
+// PathFound or PathFailed event for each. At least one request is searched
+// per call so the queue always drains. Requests with the same start, goal
+// and options share a single search within a call. It returns the number of
+// requests answered.
+func (s *Scheduler) Process(tick uint64) int {
+	deadline := s.config.Now().Add(s.config.Budget)
+	results := make(map[searchKey]searchResult)
+	answered := 0
+
+	for {
+		pending, ok := s.next()
+		if !ok {
+			break
+		}
+		if s.config.IsAlive != nil && !s.config.IsAlive(pending.request.AgentID) {
+			continue
+		}
+
+		r := pending.request
+		key := searchKey{start: r.Start, goal: r.Goal, options: r.Options}
+		result, cached := results[key]
+		if !cached {
+			result.path, result.err = s.pathfinder.FindPath(r.Start, r.Goal, r.Options)
+			results[key] = result
+		}
+		s.emit(pending.id, r, result, tick)
+		answered++
+
+		if !s.config.Now().Before(deadline) {
+			break
+		}
+	}
+	return answered
+}
+
+// next pops the first live request off the queue.
+func (s *Scheduler) next() (*pendingRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.queue) > 0 {
+		pending := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		if pending.cancelled {
+			continue
+		}
+		delete(s.byAgent, pending.request.AgentID)
+		return pending, true
+	}
+	return nil, false
+}
+
+func (s *Scheduler) emit(id uint64, r Request, result searchResult, tick uint64) {
+	data := eventsystem.PathData{
+		RequestID: id,
+		AgentID:   r.AgentID,
+		Start:     r.Start,
+		Goal:      r.Goal,
+		Err:       result.err,
+		Tick:      tick,
+	}
+	eventType := eventsystem.PathFailed
+	if result.err == nil {
+		// Every receiver gets its own copy of a shared result.
+		data.Path = append([]geometry.Point(nil), result.path...)
+		eventType = eventsystem.PathFound
+	}
+	s.Events.Emit(*eventsystem.NewEvent(data, eventType))
+}
@@ -0,0 +1,147 @@
+package pathfinding
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+// fakeClock advances by step every time it is read.
+type fakeClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func newTestScheduler(t *testing.T, config SchedulerConfig) (*Scheduler, *[]eventsystem.Event[eventsystem.PathData]) {
+	g := parseGrid(t,
+		"......",
+		"..##..",
+		"......",
+	)
+	s := NewScheduler(NewPathfinder(g), config)
+	events := make([]eventsystem.Event[eventsystem.PathData], 0)
+	record := func(e eventsystem.Event[eventsystem.PathData]) { events = append(events, e) }
+	s.Events.Register(eventsystem.PathFound, record)
+	s.Events.Register(eventsystem.PathFailed, record)
+	return s, &events
+}
+
+func agents(events []eventsystem.Event[eventsystem.PathData]) string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.Data.AgentID
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestScheduler_Budget(t *testing.T) {
+	clock := &fakeClock{step: time.Millisecond}
+	s, events := newTestScheduler(t, SchedulerConfig{Budget: 2 * time.Millisecond, Now: clock.Now})
+	for i := 0; i < 5; i++ {
+		s.Submit(Request{AgentID: fmt.Sprintf("npc-%d", i), Start: center(0, 0), Goal: center(5, 2)})
+	}
+
+	if got := s.Process(1); got != 2 {
+		t.Errorf("Process() = %d, expected the budget to allow 2 searches", got)
+	}
+	if s.Pending() != 3 {
+		t.Errorf("Pending() = %d, expected 3", s.Pending())
+	}
+	s.Process(2)
+	s.Process(3)
+	if got := agents(*events); got != "[npc-0 npc-1 npc-2 npc-3 npc-4]" {
+		t.Errorf("answered %s, expected submission order", got)
+	}
+	if (*events)[4].Data.Tick != 3 {
+		t.Errorf("Tick = %d, expected the tick of the Process call", (*events)[4].Data.Tick)
+	}
+
+	// A budget smaller than one search still makes progress.
+	clock.step = time.Second
+	s.Submit(Request{AgentID: "slow", Start: center(0, 0), Goal: center(5, 2)})
+	if got := s.Process(4); got != 1 {
+		t.Errorf("Process() = %d, expected at least one search per call", got)
+	}
+}
+
+func TestScheduler_Deduplication(t *testing.T) {
+	s, events := newTestScheduler(t, SchedulerConfig{})
+
+	s.Submit(Request{AgentID: "npc", Start: center(0, 0), Goal: center(5, 0)})
+	latest := s.Submit(Request{AgentID: "npc", Start: center(0, 0), Goal: center(5, 2)})
+	s.Submit(Request{AgentID: "twin", Start: center(0, 0), Goal: center(5, 2)})
+	if s.Pending() != 2 {
+		t.Fatalf("Pending() = %d, expected the agent's requests to be merged", s.Pending())
+	}
+
+	s.Process(1)
+	if got := agents(*events); got != "[npc twin]" {
+		t.Fatalf("answered %s, expected npc then twin", got)
+	}
+	npc, twin := (*events)[0].Data, (*events)[1].Data
+	if npc.RequestID != latest || npc.Goal != center(5, 2) {
+		t.Errorf("npc answered request %d for %v, expected the latest request", npc.RequestID, npc.Goal)
+	}
+	if fmt.Sprint(npc.Path) != fmt.Sprint(twin.Path) {
+		t.Errorf("shared search returned %v and %v", npc.Path, twin.Path)
+	}
+	npc.Path[0].X = -1
+	if twin.Path[0].X == -1 {
+		t.Errorf("agents received the same path slice")
+	}
+}
+
+func TestScheduler_Cancellation(t *testing.T) {
+	dead := map[string]bool{"ghost": true}
+	s, events := newTestScheduler(t, SchedulerConfig{IsAlive: func(id string) bool { return !dead[id] }})
+
+	s.Submit(Request{AgentID: "killed", Start: center(0, 0), Goal: center(5, 2)})
+	s.Submit(Request{AgentID: "ghost", Start: center(0, 0), Goal: center(5, 2)})
+	s.Submit(Request{AgentID: "alive", Start: center(0, 0), Goal: center(5, 2)})
+
+	if !s.Cancel("killed") || s.Cancel("killed") {
+		t.Errorf("Cancel() should report the pending request only once")
+	}
+	s.Process(1)
+	if got := agents(*events); got != "[alive]" {
+		t.Errorf("answered %s, expected only the living agent", got)
+	}
+	if s.Pending() != 0 {
+		t.Errorf("Pending() = %d, expected an empty queue", s.Pending())
+	}
+}
+
+func TestScheduler_Failure(t *testing.T) {
+	s, events := newTestScheduler(t, SchedulerConfig{})
+
+	s.Submit(Request{AgentID: "npc", Start: center(0, 0), Goal: center(2, 1)})
+	s.Process(1)
+	if len(*events) != 1 {
+		t.Fatalf("len(events) = %d, expected 1", len(*events))
+	}
+	e := (*events)[0]
+	if e.EventType != eventsystem.PathFailed || !errors.Is(e.Data.Err, ErrGoalBlocked) || e.Data.Path != nil {
+		t.Errorf("event = %+v, expected a PathFailed with ErrGoalBlocked", e)
+	}
+}
+
+func BenchmarkScheduler_Process(b *testing.B) {
+	g := randomMaze(256, 0.2, 7)
+	s := NewScheduler(NewPathfinder(g), SchedulerConfig{Budget: time.Hour})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for a := 0; a < 100; a++ {
+			s.Submit(Request{AgentID: fmt.Sprint(a), Start: center(a%16, 0), Goal: center(200, 200)})
+		}
+		s.Process(uint64(i))
+	}
+}
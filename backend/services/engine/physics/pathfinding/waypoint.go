@@ -0,0 +1,191 @@
+package pathfinding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+var ErrUnknownNode = errors.New("unknown waypoint")
+
+// Waypoint is a node of a WaypointGraph.
+type Waypoint struct {
+	ID       string
+	Position geometry.Point
+	Edges    []Edge
+	index    int
+}
+
+// Edge is a directed link between two waypoints.
+type Edge struct {
+	To   *Waypoint
+	Cost float64 // length of the link in world units
+}
+
+// WaypointGraph is a hand-placed navigation graph: agents walk straight from
+// waypoint to waypoint along the links. It is the cheap alternative to grid
+// A* for open maps and patrol routes. Building the graph is not safe for
+// concurrent use; queries on a finished graph are.
+type WaypointGraph struct {
+	nodes []*Waypoint
+	byID  map[string]*Waypoint
+}
+
+// NewWaypointGraph creates an empty graph.
+func NewWaypointGraph() *WaypointGraph {
+	return &WaypointGraph{byID: make(map[string]*Waypoint)}
+}
+
+// AddNode adds a waypoint. IDs must be unique.
+func (g *WaypointGraph) AddNode(id string, position geometry.Point) (*Waypoint, error) {
+	if _, exists := g.byID[id]; exists {
+		return nil, fmt.Errorf("waypoint %q already exists", id)
+	}
+	w := &Waypoint{ID: id, Position: position, index: len(g.nodes)}
+	g.nodes = append(g.nodes, w)
+	g.byID[id] = w
+	return w, nil
+}
+
+// Link connects two waypoints, in both directions unless oneWay is set.
+// Linking the same pair twice keeps a single edge.
+func (g *WaypointGraph) Link(from, to string, oneWay bool) error {
+	a, b := g.byID[from], g.byID[to]
+	if a == nil || b == nil {
+		return fmt.Errorf("%w: cannot link %q to %q", ErrUnknownNode, from, to)
+	}
+	cost := math.Hypot(b.Position.X-a.Position.X, b.Position.Y-a.Position.Y)
+	addEdge(a, b, cost)
+	if !oneWay {
+		addEdge(b, a, cost)
+	}
+	return nil
+}
+
+func addEdge(from, to *Waypoint, cost float64) {
+	for _, e := range from.Edges {
+		if e.To == to {
+			return
+		}
+	}
+	from.Edges = append(from.Edges, Edge{To: to, Cost: cost})
+}
+
+// Node returns the waypoint with the given ID.
+func (g *WaypointGraph) Node(id string) (*Waypoint, bool) {
+	w, exists := g.byID[id]
+	return w, exists
+}
+
+// Nodes returns every waypoint ordered by ID.
+func (g *WaypointGraph) Nodes() []*Waypoint {
+	nodes := make([]*Waypoint, len(g.nodes))
+	copy(nodes, g.nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// Len returns the number of waypoints.
+func (g *WaypointGraph) Len() int {
+	return len(g.nodes)
+}
+
+// NearestNode returns the waypoint closest to p. When reachable is set, only
+// waypoints it accepts are considered, e.g. those in line of sight of p.
+func (g *WaypointGraph) NearestNode(p geometry.Point, reachable func(from, to geometry.Point) bool) (*Waypoint, bool) {
+	var nearest *Waypoint
+	best := math.Inf(1)
+	for _, w := range g.nodes {
+		d := (w.Position.X-p.X)*(w.Position.X-p.X) + (w.Position.Y-p.Y)*(w.Position.Y-p.Y)
+		if d > best || (d == best && w.ID > nearest.ID) {
+			continue
+		}
+		if reachable != nil && !reachable(p, w.Position) {
+			continue
+		}
+		nearest, best = w, d
+	}
+	return nearest, nearest != nil
+}
+
+// ShortestPath returns the waypoints of the shortest route from one waypoint
+// to another, both included.
+func (g *WaypointGraph) ShortestPath(from, to string) ([]*Waypoint, error) {
+	start, goal := g.byID[from], g.byID[to]
+	if start == nil || goal == nil {
+		return nil, fmt.Errorf("%w: no route from %q to %q", ErrUnknownNode, from, to)
+	}
+
+	n := len(g.nodes)
+	cost := make([]float64, n)
+	parent := make([]int32, n)
+	closed := make([]bool, n)
+	for i := range cost {
+		cost[i] = math.Inf(1)
+		parent[i] = -1
+	}
+	heuristic := func(w *Waypoint) float64 {
+		return math.Hypot(goal.Position.X-w.Position.X, goal.Position.Y-w.Position.Y)
+	}
+
+	cost[start.index] = 0
+	open := make(openSet, 0, 16)
+	open.push(node{index: start.index, f: heuristic(start), h: heuristic(start)})
+	for len(open) > 0 {
+		current := open.pop()
+		if closed[current.index] {
+			continue
+		}
+		if current.index == goal.index {
+			path := make([]*Waypoint, 0)
+			for i := goal.index; i >= 0; i = int(parent[i]) {
+				path = append(path, g.nodes[i])
+			}
+			for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+				path[l], path[r] = path[r], path[l]
+			}
+			return path, nil
+		}
+		closed[current.index] = true
+
+		for _, e := range g.nodes[current.index].Edges {
+			i := e.To.index
+			if tentative := cost[current.index] + e.Cost; !closed[i] && tentative < cost[i] {
+				cost[i] = tentative
+				parent[i] = int32(current.index)
+				h := heuristic(e.To)
+				open.push(node{index: i, f: tentative + h, h: h})
+			}
+		}
+	}
+	return nil, ErrNoPath
+}
+
+// FindPath routes from start to goal through the graph: to the waypoint
+// nearest to start, along the shortest route, then from the waypoint nearest
+// to goal. reachable is passed on to NearestNode. The returned points
+// include start and goal.
+func (g *WaypointGraph) FindPath(start, goal geometry.Point, reachable func(from, to geometry.Point) bool) ([]geometry.Point, error) {
+	first, ok := g.NearestNode(start, reachable)
+	if !ok {
+		return nil, ErrNoPath
+	}
+	last, ok := g.NearestNode(goal, reachable)
+	if !ok {
+		return nil, ErrNoPath
+	}
+	route, err := g.ShortestPath(first.ID, last.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make([]geometry.Point, 0, len(route)+2)
+	path = append(path, start)
+	for _, w := range route {
+		path = append(path, w.Position)
+	}
+	return append(path, goal), nil
+}
@@ -0,0 +1,53 @@
+package pathfinding
+
+import (
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+func TestWaypointGraph_ShortestPathPrefersCheaperRoute(t *testing.T) {
+	g := NewWaypointGraph()
+	for id, p := range map[string]geometry.Point{"a": {X: 0, Y: 0}, "b": {X: 10, Y: 0}, "c": {X: 5, Y: 20}, "d": {X: 5, Y: 1}} {
+		if _, err := g.AddNode(id, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.AddNode("a", geometry.Point{}); err == nil {
+		t.Errorf("AddNode() accepted a duplicate ID")
+	}
+	for _, link := range [][2]string{{"a", "b"}, {"a", "c"}, {"c", "b"}, {"a", "d"}} {
+		if err := g.Link(link[0], link[1], false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// One-way: d -> b is allowed, b -> d is not.
+	if err := g.Link("d", "b", true); err != nil {
+		t.Fatal(err)
+	}
+
+	route, err := g.ShortestPath("a", "b")
+	if err != nil || len(route) != 2 {
+		t.Errorf("ShortestPath(a, b) = %v, %v, expected the direct link", route, err)
+	}
+	if route, _ := g.ShortestPath("b", "d"); len(route) != 3 || route[1].ID != "a" {
+		t.Errorf("ShortestPath(b, d) = %v, expected to go back through a", route)
+	}
+}
+
+func TestWaypointGraph_NearestNodeReachable(t *testing.T) {
+	g := NewWaypointGraph()
+	g.AddNode("behind-wall", geometry.Point{X: 1, Y: 0})
+	g.AddNode("visible", geometry.Point{X: -5, Y: 0})
+
+	if w, _ := g.NearestNode(geometry.Point{}, nil); w.ID != "behind-wall" {
+		t.Errorf("NearestNode() = %s, expected behind-wall", w.ID)
+	}
+	reachable := func(from, to geometry.Point) bool { return to.X < 0 }
+	if w, _ := g.NearestNode(geometry.Point{}, reachable); w.ID != "visible" {
+		t.Errorf("NearestNode() = %s, expected the reachable node", w.ID)
+	}
+	if _, ok := NewWaypointGraph().NearestNode(geometry.Point{}, nil); ok {
+		t.Errorf("NearestNode() found a node in an empty graph")
+	}
+}
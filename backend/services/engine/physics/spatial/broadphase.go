@@ -0,0 +1,89 @@
+package spatial
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// BroadPhase is implemented by every structure the engine can use to find
+// candidate collider pairs. Colliders are keyed by their EntityID, so each
+// entity may own at most one collider inside a broad phase.
+//
+// Implementations are not safe for concurrent use; the world only touches
+// them from the tick goroutine.
+type BroadPhase interface {
+	// Insert adds a collider using its current world-space bounds.
+	Insert(c *collider.Collider) error
+	// Update refreshes the bounds of a collider after it moved. Colliders that
+	// are not yet known are inserted.
+	Update(c *collider.Collider) error
+	// Remove drops the collider with the given EntityID.
+	Remove(entityID string) bool
+	// Get returns the collider stored for the given EntityID.
+	Get(entityID string) (*collider.Collider, bool)
+	// QueryAABB returns every collider whose bounds intersect the region.
+	QueryAABB(bounds geometry.Bounds) []*collider.Collider
+	// Pairs returns every pair of colliders whose bounds overlap and whose
+	// layer masks allow them to interact, ordered by EntityID.
+	Pairs() []Pair
+	// Len returns the number of stored colliders.
+	Len() int
+	// Clear removes every collider.
+	Clear()
+}
+
+// Kind names a broad-phase implementation so that it can be picked in config.
+type Kind string
+
+const (
+	KindSpatialHash Kind = "spatial_hash"
+	KindQuadTree    Kind = "quadtree"
+)
+
+// NewBroadPhase creates the broad phase identified by kind. cellSize is used by
+// the spatial hash and bounds by the quadtree; the other value is ignored.
+func NewBroadPhase(kind Kind, cellSize float64, bounds geometry.Bounds) (BroadPhase, error) {
+	switch kind {
+	case KindSpatialHash, "":
+		return NewSpatialHash(cellSize), nil
+	case KindQuadTree:
+		return NewQuadTree(bounds, DefaultQuadTreeCapacity, DefaultQuadTreeMaxDepth), nil
+	default:
+		return nil, fmt.Errorf("unknown broad phase kind '%s'", kind)
+	}
+}
+
+// Pair is a candidate collision reported by a broad phase. A.EntityID is
+// always lower than B.EntityID.
+type Pair struct {
+	A *collider.Collider
+	B *collider.Collider
+}
+
+func newPair(a, b *collider.Collider) Pair {
+	if b.EntityID < a.EntityID {
+		a, b = b, a
+	}
+	return Pair{A: a, B: b}
+}
+
+// sortPairs orders pairs deterministically so that events produced from them
+// do not depend on map iteration order.
+func sortPairs(pairs []Pair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].A.EntityID != pairs[j].A.EntityID {
+			return pairs[i].A.EntityID < pairs[j].A.EntityID
+		}
+		return pairs[i].B.EntityID < pairs[j].B.EntityID
+	})
+}
+
+// sortColliders orders query results by EntityID.
+func sortColliders(colliders []*collider.Collider) {
+	sort.Slice(colliders, func(i, j int) bool {
+		return colliders[i].EntityID < colliders[j].EntityID
+	})
+}
@@ -0,0 +1,5 @@
+// Package spatial provides broad-phase structures that find candidate
+// collider pairs and answer region queries without testing every collider
+// against every other one. Ray and circle casts (see query.go) run on top of
+// any BroadPhase.
+package spatial
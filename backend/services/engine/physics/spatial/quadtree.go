@@ -0,0 +1,336 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// Default quadtree settings.
+const (
+	DefaultQuadTreeCapacity  = 8
+	DefaultQuadTreeMaxDepth  = 8
+	DefaultQuadTreeLooseness = 2.0
+)
+
+type quadEntry struct {
+	collider *collider.Collider
+	bounds   geometry.Bounds
+	node     *quadNode
+	stamp    uint64
+}
+
+type quadNode struct {
+	bounds   geometry.Bounds // tight bounds of the quadrant
+	loose    geometry.Bounds // bounds enlarged by the looseness factor
+	depth    int
+	parent   *quadNode
+	children *[4]*quadNode
+	entries  []*quadEntry
+	count    int // entries stored in this node and all of its descendants
+}
+
+// QuadTree is a loose quadtree broad phase. Every node accepts colliders whose
+// bounds fit into an enlarged copy of its quadrant, which lets moving bodies
+// stay in the same node for longer and keeps re-insertion cheap. It adapts to
+// uneven density: empty wilderness stays a single node while a crowded town
+// square is subdivided.
+//
+// Colliders outside the root bounds are kept in the root node so that no body
+// is ever lost, at the cost of slower queries for them.
+type QuadTree struct {
+	root      *quadNode
+	capacity  int
+	maxDepth  int
+	looseness float64
+	entries   map[string]*quadEntry
+	stamp     uint64
+}
+
+// NewQuadTree creates an empty loose quadtree covering bounds. A node splits
+// once it holds more than capacity colliders, up to maxDepth levels. Values
+// <= 0 fall back to the package defaults.
+func NewQuadTree(bounds geometry.Bounds, capacity, maxDepth int) *QuadTree {
+	if capacity <= 0 {
+		capacity = DefaultQuadTreeCapacity
+	}
+	if maxDepth <= 0 {
+		maxDepth = DefaultQuadTreeMaxDepth
+	}
+
+	q := &QuadTree{
+		capacity:  capacity,
+		maxDepth:  maxDepth,
+		looseness: DefaultQuadTreeLooseness,
+		entries:   make(map[string]*quadEntry),
+	}
+	q.root = q.newNode(bounds, 0, nil)
+	return q
+}
+
+// Bounds returns the tight bounds of the root node.
+func (q *QuadTree) Bounds() geometry.Bounds {
+	return q.root.bounds
+}
+
+func (q *QuadTree) Insert(c *collider.Collider) error {
+	if c == nil {
+		return errors.New("collider cannot be nil")
+	}
+	if c.EntityID == "" {
+		return errors.New("collider must have an EntityID to be inserted")
+	}
+	if _, exists := q.entries[c.EntityID]; exists {
+		return fmt.Errorf("collider '%s' already exists in quadtree", c.EntityID)
+	}
+
+	entry := &quadEntry{collider: c, bounds: c.GetBounds()}
+	q.entries[c.EntityID] = entry
+	q.insert(q.root, entry)
+	return nil
+}
+
+// Update re-inserts a collider after it moved. A body that still fits the
+// loose bounds of its node and cannot descend further is left in place.
+func (q *QuadTree) Update(c *collider.Collider) error {
+	if c == nil {
+		return errors.New("collider cannot be nil")
+	}
+	entry, exists := q.entries[c.EntityID]
+	if !exists {
+		return q.Insert(c)
+	}
+
+	entry.collider = c
+	entry.bounds = c.GetBounds()
+
+	node := entry.node
+	if (node == q.root || contains(node.loose, entry.bounds)) && q.childFor(node, entry.bounds) == nil {
+		return nil
+	}
+
+	q.detach(entry)
+	q.insert(q.root, entry)
+	return nil
+}
+
+func (q *QuadTree) Remove(entityID string) bool {
+	entry, exists := q.entries[entityID]
+	if !exists {
+		return false
+	}
+	q.detach(entry)
+	delete(q.entries, entityID)
+	return true
+}
+
+func (q *QuadTree) Get(entityID string) (*collider.Collider, bool) {
+	entry, exists := q.entries[entityID]
+	if !exists {
+		return nil, false
+	}
+	return entry.collider, true
+}
+
+func (q *QuadTree) QueryAABB(bounds geometry.Bounds) []*collider.Collider {
+	result := make([]*collider.Collider, 0)
+	q.visit(bounds, func(entry *quadEntry) {
+		result = append(result, entry.collider)
+	})
+	sortColliders(result)
+	return result
+}
+
+func (q *QuadTree) Pairs() []Pair {
+	pairs := make([]Pair, 0)
+	for _, entry := range q.entries {
+		q.visit(entry.bounds, func(other *quadEntry) {
+			// Each pair is found from both sides; keep the one seen from A.
+			if other.collider.EntityID <= entry.collider.EntityID {
+				return
+			}
+			if entry.collider.CanPairWith(other.collider) {
+				pairs = append(pairs, newPair(entry.collider, other.collider))
+			}
+		})
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+func (q *QuadTree) Len() int {
+	return len(q.entries)
+}
+
+func (q *QuadTree) Clear() {
+	q.root = q.newNode(q.root.bounds, 0, nil)
+	q.entries = make(map[string]*quadEntry)
+}
+
+// ---------------------------------------------------------------------------
+// Internal helpers
+// ---------------------------------------------------------------------------
+
+func (q *QuadTree) newNode(bounds geometry.Bounds, depth int, parent *quadNode) *quadNode {
+	padX := bounds.Width() * (q.looseness - 1) / 2
+	padY := bounds.Height() * (q.looseness - 1) / 2
+	return &quadNode{
+		bounds: bounds,
+		loose: geometry.Bounds{
+			MinX: bounds.MinX - padX,
+			MinY: bounds.MinY - padY,
+			MaxX: bounds.MaxX + padX,
+			MaxY: bounds.MaxY + padY,
+		},
+		depth:  depth,
+		parent: parent,
+	}
+}
+
+// insert places entry in the deepest node below n whose loose bounds contain
+// it, splitting leaves that grow past capacity.
+func (q *QuadTree) insert(n *quadNode, entry *quadEntry) {
+	for {
+		n.count++
+		child := q.childFor(n, entry.bounds)
+		if child == nil {
+			break
+		}
+		n = child
+	}
+
+	n.entries = append(n.entries, entry)
+	entry.node = n
+
+	if n.children == nil && len(n.entries) > q.capacity && n.depth < q.maxDepth {
+		q.split(n)
+	}
+}
+
+// childFor returns the child of n that should hold bounds, or nil when the
+// bounds have to stay in n itself.
+func (q *QuadTree) childFor(n *quadNode, b geometry.Bounds) *quadNode {
+	if n.children == nil {
+		return nil
+	}
+	cx := (n.bounds.MinX + n.bounds.MaxX) / 2
+	cy := (n.bounds.MinY + n.bounds.MaxY) / 2
+	centerX := (b.MinX + b.MaxX) / 2
+	centerY := (b.MinY + b.MaxY) / 2
+
+	index := 0
+	if centerX >= cx {
+		index |= 1
+	}
+	if centerY >= cy {
+		index |= 2
+	}
+	child := n.children[index]
+	if !contains(child.loose, b) {
+		return nil
+	}
+	return child
+}
+
+func (q *QuadTree) split(n *quadNode) {
+	cx := (n.bounds.MinX + n.bounds.MaxX) / 2
+	cy := (n.bounds.MinY + n.bounds.MaxY) / 2
+	b := n.bounds
+
+	n.children = &[4]*quadNode{
+		q.newNode(geometry.Bounds{MinX: b.MinX, MinY: b.MinY, MaxX: cx, MaxY: cy}, n.depth+1, n),
+		q.newNode(geometry.Bounds{MinX: cx, MinY: b.MinY, MaxX: b.MaxX, MaxY: cy}, n.depth+1, n),
+		q.newNode(geometry.Bounds{MinX: b.MinX, MinY: cy, MaxX: cx, MaxY: b.MaxY}, n.depth+1, n),
+		q.newNode(geometry.Bounds{MinX: cx, MinY: cy, MaxX: b.MaxX, MaxY: b.MaxY}, n.depth+1, n),
+	}
+
+	kept := n.entries[:0]
+	for _, entry := range n.entries {
+		child := q.childFor(n, entry.bounds)
+		if child == nil {
+			kept = append(kept, entry)
+			continue
+		}
+		// n.count already includes the entry; only the subtree below changes.
+		q.insert(child, entry)
+	}
+	n.entries = kept
+}
+
+// detach removes entry from its node and collapses subtrees that became
+// small enough to fit in a single node again.
+func (q *QuadTree) detach(entry *quadEntry) {
+	n := entry.node
+	for i, e := range n.entries {
+		if e == entry {
+			n.entries[i] = n.entries[len(n.entries)-1]
+			n.entries = n.entries[:len(n.entries)-1]
+			break
+		}
+	}
+	entry.node = nil
+
+	for p := n; p != nil; p = p.parent {
+		p.count--
+	}
+	for p := n; p != nil; p = p.parent {
+		if p.children != nil && p.count <= q.capacity {
+			q.collapse(p)
+		}
+	}
+}
+
+// collapse pulls every entry of n's subtree into n and drops its children.
+func (q *QuadTree) collapse(n *quadNode) {
+	var gather func(child *quadNode)
+	gather = func(child *quadNode) {
+		for _, entry := range child.entries {
+			entry.node = n
+			n.entries = append(n.entries, entry)
+		}
+		if child.children != nil {
+			for _, c := range child.children {
+				gather(c)
+			}
+		}
+	}
+	for _, c := range n.children {
+		gather(c)
+	}
+	n.children = nil
+}
+
+// visit calls fn for every entry whose bounds intersect b, at most once per
+// entry. The root is always visited because it also holds out-of-bounds bodies.
+func (q *QuadTree) visit(b geometry.Bounds, fn func(entry *quadEntry)) {
+	q.stamp++
+	stamp := q.stamp
+
+	var walk func(n *quadNode)
+	walk = func(n *quadNode) {
+		for _, entry := range n.entries {
+			if entry.stamp == stamp || !entry.bounds.Intersects(b) {
+				continue
+			}
+			entry.stamp = stamp
+			fn(entry)
+		}
+		if n.children == nil {
+			return
+		}
+		for _, child := range n.children {
+			if child.count > 0 && child.loose.Intersects(b) {
+				walk(child)
+			}
+		}
+	}
+	walk(q.root)
+}
+
+// contains reports whether inner lies completely inside outer.
+func contains(outer, inner geometry.Bounds) bool {
+	return inner.MinX >= outer.MinX && inner.MaxX <= outer.MaxX &&
+		inner.MinY >= outer.MinY && inner.MaxY <= outer.MaxY
+}
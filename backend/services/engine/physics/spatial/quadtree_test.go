@@ -0,0 +1,179 @@
+package spatial
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+var testWorldBounds = geometry.Bounds{MinX: 0, MinY: 0, MaxX: 2048, MaxY: 2048}
+
+// newClusteredColliders places most bodies in a small "town square" and the
+// rest scattered over the wilderness.
+func newClusteredColliders(n int, seed int64) []*collider.Collider {
+	rng := rand.New(rand.NewSource(seed))
+	colliders := make([]*collider.Collider, n)
+	for i := range colliders {
+		var x, y float64
+		if i%10 != 0 {
+			x, y = 100+rng.Float64()*200, 100+rng.Float64()*200
+		} else {
+			x, y = rng.Float64()*2048, rng.Float64()*2048
+		}
+		colliders[i] = newCircleCollider(fmt.Sprintf("body-%05d", i), x, y, 4,
+			collider.LayerPlayer, collider.LayerPlayer)
+	}
+	return colliders
+}
+
+func assertSamePairs(t *testing.T, got, expected []Pair) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Pairs() = %d pairs, expected %d", len(got), len(expected))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Pairs()[%d] = %s/%s, expected %s/%s", i,
+				got[i].A.EntityID, got[i].B.EntityID, expected[i].A.EntityID, expected[i].B.EntityID)
+		}
+	}
+}
+
+// --- Tests ---
+
+func TestQuadTree_Implements_BroadPhase(t *testing.T) {
+	var s any = NewQuadTree(testWorldBounds, 0, 0)
+
+	if _, ok := s.(BroadPhase); !ok {
+		t.Fatalf("QuadTree does not implement BroadPhase")
+	}
+}
+
+func TestQuadTree_InsertQueryRemove(t *testing.T) {
+	q := NewQuadTree(testWorldBounds, 2, 4)
+	a := newCircleCollider("a", 10, 10, 2, collider.LayerPlayer)
+	b := newCircleCollider("b", 1000, 1000, 2, collider.LayerPlayer)
+	outside := newCircleCollider("outside", -500, -500, 2, collider.LayerPlayer)
+
+	for _, c := range []*collider.Collider{a, b, outside} {
+		if err := q.Insert(c); err != nil {
+			t.Fatalf("Insert(%s) error = %v", c.EntityID, err)
+		}
+	}
+	if err := q.Insert(a); err == nil {
+		t.Errorf("Insert() with duplicate EntityID should fail")
+	}
+
+	if got := q.QueryAABB(geometry.Bounds{MinX: 0, MinY: 0, MaxX: 20, MaxY: 20}); len(got) != 1 || got[0] != a {
+		t.Errorf("QueryAABB() = %v, expected only a", got)
+	}
+	if got := q.QueryAABB(geometry.Bounds{MinX: -510, MinY: -510, MaxX: -490, MaxY: -490}); len(got) != 1 || got[0] != outside {
+		t.Errorf("QueryAABB() outside the root = %v, expected outside", got)
+	}
+
+	if !q.Remove("b") {
+		t.Fatalf("Remove() = false, expected true")
+	}
+	if got := q.QueryAABB(testWorldBounds); len(got) != 1 {
+		t.Errorf("QueryAABB() after Remove = %v, expected only a", got)
+	}
+	if q.Len() != 2 {
+		t.Errorf("Len() = %d, expected 2", q.Len())
+	}
+}
+
+func TestQuadTree_SplitsAndCollapses(t *testing.T) {
+	q := NewQuadTree(testWorldBounds, 4, 6)
+	colliders := newClusteredColliders(200, 3)
+	for _, c := range colliders {
+		q.Insert(c)
+	}
+	if q.root.children == nil {
+		t.Fatalf("root did not split after %d inserts", len(colliders))
+	}
+	if q.root.count != len(colliders) {
+		t.Errorf("root count = %d, expected %d", q.root.count, len(colliders))
+	}
+
+	for _, c := range colliders[:198] {
+		q.Remove(c.EntityID)
+	}
+	if q.root.children != nil {
+		t.Errorf("root kept its children with only %d bodies left", q.Len())
+	}
+	if got := q.QueryAABB(testWorldBounds); len(got) != 2 {
+		t.Errorf("QueryAABB() after collapse = %d bodies, expected 2", len(got))
+	}
+}
+
+func TestQuadTree_PairsMatchBruteForceWhileMoving(t *testing.T) {
+	colliders := newClusteredColliders(400, 7)
+	q := NewQuadTree(testWorldBounds, 4, 8)
+	for _, c := range colliders {
+		q.Insert(c)
+	}
+	assertSamePairs(t, q.Pairs(), bruteForcePairs(colliders))
+
+	rng := rand.New(rand.NewSource(11))
+	for step := 0; step < 5; step++ {
+		for _, c := range colliders {
+			c.AddTransform(geometry.Vector2{X: rng.Float64()*80 - 40, Y: rng.Float64()*80 - 40})
+			if err := q.Update(c); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		}
+		assertSamePairs(t, q.Pairs(), bruteForcePairs(colliders))
+	}
+}
+
+func TestNewBroadPhase(t *testing.T) {
+	tests := []struct {
+		kind    Kind
+		wantErr bool
+	}{
+		{"", false},
+		{KindSpatialHash, false},
+		{KindQuadTree, false},
+		{"octree", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			bp, err := NewBroadPhase(tt.kind, 32, testWorldBounds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBroadPhase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && bp == nil {
+				t.Errorf("NewBroadPhase() returned nil broad phase")
+			}
+		})
+	}
+}
+
+// --- Benchmarks ---
+
+func BenchmarkBroadPhase_ClusteredUpdateAndPairs(b *testing.B) {
+	for _, n := range []int{1000, 5000, 10000} {
+		for _, kind := range []Kind{KindSpatialHash, KindQuadTree} {
+			colliders := newClusteredColliders(n, 1)
+			bp, _ := NewBroadPhase(kind, DefaultCellSize, testWorldBounds)
+			for _, c := range colliders {
+				bp.Insert(c)
+			}
+
+			b.Run(fmt.Sprintf("%s/%d", kind, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					for j, c := range colliders {
+						dx := float64((i+j)%3 - 1)
+						c.AddTransform(geometry.Vector2{X: dx, Y: -dx})
+						bp.Update(c)
+					}
+					bp.Pairs()
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collision"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// QueryFilter selects the colliders a ray or shape cast can hit.
+type QueryFilter struct {
+	Mask            collider.Bitmask // layers that can be hit, zero means every layer
+	IncludeTriggers bool             // trigger colliders are skipped unless set
+	IgnoreEntityID  string           // usually the caster, so it does not hit itself
+}
+
+// accepts reports whether c passes the filter.
+func (f QueryFilter) accepts(c *collider.Collider) bool {
+	if !c.Enabled || (c.IsTrigger && !f.IncludeTriggers) {
+		return false
+	}
+	if f.IgnoreEntityID != "" && c.EntityID == f.IgnoreEntityID {
+		return false
+	}
+	return f.Mask == 0 || f.Mask.HasAny(c.LayerMask)
+}
+
+// RaycastHit is a ray or shape cast hit on a collider stored in a broad phase.
+type RaycastHit struct {
+	EntityID string
+	Collider *collider.Collider
+	Point    geometry.Point   // where the ray meets the collider
+	Normal   geometry.Vector2 // surface normal at Point, facing the caster
+	Distance float64          // distance travelled from the origin
+}
+
+// Raycast returns the first collider hit by a ray from origin along direction,
+// up to maxDistance. direction does not have to be normalized.
+func Raycast(bp BroadPhase, origin geometry.Point, direction geometry.Vector2, maxDistance float64, filter QueryFilter) (RaycastHit, bool) {
+	hits := cast(bp, origin, direction, maxDistance, 0, filter)
+	if len(hits) == 0 {
+		return RaycastHit{}, false
+	}
+	return hits[0], true
+}
+
+// RaycastAll returns every collider hit by the ray, nearest first.
+func RaycastAll(bp BroadPhase, origin geometry.Point, direction geometry.Vector2, maxDistance float64, filter QueryFilter) []RaycastHit {
+	return cast(bp, origin, direction, maxDistance, 0, filter)
+}
+
+// CircleCast sweeps a circle of the given radius along the ray and returns the
+// first collider it touches. Distance is how far the circle center travelled.
+func CircleCast(bp BroadPhase, origin geometry.Point, direction geometry.Vector2, maxDistance, radius float64, filter QueryFilter) (RaycastHit, bool) {
+	hits := cast(bp, origin, direction, maxDistance, radius, filter)
+	if len(hits) == 0 {
+		return RaycastHit{}, false
+	}
+	return hits[0], true
+}
+
+// cast collects the hits of a ray (radius 0) or circle cast, nearest first
+// and by EntityID on equal distance.
+func cast(bp BroadPhase, origin geometry.Point, direction geometry.Vector2, maxDistance, radius float64, filter QueryFilter) []RaycastHit {
+	length := math.Hypot(direction.X, direction.Y)
+	if length == 0 || maxDistance <= 0 {
+		return nil
+	}
+	end := geometry.Point{
+		X: origin.X + direction.X/length*maxDistance,
+		Y: origin.Y + direction.Y/length*maxDistance,
+	}
+	ray := geometry.Line{Start: origin, End: end}
+
+	bounds := ray.GetBounds()
+	bounds.MinX, bounds.MinY = bounds.MinX-radius, bounds.MinY-radius
+	bounds.MaxX, bounds.MaxY = bounds.MaxX+radius, bounds.MaxY+radius
+
+	hits := make([]RaycastHit, 0)
+	for _, c := range bp.QueryAABB(bounds) {
+		if !filter.accepts(c) {
+			continue
+		}
+		var hit *collision.RayHit
+		var ok bool
+		if radius > 0 {
+			hit, ok = collision.CircleCastCollider(ray, radius, c)
+		} else {
+			hit, ok = collision.RaycastCollider(ray, c)
+		}
+		if ok {
+			hits = append(hits, RaycastHit{EntityID: c.EntityID, Collider: c, Point: hit.Point, Normal: hit.Normal, Distance: hit.Distance})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Distance != hits[j].Distance {
+			return hits[i].Distance < hits[j].Distance
+		}
+		return hits[i].EntityID < hits[j].EntityID
+	})
+	return hits
+}
@@ -0,0 +1,239 @@
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// --- Test Helpers ---
+
+func layers(bits ...uint32) collider.Bitmask {
+	mask := collider.NewBitmask()
+	mask.SetLayers(bits...)
+	return mask
+}
+
+// newQueryScene returns broad phases holding, along the x axis: the caster at
+// 0, a trigger zone at 20, a 4x40 wall at 30 and an enemy at 50.
+func newQueryScene(t *testing.T) map[Kind]BroadPhase {
+	t.Helper()
+
+	wall := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{Width: 4, Height: 40}},
+		Transform: geometry.Vector2{X: 30, Y: 0},
+		Enabled:   true,
+		EntityID:  "wall",
+	}
+	wall.LayerMask.SetBit(collider.LayerWall)
+
+	zone := newCircleCollider("zone", 20, 0, 3, collider.LayerTrigger)
+	zone.IsTrigger = true
+
+	scene := []*collider.Collider{
+		newCircleCollider("caster", 0, 0, 4, collider.LayerPlayer),
+		zone,
+		wall,
+		newCircleCollider("enemy", 50, 0, 5, collider.LayerEnemy),
+	}
+
+	out := make(map[Kind]BroadPhase)
+	for _, kind := range []Kind{KindSpatialHash, KindQuadTree} {
+		bp, err := NewBroadPhase(kind, 16, geometry.Bounds{MinX: -64, MinY: -64, MaxX: 64, MaxY: 64})
+		if err != nil {
+			t.Fatalf("NewBroadPhase(%s) error = %v", kind, err)
+		}
+		for _, c := range scene {
+			if err := bp.Insert(c); err != nil {
+				t.Fatalf("Insert(%s) error = %v", c.EntityID, err)
+			}
+		}
+		out[kind] = bp
+	}
+	return out
+}
+
+func assertHit(t *testing.T, hit RaycastHit, id string, distance float64, point geometry.Point, normal geometry.Vector2) {
+	t.Helper()
+	if hit.EntityID != id || hit.Collider == nil || hit.Collider.EntityID != id {
+		t.Errorf("EntityID = %q, expected %q", hit.EntityID, id)
+	}
+	if math.Abs(hit.Distance-distance) > 1e-6 {
+		t.Errorf("Distance = %v, expected %v", hit.Distance, distance)
+	}
+	if math.Abs(hit.Point.X-point.X) > 1e-6 || math.Abs(hit.Point.Y-point.Y) > 1e-6 {
+		t.Errorf("Point = %+v, expected %+v", hit.Point, point)
+	}
+	if math.Abs(hit.Normal.X-normal.X) > 1e-6 || math.Abs(hit.Normal.Y-normal.Y) > 1e-6 {
+		t.Errorf("Normal = %+v, expected %+v", hit.Normal, normal)
+	}
+}
+
+// --- Tests ---
+
+func TestRaycast(t *testing.T) {
+	right := geometry.Vector2{X: 1, Y: 0}
+	origin := geometry.Point{X: 0, Y: 0}
+
+	tests := []struct {
+		name        string
+		direction   geometry.Vector2
+		maxDistance float64
+		filter      QueryFilter
+		hit         bool
+		id          string
+		distance    float64
+		point       geometry.Point
+		normal      geometry.Vector2
+	}{
+		{
+			name:        "First solid collider, trigger skipped",
+			direction:   right,
+			maxDistance: 100,
+			filter:      QueryFilter{IgnoreEntityID: "caster"},
+			hit:         true,
+			id:          "wall",
+			distance:    28,
+			point:       geometry.Point{X: 28, Y: 0},
+			normal:      geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:        "Mask sees past the wall",
+			direction:   geometry.Vector2{X: 5, Y: 0},
+			maxDistance: 100,
+			filter:      QueryFilter{Mask: layers(collider.LayerEnemy)},
+			hit:         true,
+			id:          "enemy",
+			distance:    45,
+			point:       geometry.Point{X: 45, Y: 0},
+			normal:      geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:        "Triggers on request",
+			direction:   right,
+			maxDistance: 100,
+			filter:      QueryFilter{IgnoreEntityID: "caster", IncludeTriggers: true},
+			hit:         true,
+			id:          "zone",
+			distance:    17,
+			point:       geometry.Point{X: 17, Y: 0},
+			normal:      geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:        "Origin inside the caster",
+			direction:   right,
+			maxDistance: 100,
+			hit:         true,
+			id:          "caster",
+			distance:    0,
+			point:       origin,
+			normal:      geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:        "Too short",
+			direction:   right,
+			maxDistance: 20,
+			filter:      QueryFilter{IgnoreEntityID: "caster"},
+		},
+		{
+			name:        "Nothing above",
+			direction:   geometry.Vector2{X: 0, Y: 1},
+			maxDistance: 100,
+			filter:      QueryFilter{IgnoreEntityID: "caster"},
+		},
+		{
+			name:        "Zero direction",
+			maxDistance: 100,
+		},
+	}
+
+	for kind, bp := range newQueryScene(t) {
+		for _, tt := range tests {
+			t.Run(string(kind)+"/"+tt.name, func(t *testing.T) {
+				hit, ok := Raycast(bp, origin, tt.direction, tt.maxDistance, tt.filter)
+				if ok != tt.hit {
+					t.Fatalf("Raycast() hit = %v (%+v), expected %v", ok, hit, tt.hit)
+				}
+				if tt.hit {
+					assertHit(t, hit, tt.id, tt.distance, tt.point, tt.normal)
+				}
+			})
+		}
+	}
+}
+
+func TestRaycastAll(t *testing.T) {
+	for kind, bp := range newQueryScene(t) {
+		t.Run(string(kind), func(t *testing.T) {
+			hits := RaycastAll(bp, geometry.Point{X: 0, Y: 0}, geometry.Vector2{X: 1, Y: 0}, 100, QueryFilter{IgnoreEntityID: "caster"})
+
+			expected := []string{"wall", "enemy"}
+			if len(hits) != len(expected) {
+				t.Fatalf("RaycastAll() = %d hits (%+v), expected %d", len(hits), hits, len(expected))
+			}
+			for i, id := range expected {
+				if hits[i].EntityID != id {
+					t.Errorf("hits[%d] = %s, expected %s", i, hits[i].EntityID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestCircleCast(t *testing.T) {
+	tests := []struct {
+		name     string
+		origin   geometry.Point
+		radius   float64
+		filter   QueryFilter
+		hit      bool
+		id       string
+		distance float64
+		point    geometry.Point
+		normal   geometry.Vector2
+	}{
+		{
+			name:     "Hits enemy face on",
+			radius:   2,
+			filter:   QueryFilter{Mask: layers(collider.LayerEnemy)},
+			hit:      true,
+			id:       "enemy",
+			distance: 43,
+			point:    geometry.Point{X: 45, Y: 0},
+			normal:   geometry.Vector2{X: -1, Y: 0},
+		},
+		{
+			name:     "Clips the wall corner a ray would miss",
+			origin:   geometry.Point{X: 0, Y: 22},
+			radius:   3,
+			filter:   QueryFilter{Mask: layers(collider.LayerWall)},
+			hit:      true,
+			id:       "wall",
+			distance: 28 - math.Sqrt(5),
+			point:    geometry.Point{X: 28, Y: 20},
+			normal:   geometry.Vector2{X: -math.Sqrt(5) / 3, Y: 2.0 / 3},
+		},
+		{
+			name:   "Passes above the wall",
+			origin: geometry.Point{X: 0, Y: 24},
+			radius: 3,
+			filter: QueryFilter{Mask: layers(collider.LayerWall)},
+		},
+	}
+
+	for kind, bp := range newQueryScene(t) {
+		for _, tt := range tests {
+			t.Run(string(kind)+"/"+tt.name, func(t *testing.T) {
+				hit, ok := CircleCast(bp, tt.origin, geometry.Vector2{X: 1, Y: 0}, 100, tt.radius, tt.filter)
+				if ok != tt.hit {
+					t.Fatalf("CircleCast() hit = %v (%+v), expected %v", ok, hit, tt.hit)
+				}
+				if tt.hit {
+					assertHit(t, hit, tt.id, tt.distance, tt.point, tt.normal)
+				}
+			})
+		}
+	}
+}
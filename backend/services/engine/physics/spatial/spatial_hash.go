@@ -0,0 +1,218 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// DefaultCellSize matches the 64x64 world unit buckets described in the GDD.
+const DefaultCellSize = 64.0
+
+type cellKey struct {
+	X, Y int
+}
+
+// cellRange is the inclusive range of cells covered by a bounds.
+type cellRange struct {
+	minX, minY, maxX, maxY int
+}
+
+type hashEntry struct {
+	collider *collider.Collider
+	bounds   geometry.Bounds
+	cells    cellRange
+	stamp    uint64 // last query that visited this entry, used for de-duplication
+}
+
+// SpatialHash is a uniform-grid broad phase. Each collider is stored in every
+// cell its AABB touches, so queries only look at the cells under the region.
+// It works best when colliders are of similar size and spread evenly.
+type SpatialHash struct {
+	cellSize float64
+	cells    map[cellKey][]*hashEntry
+	entries  map[string]*hashEntry
+	stamp    uint64
+}
+
+// NewSpatialHash creates an empty spatial hash. cellSize should be close to
+// the size of a typical collider; values <= 0 fall back to DefaultCellSize.
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	if cellSize <= 0 {
+		cellSize = DefaultCellSize
+	}
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    make(map[cellKey][]*hashEntry),
+		entries:  make(map[string]*hashEntry),
+	}
+}
+
+// CellSize returns the edge length of a grid cell.
+func (h *SpatialHash) CellSize() float64 {
+	return h.cellSize
+}
+
+func (h *SpatialHash) Insert(c *collider.Collider) error {
+	if c == nil {
+		return errors.New("collider cannot be nil")
+	}
+	if c.EntityID == "" {
+		return errors.New("collider must have an EntityID to be inserted")
+	}
+	if _, exists := h.entries[c.EntityID]; exists {
+		return fmt.Errorf("collider '%s' already exists in spatial hash", c.EntityID)
+	}
+
+	bounds := c.GetBounds()
+	entry := &hashEntry{collider: c, bounds: bounds, cells: h.cellRangeOf(bounds)}
+	h.entries[c.EntityID] = entry
+	h.addToCells(entry)
+	return nil
+}
+
+func (h *SpatialHash) Update(c *collider.Collider) error {
+	if c == nil {
+		return errors.New("collider cannot be nil")
+	}
+	entry, exists := h.entries[c.EntityID]
+	if !exists {
+		return h.Insert(c)
+	}
+
+	bounds := c.GetBounds()
+	cells := h.cellRangeOf(bounds)
+	entry.collider = c
+	entry.bounds = bounds
+	if cells == entry.cells {
+		return nil
+	}
+
+	h.removeFromCells(entry)
+	entry.cells = cells
+	h.addToCells(entry)
+	return nil
+}
+
+func (h *SpatialHash) Remove(entityID string) bool {
+	entry, exists := h.entries[entityID]
+	if !exists {
+		return false
+	}
+	h.removeFromCells(entry)
+	delete(h.entries, entityID)
+	return true
+}
+
+func (h *SpatialHash) Get(entityID string) (*collider.Collider, bool) {
+	entry, exists := h.entries[entityID]
+	if !exists {
+		return nil, false
+	}
+	return entry.collider, true
+}
+
+func (h *SpatialHash) QueryAABB(bounds geometry.Bounds) []*collider.Collider {
+	h.stamp++
+	result := make([]*collider.Collider, 0)
+
+	r := h.cellRangeOf(bounds)
+	for x := r.minX; x <= r.maxX; x++ {
+		for y := r.minY; y <= r.maxY; y++ {
+			for _, entry := range h.cells[cellKey{x, y}] {
+				if entry.stamp == h.stamp {
+					continue
+				}
+				entry.stamp = h.stamp
+				if entry.bounds.Intersects(bounds) {
+					result = append(result, entry.collider)
+				}
+			}
+		}
+	}
+
+	sortColliders(result)
+	return result
+}
+
+func (h *SpatialHash) Pairs() []Pair {
+	pairs := make([]Pair, 0)
+
+	for key, bucket := range h.cells {
+		for i := 0; i < len(bucket); i++ {
+			for j := i + 1; j < len(bucket); j++ {
+				a, b := bucket[i], bucket[j]
+
+				// Two entries can share several cells; only report the pair in
+				// the first cell of their shared range.
+				if key.X != max(a.cells.minX, b.cells.minX) || key.Y != max(a.cells.minY, b.cells.minY) {
+					continue
+				}
+				if !a.bounds.Intersects(b.bounds) || !a.collider.CanPairWith(b.collider) {
+					continue
+				}
+				pairs = append(pairs, newPair(a.collider, b.collider))
+			}
+		}
+	}
+
+	sortPairs(pairs)
+	return pairs
+}
+
+func (h *SpatialHash) Len() int {
+	return len(h.entries)
+}
+
+func (h *SpatialHash) Clear() {
+	h.cells = make(map[cellKey][]*hashEntry)
+	h.entries = make(map[string]*hashEntry)
+}
+
+// ---------------------------------------------------------------------------
+// Internal helpers
+// ---------------------------------------------------------------------------
+
+func (h *SpatialHash) cellRangeOf(b geometry.Bounds) cellRange {
+	return cellRange{
+		minX: int(math.Floor(b.MinX / h.cellSize)),
+		minY: int(math.Floor(b.MinY / h.cellSize)),
+		maxX: int(math.Floor(b.MaxX / h.cellSize)),
+		maxY: int(math.Floor(b.MaxY / h.cellSize)),
+	}
+}
+
+func (h *SpatialHash) addToCells(entry *hashEntry) {
+	r := entry.cells
+	for x := r.minX; x <= r.maxX; x++ {
+		for y := r.minY; y <= r.maxY; y++ {
+			key := cellKey{x, y}
+			h.cells[key] = append(h.cells[key], entry)
+		}
+	}
+}
+
+func (h *SpatialHash) removeFromCells(entry *hashEntry) {
+	r := entry.cells
+	for x := r.minX; x <= r.maxX; x++ {
+		for y := r.minY; y <= r.maxY; y++ {
+			key := cellKey{x, y}
+			bucket := h.cells[key]
+			for i, e := range bucket {
+				if e == entry {
+					bucket[i] = bucket[len(bucket)-1]
+					bucket = bucket[:len(bucket)-1]
+					break
+				}
+			}
+			if len(bucket) == 0 {
+				delete(h.cells, key)
+			} else {
+				h.cells[key] = bucket
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// --- Test Helpers ---
+
+func newCircleCollider(id string, x, y, radius float64, layer uint32, matches ...uint32) *collider.Collider {
+	c := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Circle{Radius: radius}},
+		Transform: geometry.Vector2{X: x, Y: y},
+		Enabled:   true,
+		EntityID:  id,
+	}
+	c.LayerMask.SetBit(layer)
+	c.MatchMask.SetLayers(matches...)
+	return c
+}
+
+func newRandomColliders(n int, seed int64) []*collider.Collider {
+	// Keep density constant: roughly one body per 32x32 area.
+	side := math.Sqrt(float64(n)) * 32
+	rng := rand.New(rand.NewSource(seed))
+
+	colliders := make([]*collider.Collider, n)
+	for i := range colliders {
+		colliders[i] = newCircleCollider(fmt.Sprintf("body-%05d", i),
+			rng.Float64()*side, rng.Float64()*side, 8,
+			collider.LayerPlayer, collider.LayerPlayer)
+	}
+	return colliders
+}
+
+func bruteForcePairs(colliders []*collider.Collider) []Pair {
+	pairs := make([]Pair, 0)
+	for i := 0; i < len(colliders); i++ {
+		for j := i + 1; j < len(colliders); j++ {
+			a, b := colliders[i], colliders[j]
+			if a.GetBounds().Intersects(b.GetBounds()) && a.CanPairWith(b) {
+				pairs = append(pairs, newPair(a, b))
+			}
+		}
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+// --- Tests ---
+
+func TestSpatialHash_Implements_BroadPhase(t *testing.T) {
+	var s any = NewSpatialHash(0)
+
+	if _, ok := s.(BroadPhase); !ok {
+		t.Fatalf("SpatialHash does not implement BroadPhase")
+	}
+}
+
+func TestSpatialHash_InsertQueryRemove(t *testing.T) {
+	h := NewSpatialHash(10)
+	a := newCircleCollider("a", 5, 5, 2, collider.LayerPlayer)
+	b := newCircleCollider("b", 55, 5, 2, collider.LayerPlayer)
+
+	if err := h.Insert(a); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if err := h.Insert(b); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if err := h.Insert(a); err == nil {
+		t.Errorf("Insert() with duplicate EntityID should fail")
+	}
+	if err := h.Insert(&collider.Collider{}); err == nil {
+		t.Errorf("Insert() without EntityID should fail")
+	}
+
+	got := h.QueryAABB(geometry.Bounds{MinX: 0, MinY: 0, MaxX: 20, MaxY: 20})
+	if len(got) != 1 || got[0] != a {
+		t.Fatalf("QueryAABB() = %v, expected only a", got)
+	}
+
+	if !h.Remove("a") {
+		t.Fatalf("Remove() = false, expected true")
+	}
+	if h.Remove("a") {
+		t.Errorf("second Remove() = true, expected false")
+	}
+	if got := h.QueryAABB(geometry.Bounds{MinX: 0, MinY: 0, MaxX: 20, MaxY: 20}); len(got) != 0 {
+		t.Errorf("QueryAABB() after Remove = %v, expected empty", got)
+	}
+	if h.Len() != 1 {
+		t.Errorf("Len() = %d, expected 1", h.Len())
+	}
+}
+
+func TestSpatialHash_UpdateMovesBetweenCells(t *testing.T) {
+	h := NewSpatialHash(10)
+	a := newCircleCollider("a", 5, 5, 2, collider.LayerPlayer)
+	h.Insert(a)
+
+	a.SetTransform(geometry.Vector2{X: 105, Y: 105})
+	if err := h.Update(a); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if got := h.QueryAABB(geometry.Bounds{MinX: 0, MinY: 0, MaxX: 20, MaxY: 20}); len(got) != 0 {
+		t.Errorf("old cell still returns %v", got)
+	}
+	if got := h.QueryAABB(geometry.Bounds{MinX: 100, MinY: 100, MaxX: 110, MaxY: 110}); len(got) != 1 {
+		t.Errorf("new cell returned %v, expected a", got)
+	}
+}
+
+func TestSpatialHash_PairsRespectMasks(t *testing.T) {
+	h := NewSpatialHash(16)
+	player := newCircleCollider("player", 0, 0, 5, collider.LayerPlayer, collider.LayerEnemy)
+	enemy := newCircleCollider("enemy", 4, 0, 5, collider.LayerEnemy)
+	ghost := newCircleCollider("ghost", 2, 0, 5, collider.LayerTrigger)
+	disabled := newCircleCollider("disabled", 1, 0, 5, collider.LayerEnemy)
+	disabled.Enabled = false
+
+	for _, c := range []*collider.Collider{player, enemy, ghost, disabled} {
+		h.Insert(c)
+	}
+
+	pairs := h.Pairs()
+	if len(pairs) != 1 {
+		t.Fatalf("Pairs() = %d pairs, expected 1", len(pairs))
+	}
+	if pairs[0].A != enemy || pairs[0].B != player {
+		t.Errorf("Pairs()[0] = %s/%s, expected enemy/player", pairs[0].A.EntityID, pairs[0].B.EntityID)
+	}
+}
+
+func TestSpatialHash_PairsMatchBruteForce(t *testing.T) {
+	colliders := newRandomColliders(500, 42)
+	// Mix in large bodies spanning many cells to exercise de-duplication.
+	for i := 0; i < 10; i++ {
+		colliders[i].ShapeList = []geometry.Shape{&geometry.Rectangle{Width: 200, Height: 40}}
+	}
+
+	h := NewSpatialHash(32)
+	for _, c := range colliders {
+		h.Insert(c)
+	}
+
+	got := h.Pairs()
+	expected := bruteForcePairs(colliders)
+	if len(got) != len(expected) {
+		t.Fatalf("Pairs() = %d pairs, expected %d", len(got), len(expected))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Pairs()[%d] = %s/%s, expected %s/%s", i,
+				got[i].A.EntityID, got[i].B.EntityID, expected[i].A.EntityID, expected[i].B.EntityID)
+		}
+	}
+}
+
+// --- Benchmarks ---
+
+func BenchmarkSpatialHash_Pairs(b *testing.B) {
+	for _, n := range []int{1000, 5000, 10000} {
+		colliders := newRandomColliders(n, 1)
+		h := NewSpatialHash(DefaultCellSize)
+		for _, c := range colliders {
+			h.Insert(c)
+		}
+
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h.Pairs()
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialHash_UpdateAndPairs(b *testing.B) {
+	for _, n := range []int{1000, 5000, 10000} {
+		colliders := newRandomColliders(n, 1)
+		h := NewSpatialHash(DefaultCellSize)
+		for _, c := range colliders {
+			h.Insert(c)
+		}
+
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				// Every body moves a little each tick, like a crowd of players.
+				for j, c := range colliders {
+					dx := float64((i+j)%3 - 1)
+					c.AddTransform(geometry.Vector2{X: dx, Y: -dx})
+					h.Update(c)
+				}
+				h.Pairs()
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialHash_QueryAABB(b *testing.B) {
+	for _, n := range []int{1000, 5000, 10000} {
+		colliders := newRandomColliders(n, 1)
+		h := NewSpatialHash(DefaultCellSize)
+		for _, c := range colliders {
+			h.Insert(c)
+		}
+		region := geometry.Bounds{MinX: 100, MinY: 100, MaxX: 356, MaxY: 356}
+
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h.QueryAABB(region)
+			}
+		})
+	}
+}
+
+func BenchmarkBruteForce_Pairs(b *testing.B) {
+	colliders := newRandomColliders(1000, 1)
+	for i := 0; i < b.N; i++ {
+		bruteForcePairs(colliders)
+	}
+}
@@ -0,0 +1,224 @@
+package steering
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/pathfinding"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/spatial"
+)
+
+// Seek heads for Target at full speed.
+type Seek struct {
+	Target geometry.Point
+}
+
+func (b *Seek) Steer(agent *Agent) geometry.Vector2 {
+	return seek(agent, b.Target)
+}
+
+// Flee runs from Target at full speed while it is closer than PanicDistance
+// (always when 0).
+type Flee struct {
+	Target        geometry.Point
+	PanicDistance float64
+}
+
+func (b *Flee) Steer(agent *Agent) geometry.Vector2 {
+	return flee(agent, b.Target, b.PanicDistance)
+}
+
+// Arrive heads for Target and slows down within SlowingRadius so the agent
+// stops on it instead of overshooting.
+type Arrive struct {
+	Target        geometry.Point
+	SlowingRadius float64
+}
+
+func (b *Arrive) Steer(agent *Agent) geometry.Vector2 {
+	direction, distance := towards(agent.Position, b.Target)
+	speed := agent.MaxSpeed
+	if distance < b.SlowingRadius {
+		speed *= distance / b.SlowingRadius
+	}
+	return steerTo(agent, geometry.Vector2{X: direction.X * speed, Y: direction.Y * speed})
+}
+
+// Pursue seeks where Target will be, predicting its motion for up to
+// MaxPrediction seconds (unlimited when 0).
+type Pursue struct {
+	Target        *components.PhysicComponent
+	MaxPrediction float64
+}
+
+func (b *Pursue) Steer(agent *Agent) geometry.Vector2 {
+	future, ok := predict(agent, b.Target, b.MaxPrediction)
+	if !ok {
+		return geometry.Vector2{}
+	}
+	return seek(agent, future)
+}
+
+// Evade flees from where Target will be, see Pursue and Flee.
+type Evade struct {
+	Target        *components.PhysicComponent
+	MaxPrediction float64
+	PanicDistance float64
+}
+
+func (b *Evade) Steer(agent *Agent) geometry.Vector2 {
+	future, ok := predict(agent, b.Target, b.MaxPrediction)
+	if !ok {
+		return geometry.Vector2{}
+	}
+	return flee(agent, future, b.PanicDistance)
+}
+
+// Wander makes an idle NPC amble around: it seeks a point on a circle of
+// Radius held Distance ahead of the agent, moving that point by up to Jitter
+// radians every call.
+type Wander struct {
+	Distance float64
+	Radius   float64
+	Jitter   float64
+	Rand     *rand.Rand // Source of the jitter, seeded per NPC for replays
+
+	angle float64
+}
+
+// NewWander creates a wander behaviour with its own random source.
+func NewWander(distance, radius, jitter float64, seed int64) *Wander {
+	return &Wander{Distance: distance, Radius: radius, Jitter: jitter, Rand: rand.New(rand.NewSource(seed))}
+}
+
+func (b *Wander) Steer(agent *Agent) geometry.Vector2 {
+	if b.Rand != nil {
+		b.angle += (b.Rand.Float64()*2 - 1) * b.Jitter
+	}
+	heading := geometry.Vector2{X: 1}
+	if speed := math.Hypot(agent.Velocity.X, agent.Velocity.Y); speed > 1e-9 {
+		heading = geometry.Vector2{X: agent.Velocity.X / speed, Y: agent.Velocity.Y / speed}
+	}
+	offset := heading.Rotate(b.angle)
+	return seek(agent, geometry.Point{
+		X: agent.Position.X + heading.X*b.Distance + offset.X*b.Radius,
+		Y: agent.Position.Y + heading.Y*b.Distance + offset.Y*b.Radius,
+	})
+}
+
+// Separation pushes the agent away from neighbours closer than Distance,
+// harder the closer they are. Neighbours returns the positions of the NPCs
+// around the agent, usually from a spatial query; the agent's own position
+// is ignored.
+type Separation struct {
+	Distance   float64
+	Neighbours func(agent *Agent, radius float64) []geometry.Point
+}
+
+func (b *Separation) Steer(agent *Agent) geometry.Vector2 {
+	if b.Neighbours == nil || b.Distance <= 0 {
+		return geometry.Vector2{}
+	}
+	var push geometry.Vector2
+	for _, p := range b.Neighbours(agent, b.Distance) {
+		direction, distance := towards(p, agent.Position)
+		if distance == 0 || distance >= b.Distance {
+			continue
+		}
+		strength := 1 - distance/b.Distance
+		push.X += direction.X * strength
+		push.Y += direction.Y * strength
+	}
+	return truncate(geometry.Vector2{X: push.X * agent.MaxAcceleration, Y: push.Y * agent.MaxAcceleration}, agent.MaxAcceleration)
+}
+
+// CastFunc sweeps a circle of radius from origin along direction and reports
+// the first obstacle, e.g. a closure over World.CircleCast with a wall
+// filter.
+type CastFunc func(origin geometry.Point, direction geometry.Vector2, maxDistance, radius float64) (spatial.RaycastHit, bool)
+
+// ObstacleAvoidance looks LookAhead seconds of travel ahead of the agent and
+// steers around the first obstacle in the way, harder the closer it is.
+type ObstacleAvoidance struct {
+	Cast      CastFunc
+	LookAhead float64
+}
+
+func (b *ObstacleAvoidance) Steer(agent *Agent) geometry.Vector2 {
+	speed := math.Hypot(agent.Velocity.X, agent.Velocity.Y)
+	if b.Cast == nil || speed < 1e-9 {
+		return geometry.Vector2{}
+	}
+	reach := speed * b.LookAhead
+	if reach <= 0 {
+		return geometry.Vector2{}
+	}
+	hit, blocked := b.Cast(agent.Position, agent.Velocity, reach, agent.Radius)
+	if !blocked {
+		return geometry.Vector2{}
+	}
+
+	// Push off the surface and sideways, so a head-on obstacle is walked
+	// around rather than only braked for.
+	heading := geometry.Vector2{X: agent.Velocity.X / speed, Y: agent.Velocity.Y / speed}
+	side := heading.Perpendicular()
+	if side.Dot(&hit.Normal) < 0 {
+		side = side.Negate()
+	}
+	away := geometry.Vector2{X: hit.Normal.X + side.X, Y: hit.Normal.Y + side.Y}
+	urgency := max(1-hit.Distance/reach, 0)
+	direction := away.Normalize()
+	return geometry.Vector2{X: direction.X * agent.MaxAcceleration * urgency, Y: direction.Y * agent.MaxAcceleration * urgency}
+}
+
+// FollowFlow steers along a flow field, for swarms sharing one target.
+type FollowFlow struct {
+	Field *pathfinding.FlowField
+}
+
+func (b *FollowFlow) Steer(agent *Agent) geometry.Vector2 {
+	return steerTo(agent, b.Field.DesiredVelocity(agent.Position, agent.MaxSpeed))
+}
+
+// steerTo returns the acceleration turning the agent's velocity into desired.
+func steerTo(agent *Agent, desired geometry.Vector2) geometry.Vector2 {
+	return geometry.Vector2{X: desired.X - agent.Velocity.X, Y: desired.Y - agent.Velocity.Y}
+}
+
+func seek(agent *Agent, target geometry.Point) geometry.Vector2 {
+	direction, _ := towards(agent.Position, target)
+	return steerTo(agent, geometry.Vector2{X: direction.X * agent.MaxSpeed, Y: direction.Y * agent.MaxSpeed})
+}
+
+func flee(agent *Agent, target geometry.Point, panicDistance float64) geometry.Vector2 {
+	direction, distance := towards(target, agent.Position)
+	if panicDistance > 0 && distance > panicDistance {
+		return geometry.Vector2{}
+	}
+	return steerTo(agent, geometry.Vector2{X: direction.X * agent.MaxSpeed, Y: direction.Y * agent.MaxSpeed})
+}
+
+// predict returns where target will be when the agent could reach it,
+// looking at most maxPrediction seconds ahead (unlimited when 0).
+func predict(agent *Agent, target *components.PhysicComponent, maxPrediction float64) (geometry.Point, bool) {
+	if target == nil || target.GetTransform() == nil || target.GetTransform().Position == nil {
+		return geometry.Point{}, false
+	}
+	position := *target.GetTransform().Position
+	velocity := geometry.Vector2{}
+	if v := target.GetVelocity(); v != nil {
+		velocity = *v
+	}
+
+	_, distance := towards(agent.Position, position)
+	prediction := 0.0
+	if agent.MaxSpeed > 0 {
+		prediction = distance / agent.MaxSpeed
+	}
+	if maxPrediction > 0 && prediction > maxPrediction {
+		prediction = maxPrediction
+	}
+	return geometry.Point{X: position.X + velocity.X*prediction, Y: position.Y + velocity.Y*prediction}, true
+}
@@ -0,0 +1,6 @@
+// Package steering moves NPCs with Reynolds-style steering behaviours (seek,
+// flee, arrive, pursue, evade, wander, separation, obstacle avoidance and
+// flow field following). A Steering blends weighted behaviours into one
+// acceleration, limited by the agent's max acceleration and max speed, and
+// applies it to a components.PhysicComponent.
+package steering
@@ -0,0 +1,150 @@
+package steering
+
+import (
+	"math"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// Agent is the state behaviours steer from: where the NPC is, how fast it
+// moves and how hard it may accelerate.
+type Agent struct {
+	Position        geometry.Point
+	Velocity        geometry.Vector2
+	Radius          float64 // Used by obstacle avoidance and separation
+	MaxSpeed        float64 // Units per second
+	MaxAcceleration float64 // Units per second squared
+}
+
+// Behaviour returns the acceleration it wants the agent to take. Results are
+// not limited; Steering truncates the blend to the agent's max acceleration.
+type Behaviour interface {
+	Steer(agent *Agent) geometry.Vector2
+}
+
+// BehaviourFunc adapts a function to Behaviour.
+type BehaviourFunc func(agent *Agent) geometry.Vector2
+
+func (f BehaviourFunc) Steer(agent *Agent) geometry.Vector2 {
+	return f(agent)
+}
+
+type weighted struct {
+	behaviour Behaviour
+	weight    float64
+}
+
+// Steering blends the behaviours of one NPC. It is not safe for concurrent
+// use, as some behaviours (e.g. Wander) keep state.
+type Steering struct {
+	MaxSpeed        float64 // Units per second
+	MaxAcceleration float64 // Units per second squared
+	Radius          float64 // Size of the NPC, see Agent.Radius
+
+	behaviours []weighted
+}
+
+// NewSteering creates an empty steering for an NPC with the given limits.
+func NewSteering(maxSpeed, maxAcceleration float64) *Steering {
+	return &Steering{MaxSpeed: maxSpeed, MaxAcceleration: maxAcceleration}
+}
+
+// Add blends behaviour in with weight and returns the steering for chaining.
+func (s *Steering) Add(behaviour Behaviour, weight float64) *Steering {
+	s.behaviours = append(s.behaviours, weighted{behaviour: behaviour, weight: weight})
+	return s
+}
+
+// Clear removes every behaviour, e.g. when an NPC switches from patrolling to
+// chasing.
+func (s *Steering) Clear() {
+	s.behaviours = s.behaviours[:0]
+}
+
+// Agent returns the agent state of body under the steering's limits.
+func (s *Steering) Agent(body *components.PhysicComponent) Agent {
+	agent := Agent{Radius: s.Radius, MaxSpeed: s.MaxSpeed, MaxAcceleration: s.MaxAcceleration}
+	if transform := body.GetTransform(); transform != nil && transform.Position != nil {
+		agent.Position = *transform.Position
+	}
+	if v := body.GetVelocity(); v != nil {
+		agent.Velocity = *v
+	}
+	return agent
+}
+
+// Calculate returns the weighted sum of all behaviours, truncated to the max
+// acceleration.
+func (s *Steering) Calculate(agent *Agent) geometry.Vector2 {
+	var total geometry.Vector2
+	for _, w := range s.behaviours {
+		force := w.behaviour.Steer(agent)
+		total.X += force.X * w.weight
+		total.Y += force.Y * w.weight
+	}
+	return truncate(total, s.MaxAcceleration)
+}
+
+// Velocity returns the velocity of agent after accelerating for deltaTime
+// seconds, truncated to the max speed.
+func (s *Steering) Velocity(agent *Agent, deltaTime float64) geometry.Vector2 {
+	acceleration := s.Calculate(agent)
+	return truncate(geometry.Vector2{
+		X: agent.Velocity.X + acceleration.X*deltaTime,
+		Y: agent.Velocity.Y + acceleration.Y*deltaTime,
+	}, s.MaxSpeed)
+}
+
+// Apply steers a kinematic body by setting its velocity. Bodies without a
+// transform are left alone.
+func (s *Steering) Apply(body *components.PhysicComponent, deltaTime float64) {
+	if body.GetTransform() == nil || body.GetTransform().Position == nil {
+		return
+	}
+	agent := s.Agent(body)
+	v := s.Velocity(&agent, deltaTime)
+	body.SetVelocity(v.X, v.Y)
+}
+
+// ApplyForce steers a rigid body through AddForce, so that other forces
+// acting on it this tick still add up. The force is sized to reach the same
+// velocity as Apply; bodies without mass fall back to Apply.
+func (s *Steering) ApplyForce(body *components.PhysicComponent, deltaTime float64) {
+	if body.Mass <= 0 {
+		s.Apply(body, deltaTime)
+		return
+	}
+	if body.GetTransform() == nil || body.GetTransform().Position == nil {
+		return
+	}
+	agent := s.Agent(body)
+	v := s.Velocity(&agent, deltaTime)
+	body.AddForce(&geometry.Vector2{
+		X: (v.X - agent.Velocity.X) * body.Mass,
+		Y: (v.Y - agent.Velocity.Y) * body.Mass,
+	})
+}
+
+// truncate shortens v to at most length; a length of 0 or less means no
+// limit.
+func truncate(v geometry.Vector2, length float64) geometry.Vector2 {
+	if length <= 0 {
+		return v
+	}
+	current := math.Hypot(v.X, v.Y)
+	if current <= length {
+		return v
+	}
+	return geometry.Vector2{X: v.X / current * length, Y: v.Y / current * length}
+}
+
+// towards returns the unit vector from a to b and the distance between them.
+func towards(a, b geometry.Point) (geometry.Vector2, float64) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	distance := math.Hypot(dx, dy)
+	if distance < 1e-9 {
+		return geometry.Vector2{}, 0
+	}
+	return geometry.Vector2{X: dx / distance, Y: dy / distance}, distance
+}
@@ -0,0 +1,195 @@
+package steering
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/spatial"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems"
+)
+
+// --- Test Helpers ---
+
+func newBody(t *testing.T, x, y float64) *components.PhysicComponent {
+	t.Helper()
+	body, err := components.NewPhysicComponent(components.KinematicBody, &collider.Collider{Enabled: true},
+		components.NewTransformComponent(geometry.NewPoint(x, y), 0, 1))
+	if err != nil {
+		t.Fatalf("NewPhysicComponent() error = %v", err)
+	}
+	return body
+}
+
+func near(a, b geometry.Vector2) bool {
+	return math.Abs(a.X-b.X) < 1e-6 && math.Abs(a.Y-b.Y) < 1e-6
+}
+
+// --- Tests ---
+
+func TestBehaviours(t *testing.T) {
+	target := newBody(t, 100, 0)
+	target.SetVelocity(0, 10)
+
+	tests := []struct {
+		name      string
+		behaviour Behaviour
+		agent     Agent
+		expected  geometry.Vector2
+	}{
+		{"seek", &Seek{Target: geometry.Point{X: 0, Y: 50}}, Agent{MaxSpeed: 10}, geometry.Vector2{X: 0, Y: 10}},
+		{"seek cancels the current velocity", &Seek{Target: geometry.Point{X: 50}}, Agent{MaxSpeed: 10, Velocity: geometry.Vector2{Y: 4}},
+			geometry.Vector2{X: 10, Y: -4}},
+		{"flee", &Flee{Target: geometry.Point{X: 5}}, Agent{MaxSpeed: 10}, geometry.Vector2{X: -10}},
+		{"flee ignores far targets", &Flee{Target: geometry.Point{X: 50}, PanicDistance: 20}, Agent{MaxSpeed: 10}, geometry.Vector2{}},
+		{"arrive at full speed", &Arrive{Target: geometry.Point{X: 100}, SlowingRadius: 20}, Agent{MaxSpeed: 10}, geometry.Vector2{X: 10}},
+		{"arrive slows down", &Arrive{Target: geometry.Point{X: 5}, SlowingRadius: 20}, Agent{MaxSpeed: 10}, geometry.Vector2{X: 2.5}},
+		{"arrive stops on the target", &Arrive{Target: geometry.Point{}, SlowingRadius: 20}, Agent{MaxSpeed: 10, Velocity: geometry.Vector2{X: 3}},
+			geometry.Vector2{X: -3}},
+		// Reaching the target takes 10s at 10 u/s, in which it moves 100 up.
+		{"pursue", &Pursue{Target: target}, Agent{MaxSpeed: 10}, geometry.Vector2{X: 10 / math.Sqrt2, Y: 10 / math.Sqrt2}},
+		{"pursue limits the prediction", &Pursue{Target: target, MaxPrediction: 1}, Agent{MaxSpeed: 10},
+			geometry.Vector2{X: 10 * 100 / math.Hypot(100, 10), Y: 10 * 10 / math.Hypot(100, 10)}},
+		{"evade", &Evade{Target: target}, Agent{MaxSpeed: 10}, geometry.Vector2{X: -10 / math.Sqrt2, Y: -10 / math.Sqrt2}},
+		{"evade outside the panic distance", &Evade{Target: target, PanicDistance: 50}, Agent{MaxSpeed: 10}, geometry.Vector2{}},
+		{"pursue without target", &Pursue{}, Agent{MaxSpeed: 10}, geometry.Vector2{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.behaviour.Steer(&tt.agent); !near(got, tt.expected) {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSeparation(t *testing.T) {
+	neighbours := []geometry.Point{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: -10, Y: 0}, {X: 0, Y: 30}}
+	separation := &Separation{
+		Distance:   20,
+		Neighbours: func(agent *Agent, radius float64) []geometry.Point { return neighbours },
+	}
+
+	// The agent itself and the neighbour beyond 20 are ignored; the one at 5
+	// pushes with 0.75, the one at 10 with 0.5 the other way.
+	got := separation.Steer(&Agent{MaxAcceleration: 8})
+	if expected := (geometry.Vector2{X: -0.25 * 8}); !near(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	neighbours = []geometry.Point{{X: 1}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+	if got := separation.Steer(&Agent{MaxAcceleration: 8}); math.Abs(math.Hypot(got.X, got.Y)-8) > 1e-9 {
+		t.Errorf("crowded agent pushed with %v, expected the max acceleration", math.Hypot(got.X, got.Y))
+	}
+}
+
+func TestObstacleAvoidance(t *testing.T) {
+	wall := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{Width: 4, Height: 40}},
+		Transform: geometry.Vector2{X: 30, Y: 0},
+		Enabled:   true,
+		EntityID:  "wall",
+	}
+	walls := spatial.NewSpatialHash(16)
+	if err := walls.Insert(wall); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	avoid := &ObstacleAvoidance{
+		LookAhead: 1,
+		Cast: func(origin geometry.Point, direction geometry.Vector2, maxDistance, radius float64) (spatial.RaycastHit, bool) {
+			return spatial.CircleCast(walls, origin, direction, maxDistance, radius, spatial.QueryFilter{})
+		},
+	}
+
+	tests := []struct {
+		name  string
+		agent Agent
+		check func(force geometry.Vector2) bool
+	}{
+		{"wall out of reach", Agent{Velocity: geometry.Vector2{X: 10}, MaxAcceleration: 5, Radius: 2},
+			func(f geometry.Vector2) bool { return f == geometry.Vector2{} }},
+		{"head on turns away and brakes", Agent{Velocity: geometry.Vector2{X: 40}, MaxAcceleration: 5, Radius: 2},
+			func(f geometry.Vector2) bool { return f.X < 0 && f.Y != 0 }},
+		{"sideways off a glancing hit", Agent{Position: geometry.Point{Y: 10}, Velocity: geometry.Vector2{X: 30, Y: 3}, MaxAcceleration: 5, Radius: 2},
+			func(f geometry.Vector2) bool { return f.X < 0 && f.Y > 0 }},
+		{"standing still", Agent{MaxAcceleration: 5, Radius: 2},
+			func(f geometry.Vector2) bool { return f == geometry.Vector2{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			force := avoid.Steer(&tt.agent)
+			if !tt.check(force) || math.Hypot(force.X, force.Y) > 5+1e-9 {
+				t.Errorf("got %v", force)
+			}
+		})
+	}
+}
+
+func TestWander_StaysOnCourse(t *testing.T) {
+	a, b := NewWander(20, 5, 0.5, 42), NewWander(20, 5, 0.5, 42)
+	agent := Agent{Velocity: geometry.Vector2{X: 10}, MaxSpeed: 10}
+	for i := 0; i < 100; i++ {
+		fa, fb := a.Steer(&agent), b.Steer(&agent)
+		if fa != fb {
+			t.Fatalf("same seed steered %v and %v", fa, fb)
+		}
+		// The wander target stays within 5 of a point 20 ahead, so the
+		// agent never turns by more than asin(5/20).
+		desired := geometry.Vector2{X: fa.X + agent.Velocity.X, Y: fa.Y + agent.Velocity.Y}
+		if angle := math.Abs(math.Atan2(desired.Y, desired.X)); angle > math.Asin(0.25)+1e-9 {
+			t.Fatalf("wandered off by %v rad", angle)
+		}
+	}
+}
+
+func TestSteering_BlendsWithinLimits(t *testing.T) {
+	steering := NewSteering(10, 4).
+		Add(&Seek{Target: geometry.Point{X: 100}}, 1).
+		Add(&Seek{Target: geometry.Point{Y: 100}}, 0.5)
+
+	agent := Agent{MaxSpeed: 10, MaxAcceleration: 4}
+	force := steering.Calculate(&agent)
+	if length := math.Hypot(force.X, force.Y); math.Abs(length-4) > 1e-9 {
+		t.Errorf("|force| = %v, expected the max acceleration 4", length)
+	}
+	if math.Abs(force.Y/force.X-0.5) > 1e-9 {
+		t.Errorf("force = %v, expected the weights 1 and 0.5", force)
+	}
+
+	body := newBody(t, 0, 0)
+	body.SetVelocity(9, 0)
+	steering.Clear()
+	steering.Add(&Seek{Target: geometry.Point{X: 100}}, 1)
+	steering.Apply(body, 1)
+	if v := body.GetVelocity(); v.X != 10 || v.Y != 0 {
+		t.Errorf("velocity = %v, expected the max speed", v)
+	}
+}
+
+func TestSteering_ApplyForce(t *testing.T) {
+	body := newBody(t, 0, 0)
+	body.Mass = 2
+	steering := NewSteering(10, 4).Add(&Seek{Target: geometry.Point{X: 100}}, 1)
+
+	steering.ApplyForce(body, 0.5)
+	if v := body.GetVelocity(); !near(*v, geometry.Vector2{X: 2}) {
+		t.Errorf("velocity = %v, expected 4 u/s² for 0.5s", v)
+	}
+}
+
+func TestSteering_ArriveSettles(t *testing.T) {
+	body := newBody(t, 0, 0)
+	steering := NewSteering(50, 100).Add(&Arrive{Target: geometry.Point{X: 200, Y: 100}, SlowingRadius: 40}, 1)
+
+	for i := 0; i < 1200; i++ {
+		steering.Apply(body, 1.0/60)
+		systems.IntegrateBody(body, 1.0/60)
+	}
+	position := body.GetTransform().Position
+	if math.Hypot(position.X-200, position.Y-100) > 0.5 || math.Hypot(body.Velocity.X, body.Velocity.Y) > 0.5 {
+		t.Errorf("ended at %v moving %v, expected to rest on the target", position, body.Velocity)
+	}
+}
@@ -0,0 +1,220 @@
+package prefab
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"gopkg.in/yaml.v2"
+)
+
+// Shape types of a collider definition.
+const (
+	ShapeCircle    = "circle"
+	ShapeRectangle = "rectangle"
+)
+
+// layers maps the layer names of a collider definition to collider layers.
+var layers = map[string]uint32{
+	"player":     collider.LayerPlayer,
+	"enemy":      collider.LayerEnemy,
+	"projectile": collider.LayerProjectile,
+	"wall":       collider.LayerWall,
+	"trigger":    collider.LayerTrigger,
+	"water":      collider.LayerWater,
+}
+
+// Definition is the authored form of a prefab. A goblin written in YAML
+// looks like
+//
+//	name: goblin
+//	tags: [enemy]
+//	collider:
+//	  layer: enemy
+//	  shapes: [{type: circle, radius: 6}]
+//	components:
+//	  - type: transform
+//	  - type: physic
+//	    params: {physic_type: 1, mass: 2, linear_damping: 0.1}
+//	  - type: ai
+//	    params: {machine: melee, values: {health: 30}}
+//
+// Params override the fields the component writes in Serialize, so they use
+// the same names as a snapshot.
+type Definition struct {
+	Name       string         `json:"name" yaml:"name"`
+	Identifier string         `json:"identifier,omitempty" yaml:"identifier,omitempty"` // Entity identifier, defaults to Name
+	Tags       []string       `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Collider   *ColliderDef   `json:"collider,omitempty" yaml:"collider,omitempty"` // Collider of the physic component
+	Components []ComponentDef `json:"components" yaml:"components"`
+}
+
+// ComponentDef declares one component of a prefab.
+type ComponentDef struct {
+	Type   string `json:"type" yaml:"type"`                         // Kind registered in the Library
+	Params Params `json:"params,omitempty" yaml:"params,omitempty"` // Overrides of the serialized fields
+}
+
+// ColliderDef describes the collider given to a prefab's physic component.
+type ColliderDef struct {
+	Shapes  []ShapeDef `json:"shapes" yaml:"shapes"`
+	Layer   string     `json:"layer,omitempty" yaml:"layer,omitempty"`     // Layer name, e.g. enemy
+	Matches []string   `json:"matches,omitempty" yaml:"matches,omitempty"` // Layers collided with, defaults to player, enemy, projectile and wall
+	Trigger bool       `json:"trigger,omitempty" yaml:"trigger,omitempty"` // Implied by the trigger layer
+}
+
+// ShapeDef is one shape of a collider, relative to the entity's position.
+type ShapeDef struct {
+	Type   string  `json:"type" yaml:"type"`
+	X      float64 `json:"x,omitempty" yaml:"x,omitempty"`
+	Y      float64 `json:"y,omitempty" yaml:"y,omitempty"`
+	Radius float64 `json:"radius,omitempty" yaml:"radius,omitempty"` // Circles
+	Width  float64 `json:"width,omitempty" yaml:"width,omitempty"`   // Rectangles
+	Height float64 `json:"height,omitempty" yaml:"height,omitempty"` // Rectangles
+}
+
+// ParseJSON decodes a prefab definition from JSON.
+func ParseJSON(data []byte) (*Definition, error) {
+	var def Definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("invalid prefab: %w", err)
+	}
+	return &def, nil
+}
+
+// ParseYAML decodes a prefab definition from YAML.
+func ParseYAML(data []byte) (*Definition, error) {
+	var def Definition
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("invalid prefab: %w", err)
+	}
+	for i := range def.Components {
+		def.Components[i].Params = normalize(def.Components[i].Params).(map[string]any)
+	}
+	return &def, nil
+}
+
+// Params are the overrides of a component definition.
+type Params map[string]any
+
+// String returns the string param key.
+func (p Params) String(key string) (string, bool) {
+	s, ok := p[key].(string)
+	return s, ok
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// normalize turns the map[interface{}]interface{} values YAML decodes nested
+// maps into string-keyed maps, so params can be encoded as JSON.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case Params:
+		return normalize(map[string]any(v))
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = normalize(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[fmt.Sprint(k)] = normalize(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalize(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// merge writes overrides into fields. Nested objects are merged key by key,
+// and keys match case-insensitively like encoding/json does, so "x" replaces
+// the "X" of a serialized geometry.Point.
+func merge(fields, overrides map[string]any) {
+	for key, value := range overrides {
+		existing := key
+		for k := range fields {
+			if strings.EqualFold(k, key) {
+				existing = k
+				break
+			}
+		}
+		inner, isObject := value.(map[string]any)
+		current, wasObject := fields[existing].(map[string]any)
+		if isObject && wasObject {
+			merge(current, inner)
+			continue
+		}
+		delete(fields, existing)
+		fields[key] = value
+	}
+}
+
+// build creates the collider described by d.
+func (d *ColliderDef) build() (*collider.Collider, error) {
+	if len(d.Shapes) == 0 {
+		return nil, fmt.Errorf("collider has no shapes")
+	}
+	col := &collider.Collider{Enabled: true, IsTrigger: d.Trigger}
+	for i, s := range d.Shapes {
+		center := geometry.Point{X: s.X, Y: s.Y}
+		switch s.Type {
+		case ShapeCircle:
+			if s.Radius <= 0 {
+				return nil, fmt.Errorf("shapes[%d]: circle needs a positive radius", i)
+			}
+			circle := geometry.NewCircle(center, s.Radius)
+			col.ShapeList = append(col.ShapeList, &circle)
+		case ShapeRectangle:
+			if s.Width <= 0 || s.Height <= 0 {
+				return nil, fmt.Errorf("shapes[%d]: rectangle needs a positive width and height", i)
+			}
+			col.ShapeList = append(col.ShapeList, geometry.NewRectangle(center, s.Width, s.Height))
+		default:
+			return nil, fmt.Errorf("shapes[%d]: unknown shape type %q", i, s.Type)
+		}
+	}
+
+	if d.Layer != "" {
+		layer, exists := layers[d.Layer]
+		if !exists {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownLayer, d.Layer)
+		}
+		col.LayerMask.SetBit(layer)
+		col.IsTrigger = col.IsTrigger || layer == collider.LayerTrigger
+	}
+	if len(d.Matches) == 0 {
+		col.MatchMask.SetLayers(collider.LayerPlayer, collider.LayerEnemy, collider.LayerProjectile, collider.LayerWall)
+	}
+	for _, name := range d.Matches {
+		layer, exists := layers[name]
+		if !exists {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownLayer, name)
+		}
+		col.MatchMask.SetBit(layer)
+	}
+	return col, nil
+}
+
+// cloneCollider copies c with its own shapes, so moving the copy leaves c
+// alone. UserData is not copied.
+func cloneCollider(c *collider.Collider) *collider.Collider {
+	clone := *c
+	clone.ShapeList = make([]geometry.Shape, len(c.ShapeList))
+	for i, s := range c.ShapeList {
+		clone.ShapeList[i] = s.Clone()
+	}
+	clone.UserData = nil
+	return &clone
+}
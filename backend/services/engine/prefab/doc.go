@@ -0,0 +1,8 @@
+// Package prefab spawns entities from named templates such as "goblin",
+// "arrow" or "chest". Templates are authored in JSON or YAML, listing the
+// entity's components with overrides of their serialized fields, and built
+// once into a Library. Spawning clones the template's components and links
+// the clones to each other again (the physics body to its transform and
+// collider, the AI to its transform), which Component.Clone leaves to the
+// caller.
+package prefab
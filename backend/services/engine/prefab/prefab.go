@@ -0,0 +1,347 @@
+package prefab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownPrefab  = errors.New("unknown prefab")
+	ErrUnknownKind    = errors.New("unknown component kind")
+	ErrUnknownMachine = errors.New("unknown AI machine")
+	ErrUnknownLayer   = errors.New("unknown collision layer")
+)
+
+// Component kinds every Library knows.
+const (
+	KindTransform = "transform"
+	KindPhysic    = "physic"
+	KindAI        = "ai"
+)
+
+// Kind creates and wires one type of component.
+type Kind struct {
+	// New returns a component with default values, which the params of a
+	// definition then override.
+	New func() components.Component
+	// Wire links a spawned component to the rest of its entity. It may be
+	// nil for components without references.
+	Wire func(c components.Component, w *Wiring) error
+}
+
+// Wiring is what a spawned component can link to.
+type Wiring struct {
+	Transform *components.TransformComponent // The entity's first transform, nil without one
+	Collider  *collider.Collider             // The entity's own copy of the prefab's collider, nil without one
+	Params    Params                         // Params of the component's definition
+	Library   *Library
+}
+
+// Prefab is a definition built into template components.
+type Prefab struct {
+	Definition
+	template []components.Component
+	kinds    []Kind
+	collider *collider.Collider
+}
+
+// Library holds the prefabs of a game and the component kinds and AI
+// machines they are built from. It is safe for concurrent use.
+type Library struct {
+	mu       sync.RWMutex
+	kinds    map[string]Kind
+	machines map[string]*components.AIMachine
+	prefabs  map[string]*Prefab
+}
+
+// NewLibrary creates a library without prefabs that knows the transform,
+// physic and ai kinds. The ai kind takes its machine from the "machine"
+// param, see RegisterMachine.
+func NewLibrary() *Library {
+	l := &Library{
+		kinds:    make(map[string]Kind),
+		machines: make(map[string]*components.AIMachine),
+		prefabs:  make(map[string]*Prefab),
+	}
+	l.RegisterKind(KindTransform, Kind{New: newTransform})
+	l.RegisterKind(KindPhysic, Kind{New: newPhysic, Wire: wirePhysic})
+	l.RegisterKind(KindAI, Kind{New: newAI, Wire: wireAI})
+	return l
+}
+
+// RegisterKind sets the kind used by components of the given type, replacing
+// any previous one. Prefabs added earlier keep the kind they were built with.
+func (l *Library) RegisterKind(name string, kind Kind) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.kinds[name] = kind
+}
+
+// RegisterMachine names an AI machine for the "machine" param of ai
+// components. Register machines before adding the prefabs using them.
+func (l *Library) RegisterMachine(name string, machine *components.AIMachine) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.machines[name] = machine
+}
+
+// Machine returns the AI machine registered under name.
+func (l *Library) Machine(name string) (*components.AIMachine, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	machine, exists := l.machines[name]
+	return machine, exists
+}
+
+// Add builds def and stores it under its name, replacing any prefab of that
+// name. Definitions are checked by spawning one entity, so a broken prefab
+// fails here rather than in the middle of a level.
+func (l *Library) Add(def *Definition) (*Prefab, error) {
+	p, err := l.build(def)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prefabs[p.Name] = p
+	return p, nil
+}
+
+// LoadFile parses and adds the prefab in a .json, .yaml or .yml file.
+func (l *Library) LoadFile(path string) (*Prefab, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var def *Definition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		def, err = ParseJSON(data)
+	case ".yaml", ".yml":
+		def, err = ParseYAML(data)
+	default:
+		return nil, fmt.Errorf("%s: prefabs are .json, .yaml or .yml files", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p, err := l.Add(def)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// LoadDir adds the prefabs of every .json, .yaml and .yml file in dir. Other
+// files and subdirectories are ignored.
+func (l *Library) LoadDir(dir string) ([]*Prefab, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([]*Prefab, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		p, err := l.LoadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, p)
+	}
+	return loaded, nil
+}
+
+// Get returns the prefab named name.
+func (l *Library) Get(name string) (*Prefab, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	p, exists := l.prefabs[name]
+	return p, exists
+}
+
+// Names returns the names of the prefabs, sorted.
+func (l *Library) Names() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	names := make([]string, 0, len(l.prefabs))
+	for name := range l.prefabs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Spawn creates an entity from the prefab named name, with its transform at
+// position. An empty iid gets a random one; the collider is keyed by it.
+func (l *Library) Spawn(name, iid string, position geometry.Point) (*entities.Entity, error) {
+	p, exists := l.Get(name)
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPrefab, name)
+	}
+	if iid == "" {
+		iid = uuid.New().String()
+	}
+	return p.spawn(l, iid, position)
+}
+
+// ---------------------------------------------------------------------------
+// Building and spawning
+// ---------------------------------------------------------------------------
+
+func (l *Library) build(def *Definition) (*Prefab, error) {
+	if def.Name == "" {
+		return nil, errors.New("prefab has no name")
+	}
+	p := &Prefab{Definition: *def}
+	if p.Identifier == "" {
+		p.Identifier = p.Name
+	}
+	if def.Collider != nil {
+		col, err := def.Collider.build()
+		if err != nil {
+			return nil, fmt.Errorf("prefab %s: %w", p.Name, err)
+		}
+		col.Tag = p.Identifier
+		p.collider = col
+	}
+
+	for i, cd := range def.Components {
+		l.mu.RLock()
+		kind, exists := l.kinds[cd.Type]
+		l.mu.RUnlock()
+		if !exists {
+			return nil, fmt.Errorf("prefab %s: components[%d]: %w: %q", p.Name, i, ErrUnknownKind, cd.Type)
+		}
+		c := kind.New()
+		if err := override(c, cd.Params); err != nil {
+			return nil, fmt.Errorf("prefab %s: components[%d] (%s): %w", p.Name, i, cd.Type, err)
+		}
+		p.template = append(p.template, c)
+		p.kinds = append(p.kinds, kind)
+	}
+
+	if _, err := p.spawn(l, "", geometry.Point{}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// spawn clones the template and wires the clones together.
+func (p *Prefab) spawn(l *Library, iid string, position geometry.Point) (*entities.Entity, error) {
+	var col *collider.Collider
+	if p.collider != nil {
+		col = cloneCollider(p.collider)
+		col.EntityID = iid
+	}
+
+	comps := make([]components.Component, len(p.template))
+	var transform *components.TransformComponent
+	for i, c := range p.template {
+		comps[i] = c.Clone()
+		if t, ok := comps[i].(*components.TransformComponent); ok && transform == nil {
+			transform = t
+			t.SetPosition(position.X, position.Y)
+			t.PreviousPosition = geometry.NewPoint(position.X, position.Y)
+		}
+	}
+
+	w := &Wiring{Transform: transform, Collider: col, Library: l}
+	for i, c := range comps {
+		if p.kinds[i].Wire == nil {
+			continue
+		}
+		w.Params = p.Components[i].Params
+		if err := p.kinds[i].Wire(c, w); err != nil {
+			return nil, fmt.Errorf("prefab %s: components[%d] (%s): %w", p.Name, i, p.Components[i].Type, err)
+		}
+	}
+
+	tags := make([]string, len(p.Tags))
+	copy(tags, p.Tags)
+	return &entities.Entity{
+		Identifier: p.Identifier,
+		IID:        iid,
+		Position:   []int{int(position.X), int(position.Y)},
+		Tags:       tags,
+		Components: comps,
+	}, nil
+}
+
+// override applies params over the fields c writes in Serialize.
+func override(c components.Component, params Params) error {
+	if len(params) == 0 {
+		return nil
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(c.Serialize(), &fields); err != nil {
+		return err
+	}
+	merge(fields, normalize(params).(map[string]any))
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return c.Deserialize(data)
+}
+
+// ---------------------------------------------------------------------------
+// Built-in kinds
+// ---------------------------------------------------------------------------
+
+func newTransform() components.Component {
+	return components.NewTransformComponent(geometry.NewPoint(0, 0), 0, 1)
+}
+
+func newPhysic() components.Component {
+	// The placeholder transform is replaced when the clone is wired.
+	body, _ := components.NewPhysicComponent(components.KinematicBody, nil, components.NewTransformComponent(geometry.NewPoint(0, 0), 0, 1))
+	return body
+}
+
+func wirePhysic(c components.Component, w *Wiring) error {
+	body := c.(*components.PhysicComponent)
+	if w.Transform == nil {
+		return errors.New("physic component needs a transform component")
+	}
+	if err := body.SetTransform(w.Transform); err != nil {
+		return err
+	}
+	body.SetCollider(w.Collider)
+	return nil
+}
+
+func newAI() components.Component {
+	return components.NewAIComponent(nil, nil)
+}
+
+func wireAI(c components.Component, w *Wiring) error {
+	ai := c.(*components.AIComponent)
+	ai.Transform = w.Transform
+	if name, ok := w.Params.String("machine"); ok {
+		machine, exists := w.Library.Machine(name)
+		if !exists {
+			return fmt.Errorf("%w: %q", ErrUnknownMachine, name)
+		}
+		ai.SetMachine(machine)
+	}
+	return nil
+}
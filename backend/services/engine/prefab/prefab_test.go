@@ -0,0 +1,231 @@
+package prefab
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+const goblinYAML = `
+name: goblin
+identifier: Goblin
+tags: [enemy, melee]
+collider:
+  layer: enemy
+  matches: [player, wall]
+  shapes:
+    - {type: circle, radius: 6}
+components:
+  - type: transform
+    params: {scale: 2}
+  - type: physic
+    params:
+      physic_type: 2
+      mass: 3
+      velocity: {x: 1.5}
+  - type: ai
+    params:
+      machine: melee
+      values: {health: 30}
+`
+
+const arrowJSON = `{
+	"name": "arrow",
+	"tags": ["projectile"],
+	"collider": {"layer": "projectile", "shapes": [{"type": "rectangle", "width": 8, "height": 2}]},
+	"components": [
+		{"type": "transform"},
+		{"type": "physic", "params": {"continuous_collision": true, "linear_damping": 0.2}}
+	]
+}`
+
+// --- Test Helpers ---
+
+func newTestLibrary(t *testing.T) *Library {
+	t.Helper()
+	l := NewLibrary()
+	l.RegisterMachine("melee", components.NewAIMachine(components.AIIdle))
+	goblin, err := ParseYAML([]byte(goblinYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+	if _, err := l.Add(goblin); err != nil {
+		t.Fatalf("Add(goblin) error = %v", err)
+	}
+	arrow, err := ParseJSON([]byte(arrowJSON))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	if _, err := l.Add(arrow); err != nil {
+		t.Fatalf("Add(arrow) error = %v", err)
+	}
+	return l
+}
+
+func parts(t *testing.T, e *entities.Entity) (*components.TransformComponent, *components.PhysicComponent) {
+	t.Helper()
+	transform, ok := e.Components[0].(*components.TransformComponent)
+	if !ok {
+		t.Fatalf("Components[0] = %T, expected *components.TransformComponent", e.Components[0])
+	}
+	body, ok := e.Components[1].(*components.PhysicComponent)
+	if !ok {
+		t.Fatalf("Components[1] = %T, expected *components.PhysicComponent", e.Components[1])
+	}
+	return transform, body
+}
+
+// --- Tests ---
+
+func TestLibrary_SpawnWiresComponents(t *testing.T) {
+	l := newTestLibrary(t)
+
+	e, err := l.Spawn("goblin", "goblin-1", geometry.Point{X: 40, Y: 80})
+	if err != nil {
+		t.Fatalf("Spawn() error = %v", err)
+	}
+	if e.Identifier != "Goblin" || e.IID != "goblin-1" || len(e.Tags) != 2 || e.Position[0] != 40 || e.Position[1] != 80 {
+		t.Errorf("entity = %+v, expected Goblin goblin-1 at (40, 80)", e)
+	}
+
+	transform, body := parts(t, e)
+	if transform.Position.X != 40 || transform.PreviousPosition.Y != 80 || transform.Scale != 2 {
+		t.Errorf("transform = %+v, expected scale 2 at (40, 80)", transform)
+	}
+	if body.GetTransform() != transform {
+		t.Errorf("body is not linked to the entity's transform")
+	}
+	if !body.IsRigid() || body.Mass != 3 || body.Velocity.X != 1.5 || body.Velocity.Y != 0 || body.Friction != 0.5 {
+		t.Errorf("body = %+v, expected the overrides over the defaults", body)
+	}
+
+	c := body.GetCollider()
+	if c == nil || c.EntityID != "goblin-1" || c.Tag != "Goblin" || !c.LayerMask.IsSet(collider.LayerEnemy) {
+		t.Fatalf("collider = %+v, expected an enemy collider keyed by goblin-1", c)
+	}
+	if !c.MatchMask.IsSet(collider.LayerWall) || c.MatchMask.IsSet(collider.LayerProjectile) {
+		t.Errorf("MatchMask = %b, expected player and wall", c.MatchMask)
+	}
+	if bounds := c.GetBounds(); bounds.MinX != 34 || bounds.MaxY != 86 {
+		t.Errorf("GetBounds() = %+v, expected a radius 6 circle around (40, 80)", bounds)
+	}
+
+	ai, ok := e.Components[2].(*components.AIComponent)
+	if !ok || ai.Transform != transform || ai.Machine() == nil || ai.Values["health"] != 30 {
+		t.Errorf("Components[2] = %+v, expected a wired AI with 30 health", e.Components[2])
+	}
+}
+
+func TestLibrary_SpawnedEntitiesShareNothing(t *testing.T) {
+	l := newTestLibrary(t)
+	a, _ := l.Spawn("arrow", "", geometry.Point{X: 0, Y: 0})
+	b, _ := l.Spawn("arrow", "", geometry.Point{X: 100, Y: 0})
+	if a.IID == "" || a.IID == b.IID {
+		t.Fatalf("IIDs %q and %q, expected distinct random IIDs", a.IID, b.IID)
+	}
+
+	ta, ba := parts(t, a)
+	tb, bb := parts(t, b)
+	if ta == tb || ba == bb || ba.GetCollider() == bb.GetCollider() || ta.ComponentID() == tb.ComponentID() {
+		t.Fatalf("spawned entities share components")
+	}
+	if !ba.ContinuousCollision || ba.LinearDamping != 0.2 {
+		t.Errorf("body = %+v, expected the arrow's overrides", ba)
+	}
+
+	ta.Translate(5, 0)
+	ba.SyncCollider()
+	if got := bb.GetCollider().GetBounds().MinX; got != 96 {
+		t.Errorf("second arrow MinX = %v after moving the first, expected 96", got)
+	}
+	if shapes := bb.GetCollider().ShapeList; shapes[0] == ba.GetCollider().ShapeList[0] {
+		t.Errorf("colliders share shapes")
+	}
+}
+
+func TestLibrary_AddErrors(t *testing.T) {
+	shape := []ShapeDef{{Type: ShapeCircle, Radius: 1}}
+	tests := []struct {
+		name     string
+		def      Definition
+		expected error
+	}{
+		{"unknown kind", Definition{Name: "x", Components: []ComponentDef{{Type: "inventory"}}}, ErrUnknownKind},
+		{"unknown machine", Definition{Name: "x", Components: []ComponentDef{{Type: KindAI, Params: Params{"machine": "boss"}}}}, ErrUnknownMachine},
+		{"unknown layer", Definition{Name: "x", Collider: &ColliderDef{Layer: "lava", Shapes: shape}}, ErrUnknownLayer},
+		{"physic without transform", Definition{Name: "x", Components: []ComponentDef{{Type: KindPhysic}}}, nil},
+		{"bad override", Definition{Name: "x", Components: []ComponentDef{{Type: KindPhysic, Params: Params{"mass": "heavy"}}}}, nil},
+		{"bad shape", Definition{Name: "x", Collider: &ColliderDef{Shapes: []ShapeDef{{Type: ShapeCircle}}}}, nil},
+		{"no name", Definition{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLibrary().Add(&tt.def)
+			if err == nil {
+				t.Fatalf("Add() error = nil, expected an error")
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("Add() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := NewLibrary().Spawn("dragon", "", geometry.Point{}); !errors.Is(err, ErrUnknownPrefab) {
+		t.Errorf("Spawn() error = %v, expected ErrUnknownPrefab", err)
+	}
+}
+
+func TestLibrary_LoadDir(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "goblin.yaml"), []byte(goblinYAML), 0o644)
+	os.WriteFile(filepath.Join(dir, "arrow.json"), []byte(arrowJSON), 0o644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("# prefabs"), 0o644)
+
+	l := NewLibrary()
+	l.RegisterMachine("melee", components.NewAIMachine(components.AIIdle))
+	loaded, err := l.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Errorf("LoadDir() loaded %d prefabs, expected 2", len(loaded))
+	}
+	if names := l.Names(); len(names) != 2 || names[0] != "arrow" || names[1] != "goblin" {
+		t.Errorf("Names() = %v, expected [arrow goblin]", names)
+	}
+
+	os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("name: broken\ncomponents: [{type: physic}]"), 0o644)
+	if _, err := l.LoadDir(dir); err == nil {
+		t.Errorf("LoadDir() error = nil, expected the broken prefab to fail")
+	}
+}
+
+func TestLibrary_CustomKind(t *testing.T) {
+	l := NewLibrary()
+	wired := 0
+	l.RegisterKind("marker", Kind{
+		New: func() components.Component { return components.NewTransformComponent(geometry.NewPoint(0, 0), 0, 1) },
+		Wire: func(c components.Component, w *Wiring) error {
+			wired++
+			c.(*components.TransformComponent).SetParent(w.Transform)
+			return nil
+		},
+	})
+	if _, err := l.Add(&Definition{Name: "banner", Components: []ComponentDef{{Type: KindTransform}, {Type: "marker"}}}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	e, err := l.Spawn("banner", "banner-1", geometry.Point{X: 3, Y: 4})
+	if err != nil {
+		t.Fatalf("Spawn() error = %v", err)
+	}
+	marker := e.Components[1].(*components.TransformComponent)
+	if marker.Parent() != e.Components[0] || wired != 2 {
+		t.Errorf("marker parent = %v after %d wirings, expected the entity's transform after 2", marker.Parent(), wired)
+	}
+}
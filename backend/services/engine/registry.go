@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/ecs"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems"
+)
+
+// Order of the built-in systems. Game systems slot in around them, e.g.
+// steering between OrderAI and OrderPhysics so velocities are set before
+// bodies move.
+const (
+	OrderTransforms = 100 // Snapshot previous transforms
+	OrderAI         = 150 // Step NPC state machines
+	OrderPhysics    = 200 // Integrate velocities
+)
+
+// registryChange is an AddEntity or RemoveEntity waiting to be mirrored into
+// the registry at the start of the next tick.
+type registryChange struct {
+	add    *entities.Entity
+	remove string
+}
+
+// newRegistry creates the world's registry with the engine's component
+// types and built-in systems, and records the types in types. Errors are
+// programmer errors, such as two systems sharing a name.
+func newRegistry(types map[reflect.Type]bool) (*ecs.Registry, error) {
+	r := ecs.NewRegistry()
+	for _, register := range []func(*ecs.Registry, map[reflect.Type]bool) error{
+		registerComponent[*components.TransformComponent],
+		registerComponent[*components.PhysicComponent],
+		registerComponent[*components.AIComponent],
+	} {
+		if err := register(r, types); err != nil {
+			return nil, err
+		}
+	}
+
+	builtins := []struct {
+		name  string
+		order int
+		new   func(*ecs.Registry) (ecs.System, error)
+	}{
+		{"transforms", OrderTransforms, systems.Transforms},
+		{"ai", OrderAI, systems.AI},
+		{"physics", OrderPhysics, systems.Physics},
+	}
+	for _, b := range builtins {
+		system, err := b.new(r)
+		if err != nil {
+			return nil, fmt.Errorf("system %s: %w", b.name, err)
+		}
+		if err := r.AddSystem(b.name, b.order, system); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func registerComponent[T components.Component](r *ecs.Registry, types map[reflect.Type]bool) error {
+	if _, err := ecs.RegisterType[T](r); err != nil {
+		return err
+	}
+	types[reflect.TypeFor[T]()] = true
+	return nil
+}
+
+// RegisterComponent lets the entities of w carry components of type T, which
+// systems can then query. The transform, physic and AI components are always
+// registered; AddEntity rejects entities with components of other types.
+// Like AddSystem, call it between ticks.
+func RegisterComponent[T components.Component](w *World) error {
+	w.tickMu.Lock()
+	defer w.tickMu.Unlock()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return registerComponent[T](w.registry, w.types)
+}
+
+// Registry returns the world's entity registry. It belongs to the simulation
+// goroutine: use it from systems and event handlers, or between ticks.
+func (w *World) Registry() *ecs.Registry {
+	return w.registry
+}
+
+// AddSystem registers a system to run every tick, see ecs.Registry.AddSystem
+// and the Order constants.
+func (w *World) AddSystem(name string, order int, system ecs.System) error {
+	w.tickMu.Lock()
+	defer w.tickMu.Unlock()
+	return w.registry.AddSystem(name, order, system)
+}
+
+// EntityID returns the registry ID of the entity with the given IID. Entities
+// get one at the start of the first tick after they were added.
+func (w *World) EntityID(iid string) (ecs.EntityID, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	id, exists := w.ids[iid]
+	return id, exists
+}
+
+// syncRegistry mirrors the entities added and removed since the last tick
+// into the registry.
+func (w *World) syncRegistry() {
+	w.mu.Lock()
+	changes := w.changes
+	w.changes = nil
+	w.mu.Unlock()
+
+	for _, change := range changes {
+		if change.add == nil {
+			w.mu.Lock()
+			id, exists := w.ids[change.remove]
+			delete(w.ids, change.remove)
+			w.mu.Unlock()
+			if exists {
+				w.registry.Destroy(id)
+			}
+			continue
+		}
+
+		// AddEntity only accepts registered component types, so AddAny
+		// cannot fail on the entity just created.
+		id := w.registry.Create()
+		for _, c := range change.add.Components {
+			w.registry.AddAny(id, c)
+		}
+		w.mu.Lock()
+		w.ids[change.add.IID] = id
+		w.mu.Unlock()
+	}
+}
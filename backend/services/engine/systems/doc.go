@@ -0,0 +1,5 @@
+// Package systems holds the engine's built-in ecs systems. They carry the
+// per-tick logic components used to run in their own Update: snapshotting
+// transforms, integrating physics bodies and advancing AI state machines,
+// each as one loop over the matching entities of an ecs.Registry.
+package systems
@@ -1,15 +1,47 @@
 package eventsystem
 
-import "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+import (
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collision"
+)
+
+// Collision event types emitted by the world once per tick and per pair.
+const (
+	CollisionEnter = "collision_enter" // first tick the pair touches
+	CollisionStay  = "collision_stay"  // every following tick while touching
+	CollisionExit  = "collision_exit"  // first tick the pair no longer touches
+)
 
 type CollisionData struct {
-	collider1 collider.Collider
-	collider2 collider.Collider
+	Collider1 *collider.Collider
+	Collider2 *collider.Collider
+	Manifold  *collision.Manifold // normal points from Collider1 to Collider2, nil on exit
+	Tick      uint64              // simulation tick the event was produced on
 }
 
 type CollisionEvent struct {
 	*EventManager[CollisionData]
 }
 
-func (c *CollisionEvent) OnCollide(collisionData CollisionData) {
+// NewCollisionEvent creates a collision event bus with no handlers.
+func NewCollisionEvent() *CollisionEvent {
+	return &CollisionEvent{EventManager: NewEventManager[CollisionData]()}
+}
+
+// IsTrigger reports whether either side of the collision is a trigger, in
+// which case the pair must not be physically resolved.
+func (d CollisionData) IsTrigger() bool {
+	return d.Collider1.IsTrigger || d.Collider2.IsTrigger
+}
+
+func (c *CollisionEvent) OnCollisionEnter(collisionData CollisionData) {
+	c.Emit(*NewEvent(collisionData, CollisionEnter))
+}
+
+func (c *CollisionEvent) OnCollisionStay(collisionData CollisionData) {
+	c.Emit(*NewEvent(collisionData, CollisionStay))
+}
+
+func (c *CollisionEvent) OnCollisionExit(collisionData CollisionData) {
+	c.Emit(*NewEvent(collisionData, CollisionExit))
 }
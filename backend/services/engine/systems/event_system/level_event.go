@@ -0,0 +1,34 @@
+package eventsystem
+
+import (
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// Level event types emitted by level streaming. A level is first loaded (its
+// static colliders become resident), then activated once a player comes
+// close (its entities are spawned); it leaves the world in reverse order.
+// LevelReload is emitted for every loaded level after a new version of the
+// map was swapped in.
+const (
+	LevelLoad       = "level_load"
+	LevelActivate   = "level_activate"
+	LevelDeactivate = "level_deactivate"
+	LevelUnload     = "level_unload"
+	LevelReload     = "level_reload"
+)
+
+type LevelData struct {
+	LevelIID   string
+	Identifier string
+	Bounds     geometry.Bounds // world-space area covered by the level
+	Tick       uint64          // simulation tick the event was produced on
+}
+
+type LevelEvent struct {
+	*EventManager[LevelData]
+}
+
+// NewLevelEvent creates a level event bus with no handlers.
+func NewLevelEvent() *LevelEvent {
+	return &LevelEvent{EventManager: NewEventManager[LevelData]()}
+}
@@ -0,0 +1,31 @@
+package eventsystem
+
+import (
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// Path event types emitted by the path request scheduler once a queued
+// request was searched.
+const (
+	PathFound  = "path_found"
+	PathFailed = "path_failed"
+)
+
+type PathData struct {
+	RequestID uint64
+	AgentID   string
+	Start     geometry.Point
+	Goal      geometry.Point
+	Path      []geometry.Point // waypoints from Start to Goal, nil on failure
+	Err       error            // why no path was found, nil on success
+	Tick      uint64           // simulation tick the search ran on
+}
+
+type PathEvent struct {
+	*EventManager[PathData]
+}
+
+// NewPathEvent creates a path event bus with no handlers.
+func NewPathEvent() *PathEvent {
+	return &PathEvent{EventManager: NewEventManager[PathData]()}
+}
@@ -0,0 +1,108 @@
+package systems
+
+import (
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/ecs"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// ---------------------------------------------------------------------------
+// Transforms
+// ---------------------------------------------------------------------------
+
+// Transforms returns a system snapshotting every active transform, see
+// SnapshotTransform. It runs before anything moves.
+func Transforms(r *ecs.Registry) (ecs.System, error) {
+	transforms, err := ecs.NewQuery1[*components.TransformComponent](r)
+	if err != nil {
+		return nil, err
+	}
+	return ecs.SystemFunc(func(r *ecs.Registry, dt float64) {
+		transforms.Each(func(_ ecs.EntityID, t *components.TransformComponent) {
+			if t.IsActive() {
+				SnapshotTransform(t)
+			}
+		})
+	}), nil
+}
+
+// SnapshotTransform stores the current position and rotation as the
+// previous ones, so that interpolation and sweep tests can see this tick's
+// displacement.
+func SnapshotTransform(t *components.TransformComponent) {
+	t.PreviousPosition = geometry.NewPoint(t.Position.X, t.Position.Y)
+	t.PreviousRotation = t.Rotation
+}
+
+// ---------------------------------------------------------------------------
+// Physics
+// ---------------------------------------------------------------------------
+
+// Physics returns a system integrating every active body, see IntegrateBody.
+func Physics(r *ecs.Registry) (ecs.System, error) {
+	bodies, err := ecs.NewQuery1[*components.PhysicComponent](r)
+	if err != nil {
+		return nil, err
+	}
+	return ecs.SystemFunc(func(r *ecs.Registry, dt float64) {
+		bodies.Each(func(_ ecs.EntityID, p *components.PhysicComponent) {
+			if p.IsActive() {
+				IntegrateBody(p, dt)
+			}
+		})
+	}), nil
+}
+
+// IntegrateBody damps the body's velocities, moves its transform by them
+// and syncs the collider. Static bodies are stopped instead. Bodies without
+// a transform are skipped.
+func IntegrateBody(p *components.PhysicComponent, deltaTime float64) {
+	transform := p.GetTransform()
+	if transform == nil {
+		return
+	}
+
+	switch *p.PhysicType {
+	case components.StaticBody:
+		p.Velocity = geometry.NewVector2(0, 0)
+		p.RotationVelocity = 0
+
+	case components.KinematicBody, components.RigidBody:
+		if p.LinearDamping > 0 {
+			factor := max(1.0-p.LinearDamping*deltaTime, 0)
+			p.Velocity.X *= factor
+			p.Velocity.Y *= factor
+		}
+		if p.AngularDamping > 0 {
+			p.RotationVelocity *= max(1.0-p.AngularDamping*deltaTime, 0)
+		}
+
+		transform.Translate(p.Velocity.X*deltaTime, p.Velocity.Y*deltaTime)
+		if p.RotationVelocity != 0 {
+			transform.Rotate(p.RotationVelocity * deltaTime)
+		}
+	}
+
+	p.SyncCollider()
+}
+
+// ---------------------------------------------------------------------------
+// AI
+// ---------------------------------------------------------------------------
+
+// AI returns a system stepping the state machine of every active AI
+// component, see components.AIMachine.Step. Register it before physics so
+// that the velocities its hooks set move the bodies the same tick.
+func AI(r *ecs.Registry) (ecs.System, error) {
+	agents, err := ecs.NewQuery1[*components.AIComponent](r)
+	if err != nil {
+		return nil, err
+	}
+	return ecs.SystemFunc(func(r *ecs.Registry, dt float64) {
+		agents.Each(func(_ ecs.EntityID, a *components.AIComponent) {
+			if m := a.Machine(); m != nil && a.IsActive() {
+				m.Step(a, dt)
+			}
+		})
+	}), nil
+}
@@ -0,0 +1,93 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/ecs"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// --- Test Helpers ---
+
+func newBody(t *testing.T, pt components.PhysicBodyType) *components.PhysicComponent {
+	t.Helper()
+	transform := components.NewTransformComponent(geometry.NewPoint(0, 0), 0, 1)
+	body, err := components.NewPhysicComponent(pt, &collider.Collider{Enabled: true}, transform)
+	if err != nil {
+		t.Fatalf("NewPhysicComponent() error = %v", err)
+	}
+	return body
+}
+
+// --- Tests ---
+
+func TestIntegrateBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		pt       components.PhysicBodyType
+		damping  float64
+		expected geometry.Point
+		speed    float64
+	}{
+		{"Kinematic body moves", components.KinematicBody, 0, geometry.Point{X: 5, Y: -2.5}, 10},
+		{"Damping slows before moving", components.RigidBody, 1, geometry.Point{X: 2.5, Y: -1.25}, 5},
+		{"Static body stops", components.StaticBody, 0, geometry.Point{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := newBody(t, tt.pt)
+			body.LinearDamping = tt.damping
+			body.Velocity = geometry.NewVector2(10, -5)
+			IntegrateBody(body, 0.5)
+
+			if got := *body.GetTransform().Position; got != tt.expected {
+				t.Errorf("Position = %+v, expected %+v", got, tt.expected)
+			}
+			if body.Velocity.X != tt.speed {
+				t.Errorf("Velocity.X = %v, expected %v", body.Velocity.X, tt.speed)
+			}
+			if got := body.GetCollider().Transform; got.X != tt.expected.X || got.Y != tt.expected.Y {
+				t.Errorf("collider at %+v, expected it synced to %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSystems_RunOverRegistry(t *testing.T) {
+	r := ecs.NewRegistry()
+	builders := map[string]func(*ecs.Registry) (ecs.System, error){"transforms": Transforms, "ai": AI, "physics": Physics}
+	for order, name := range []string{"transforms", "ai", "physics"} {
+		system, err := builders[name](r)
+		if err != nil {
+			t.Fatalf("%s error = %v", name, err)
+		}
+		r.AddSystem(name, order, system)
+	}
+
+	body := newBody(t, components.KinematicBody)
+	machine := components.NewAIMachine(components.AIIdle, components.AITransition{
+		From: components.AIIdle, To: components.AIPatrol,
+		Conditions: []components.AICondition{{Kind: components.InStateFor, Value: 0.1}},
+	}).On(components.AIPatrol, components.AIStateHooks{
+		Update: func(a *components.AIComponent, dt float64) { body.SetVelocity(4, 0) },
+	})
+	npc := components.NewAIComponent(machine, body.GetTransform())
+
+	e := r.Create()
+	ecs.Add(r, e, body.GetTransform())
+	ecs.Add(r, e, body)
+	ecs.Add(r, e, npc)
+
+	r.Update(0.25)
+	transform := body.GetTransform()
+	if npc.State != components.AIPatrol || transform.Position.X != 1 {
+		t.Errorf("after one tick: state %q at x=%v, expected patrol at x=1", npc.State, transform.Position.X)
+	}
+	r.Update(0.25)
+	if transform.PreviousPosition.X != 1 || transform.Position.X != 2 {
+		t.Errorf("previous x=%v, x=%v, expected the snapshot before moving: 1 then 2", transform.PreviousPosition.X, transform.Position.X)
+	}
+}
@@ -1,6 +1,510 @@
 // Package engine provides the core functionality for the game engine.
 package engine
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/ecs"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collision"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/spatial"
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+// Default simulation settings. The GDD targets a fixed 20ms (50Hz) tick.
+const (
+	DefaultTickRate        = 20 * time.Millisecond
+	DefaultMaxTicksPerStep = 5
+)
+
+// DefaultWorldBounds is the area covered by a quadtree broad phase when the
+// config does not say otherwise. Bodies outside of it still collide.
+var DefaultWorldBounds = geometry.Bounds{MinX: 0, MinY: 0, MaxX: 4096, MaxY: 4096}
+
+// WorldConfig holds the simulation settings of a World. Zero values are
+// replaced with the defaults above.
+type WorldConfig struct {
+	TickRate        time.Duration   // Fixed simulation step
+	MaxTicksPerStep int             // Upper bound of catch-up ticks run by a single Advance
+	Clock           Clock           // Source of real time, defaults to the system clock
+	BroadPhase      spatial.Kind    // Broad-phase implementation, defaults to the spatial hash
+	CellSize        float64         // Spatial hash cell size in world units
+	WorldBounds     geometry.Bounds // Area covered by the quadtree broad phase
+}
+
+// World owns the simulated entities and advances them in fixed steps. Every
+// tick runs the same phases in the same order:
+//
+//  1. run the registry's systems by order, among them
+//     a. snapshot previous transforms (OrderTransforms)
+//     b. step AI state machines (OrderAI)
+//     c. integrate physics (OrderPhysics)
+//  2. sweep continuous (fast) bodies
+//  3. detect collisions
+//  4. resolve contacts
+//  5. dispatch events
+//  6. stream levels
+//
+// Entities added with AddEntity are mirrored into an ecs.Registry at the
+// start of the next tick, so systems can query them by component.
 type World struct {
-	// Fields representing the world's state
+	config WorldConfig
+
+	mu       sync.RWMutex
+	entities []*entities.Entity
+	ids      map[string]ecs.EntityID // registry ID per IID
+	types    map[reflect.Type]bool   // component types registered in the registry
+	changes  []registryChange        // entity changes not yet in the registry
+
+	// tickMu serializes ticks; handlers run during dispatch may still add or
+	// remove entities because those only take mu.
+	tickMu     sync.Mutex
+	tick       uint64
+	registry   *ecs.Registry
+	broadPhase spatial.BroadPhase
+	tracked    map[string]bool             // EntityIDs currently stored in the broad phase
+	contacts   []eventsystem.CollisionData // pairs touching at the end of the last tick
+	pending    []eventsystem.Event[eventsystem.CollisionData]
+	resolver   *collision.Resolver
+	streamer   LevelStreamer
+
+	// Game loop state, see game_loop.go
+	loopMu      sync.Mutex
+	accumulator time.Duration
+	lastTime    time.Time
+	running     bool
+	stop        chan struct{}
+	done        chan struct{}
+
+	CollisionEvents *eventsystem.CollisionEvent
+	LevelEvents     *eventsystem.LevelEvent
+}
+
+// LevelStreamer loads and unloads map content around the players. The world
+// runs it at the end of every tick, after events were dispatched, so the
+// entities it adds or removes take part from the next tick on.
+type LevelStreamer interface {
+	Stream(w *World, tick uint64) error
+}
+
+// Validate reports settings NewWorld cannot run with, such as an unknown
+// broad-phase kind. Zero values are valid and replaced with the defaults.
+func (c WorldConfig) Validate() error {
+	switch c.BroadPhase {
+	case "", spatial.KindSpatialHash, spatial.KindQuadTree:
+		return nil
+	default:
+		return fmt.Errorf("unknown broad phase kind '%s'", c.BroadPhase)
+	}
+}
+
+// NewWorld creates an empty world using the given configuration. It panics
+// when the configuration fails Validate, so callers building it from user
+// settings should validate it first.
+func NewWorld(config WorldConfig) *World {
+	if err := config.Validate(); err != nil {
+		panic("engine: invalid world config: " + err.Error())
+	}
+	if config.TickRate <= 0 {
+		config.TickRate = DefaultTickRate
+	}
+	if config.MaxTicksPerStep <= 0 {
+		config.MaxTicksPerStep = DefaultMaxTicksPerStep
+	}
+	if config.Clock == nil {
+		config.Clock = systemClock{}
+	}
+	if config.CellSize <= 0 {
+		config.CellSize = spatial.DefaultCellSize
+	}
+	if config.WorldBounds.Width() <= 0 || config.WorldBounds.Height() <= 0 {
+		config.WorldBounds = DefaultWorldBounds
+	}
+
+	if config.BroadPhase == "" {
+		config.BroadPhase = spatial.KindSpatialHash
+	}
+	broadPhase, err := spatial.NewBroadPhase(config.BroadPhase, config.CellSize, config.WorldBounds)
+	if err != nil {
+		panic("engine: " + err.Error())
+	}
+	types := make(map[reflect.Type]bool)
+	registry, err := newRegistry(types)
+	if err != nil {
+		panic("engine: cannot set up the world's registry: " + err.Error())
+	}
+
+	return &World{
+		config:          config,
+		entities:        make([]*entities.Entity, 0),
+		ids:             make(map[string]ecs.EntityID),
+		types:           types,
+		registry:        registry,
+		broadPhase:      broadPhase,
+		tracked:         make(map[string]bool),
+		resolver:        collision.NewResolver(),
+		CollisionEvents: eventsystem.NewCollisionEvent(),
+		LevelEvents:     eventsystem.NewLevelEvent(),
+	}
+}
+
+// Config returns the effective configuration of the world.
+func (w *World) Config() WorldConfig {
+	return w.config
+}
+
+// Resolver returns the contact resolver so its iterations and correction
+// settings can be tuned.
+func (w *World) Resolver() *collision.Resolver {
+	return w.resolver
+}
+
+// SetLevelStreamer installs the streamer run at the end of every tick. Pass
+// nil to stop streaming.
+func (w *World) SetLevelStreamer(s LevelStreamer) {
+	w.tickMu.Lock()
+	defer w.tickMu.Unlock()
+	w.streamer = s
+}
+
+// CurrentTick returns the number of ticks simulated so far.
+func (w *World) CurrentTick() uint64 {
+	w.tickMu.Lock()
+	defer w.tickMu.Unlock()
+	return w.tick
+}
+
+// ---------------------------------------------------------------------------
+// Entity management
+// ---------------------------------------------------------------------------
+
+// AddEntity registers an entity with the world. Entities are identified by
+// their IID, which must be unique inside the world, and may only carry
+// component types the registry knows, see RegisterComponent. Colliders
+// without an EntityID inherit the entity's IID so the broad phase can track
+// them.
+func (w *World) AddEntity(e *entities.Entity) error {
+	if e == nil {
+		return errors.New("entity cannot be nil")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, c := range e.Components {
+		if !w.types[reflect.TypeOf(c)] {
+			return fmt.Errorf("entity '%s': %w: %T", e.IID, ecs.ErrUnregisteredType, c)
+		}
+	}
+	for _, existing := range w.entities {
+		if existing.IID == e.IID {
+			return errors.New("entity '" + e.IID + "' already exists in world")
+		}
+	}
+	for _, c := range e.Components {
+		if p, ok := c.(*components.PhysicComponent); ok && p.GetCollider() != nil && p.GetCollider().EntityID == "" {
+			p.GetCollider().SetEntityID(e.IID)
+		}
+	}
+	w.entities = append(w.entities, e)
+	w.changes = append(w.changes, registryChange{add: e})
+	return nil
+}
+
+// RemoveEntity removes the entity with the given IID. It returns false when
+// no such entity exists.
+func (w *World) RemoveEntity(iid string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, e := range w.entities {
+		if e.IID == iid {
+			w.entities = append(w.entities[:i], w.entities[i+1:]...)
+			w.changes = append(w.changes, registryChange{remove: iid})
+			return true
+		}
+	}
+	return false
+}
+
+// GetEntity returns the entity with the given IID.
+func (w *World) GetEntity(iid string) (*entities.Entity, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for _, e := range w.entities {
+		if e.IID == iid {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Entities returns a copy of the entity list.
+func (w *World) Entities() []*entities.Entity {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	out := make([]*entities.Entity, len(w.entities))
+	copy(out, w.entities)
+	return out
+}
+
+// ---------------------------------------------------------------------------
+// Queries
+// ---------------------------------------------------------------------------
+
+// Queries run against the broad phase as of the last collision detection, so
+// entities added since then are not visible until the next tick. They do not
+// lock the simulation and are meant to be called between ticks or from event
+// handlers, which run on the simulation goroutine.
+
+// Raycast returns the first collider hit by a ray from origin along
+// direction, up to maxDistance.
+func (w *World) Raycast(origin geometry.Point, direction geometry.Vector2, maxDistance float64, filter spatial.QueryFilter) (spatial.RaycastHit, bool) {
+	return spatial.Raycast(w.broadPhase, origin, direction, maxDistance, filter)
+}
+
+// RaycastAll returns every collider hit by the ray, nearest first.
+func (w *World) RaycastAll(origin geometry.Point, direction geometry.Vector2, maxDistance float64, filter spatial.QueryFilter) []spatial.RaycastHit {
+	return spatial.RaycastAll(w.broadPhase, origin, direction, maxDistance, filter)
+}
+
+// CircleCast sweeps a circle along the ray and returns the first collider it
+// touches.
+func (w *World) CircleCast(origin geometry.Point, direction geometry.Vector2, maxDistance, radius float64, filter spatial.QueryFilter) (spatial.RaycastHit, bool) {
+	return spatial.CircleCast(w.broadPhase, origin, direction, maxDistance, radius, filter)
+}
+
+// ---------------------------------------------------------------------------
+// Simulation
+// ---------------------------------------------------------------------------
+
+// Tick advances the simulation by exactly one fixed step, independent of the
+// clock. The game loop calls it from Advance; tests may call it directly.
+func (w *World) Tick() {
+	w.tickMu.Lock()
+	defer w.tickMu.Unlock()
+
+	w.tick++
+	dt := w.config.TickRate.Seconds()
+	w.syncRegistry()
+	w.registry.Update(dt)
+
+	bodies := activeBodies(w.Entities())
+	w.syncBroadPhase(bodies)
+	w.sweepContinuousBodies(bodies)
+	w.detectCollisions(bodies)
+	w.resolveContacts(bodies)
+	w.dispatchEvents()
+	w.streamLevels()
+}
+
+// ccdSkin is how far a swept body is left inside the collider it hit, so that
+// the narrow phase reports the contact and the resolver pushes it back out.
+const ccdSkin = 1e-6
+
+// sweepContinuousBodies moves every body with ContinuousCollision back to the
+// first solid collider it crossed between its previous and current position.
+// Other bodies are treated as resting at their current position. Bodies are
+// swept in EntityID order to keep ticks deterministic.
+func (w *World) sweepContinuousBodies(bodies map[*collider.Collider]*components.PhysicComponent) {
+	swept := make([]*collider.Collider, 0)
+	for c, p := range bodies {
+		if p.ContinuousCollision && !p.IsStatic() && !c.IsTrigger {
+			swept = append(swept, c)
+		}
+	}
+	sort.Slice(swept, func(i, j int) bool { return swept[i].EntityID < swept[j].EntityID })
+
+	for _, c := range swept {
+		p := bodies[c]
+		transform := p.GetTransform()
+		from := geometry.Vector2{X: transform.PreviousPosition.X, Y: transform.PreviousPosition.Y}
+		to := geometry.Vector2{X: transform.Position.X, Y: transform.Position.Y}
+		if from == to {
+			continue
+		}
+
+		var first *collision.Impact
+		for _, other := range w.broadPhase.QueryAABB(sweptBounds(c, from, to)) {
+			if other == c || other.IsTrigger || !c.CanPairWith(other) {
+				continue
+			}
+			if impact, ok := collision.SweepCollider(c, from, to, other); ok && (first == nil || impact.Time < first.Time) {
+				first = impact
+			}
+		}
+		if first == nil {
+			continue
+		}
+
+		transform.SetPosition(first.Position.X-first.Normal.X*ccdSkin, first.Position.Y-first.Normal.Y*ccdSkin)
+		p.SyncCollider()
+		w.broadPhase.Update(c)
+	}
+}
+
+// sweptBounds covers a collider moving from one transform to another.
+func sweptBounds(c *collider.Collider, from, to geometry.Vector2) geometry.Bounds {
+	end := c.GetBounds()
+	dx, dy := to.X-from.X, to.Y-from.Y
+	return geometry.Bounds{
+		MinX: math.Min(end.MinX, end.MinX-dx),
+		MinY: math.Min(end.MinY, end.MinY-dy),
+		MaxX: math.Max(end.MaxX, end.MaxX-dx),
+		MaxY: math.Max(end.MaxY, end.MaxY-dy),
+	}
+}
+
+// detectCollisions finds every touching collider pair and queues the
+// enter/stay/exit events for the dispatch phase. Candidate pairs come from the
+// broad phase; only those run through the narrow phase.
+func (w *World) detectCollisions(bodies map[*collider.Collider]*components.PhysicComponent) {
+
+	current := make([]eventsystem.CollisionData, 0)
+	for _, pair := range w.broadPhase.Pairs() {
+		a, b := bodies[pair.A], bodies[pair.B]
+		if a == nil || b == nil || (a.IsStatic() && b.IsStatic()) {
+			continue
+		}
+		manifold, ok := collision.CollideColliders(pair.A, pair.B)
+		if !ok {
+			continue
+		}
+		current = append(current, eventsystem.CollisionData{
+			Collider1: pair.A,
+			Collider2: pair.B,
+			Manifold:  manifold,
+			Tick:      w.tick,
+		})
+	}
+
+	w.queueContactEvents(current)
+}
+
+// resolveContacts pushes overlapping bodies apart and exchanges impulses for
+// every contact found this tick. Trigger pairs only produce events. Moved
+// colliders are synced so the events and next tick's queries see the final
+// positions.
+func (w *World) resolveContacts(bodies map[*collider.Collider]*components.PhysicComponent) {
+	resolved := make(map[*components.PhysicComponent]*collision.Body)
+	body := func(p *components.PhysicComponent) *collision.Body {
+		if b, ok := resolved[p]; ok {
+			return b
+		}
+		b := p.Body()
+		resolved[p] = b
+		return b
+	}
+
+	contacts := make([]collision.Contact, 0, len(w.contacts))
+	for _, c := range w.contacts {
+		if c.IsTrigger() {
+			continue
+		}
+		contacts = append(contacts, collision.Contact{
+			A:        body(bodies[c.Collider1]),
+			B:        body(bodies[c.Collider2]),
+			Manifold: c.Manifold,
+		})
+	}
+	if len(contacts) == 0 {
+		return
+	}
+
+	w.resolver.Resolve(contacts)
+	for p := range resolved {
+		p.SyncCollider()
+	}
+}
+
+// syncBroadPhase refreshes the broad phase with this tick's bodies and drops
+// the ones that disappeared or were disabled since the last tick.
+func (w *World) syncBroadPhase(bodies map[*collider.Collider]*components.PhysicComponent) {
+	seen := make(map[string]bool, len(bodies))
+	for c := range bodies {
+		if err := w.broadPhase.Update(c); err != nil {
+			delete(bodies, c)
+			continue
+		}
+		seen[c.EntityID] = true
+	}
+	for id := range w.tracked {
+		if !seen[id] {
+			w.broadPhase.Remove(id)
+		}
+	}
+	w.tracked = seen
+}
+
+// queueContactEvents diffs the pairs touching this tick against the previous
+// tick and queues the matching events.
+func (w *World) queueContactEvents(current []eventsystem.CollisionData) {
+	type pairKey struct{ a, b *collider.Collider }
+
+	previous := make(map[pairKey]bool, len(w.contacts))
+	for _, c := range w.contacts {
+		previous[pairKey{c.Collider1, c.Collider2}] = true
+	}
+	touching := make(map[pairKey]bool, len(current))
+	for _, c := range current {
+		key := pairKey{c.Collider1, c.Collider2}
+		touching[key] = true
+		if previous[key] {
+			w.pending = append(w.pending, *eventsystem.NewEvent(c, eventsystem.CollisionStay))
+		} else {
+			w.pending = append(w.pending, *eventsystem.NewEvent(c, eventsystem.CollisionEnter))
+		}
+	}
+	for _, c := range w.contacts {
+		if !touching[pairKey{c.Collider1, c.Collider2}] {
+			c.Tick = w.tick
+			c.Manifold = nil
+			w.pending = append(w.pending, *eventsystem.NewEvent(c, eventsystem.CollisionExit))
+		}
+	}
+
+	w.contacts = current
+}
+
+// dispatchEvents emits every event queued during this tick.
+func (w *World) dispatchEvents() {
+	pending := w.pending
+	w.pending = nil
+	for _, event := range pending {
+		w.CollisionEvents.Emit(event)
+	}
+}
+
+// streamLevels runs the level streamer, if any. Streaming errors do not stop
+// the simulation.
+func (w *World) streamLevels() {
+	if w.streamer == nil {
+		return
+	}
+	if err := w.streamer.Stream(w, w.tick); err != nil {
+		fmt.Printf("Warning: level streaming failed: %v\n", err)
+	}
+}
+
+// activeBodies returns the physic components that take part in collision
+// detection this tick, keyed by their collider.
+func activeBodies(ents []*entities.Entity) map[*collider.Collider]*components.PhysicComponent {
+	bodies := make(map[*collider.Collider]*components.PhysicComponent, len(ents))
+	for _, e := range ents {
+		for _, c := range e.Components {
+			p, ok := c.(*components.PhysicComponent)
+			if !ok || !p.IsActive() || p.GetCollider() == nil || !p.GetCollider().Enabled {
+				continue
+			}
+			bodies[p.GetCollider()] = p
+		}
+	}
+	return bodies
 }
@@ -0,0 +1,614 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/ecs"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/spatial"
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+// --- Fake Clock ---
+
+type fakeClock struct {
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Add(d time.Duration) { c.now = c.now.Add(d) }
+
+// --- Test Helpers ---
+
+func newTestBody(t *testing.T, id string, pt components.PhysicBodyType, x, y, radius float64) (*entities.Entity, *components.PhysicComponent) {
+	t.Helper()
+
+	c := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Circle{Radius: radius}},
+		Enabled:   true,
+		EntityID:  id,
+	}
+	c.LayerMask.SetBit(collider.LayerPlayer)
+	c.MatchMask.SetBit(collider.LayerPlayer)
+
+	transform := components.NewTransformComponent(geometry.NewPoint(x, y), 0, 1)
+	physic, err := components.NewPhysicComponent(pt, c, transform)
+	if err != nil {
+		t.Fatalf("NewPhysicComponent() error = %v", err)
+	}
+	physic.SyncCollider()
+
+	e := &entities.Entity{
+		Identifier: "TestBody",
+		IID:        id,
+		Components: []components.Component{transform, physic},
+	}
+	return e, physic
+}
+
+// tileSize is the edge length of the wall tiles built by newTestTile.
+const tileSize = 16
+
+// newTestTile returns a static square wall tile covering grid cell (col, row).
+func newTestTile(t *testing.T, col, row int) *entities.Entity {
+	t.Helper()
+
+	id := fmt.Sprintf("tile-%02d-%02d", col, row)
+	c := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{Width: tileSize, Height: tileSize}},
+		Enabled:   true,
+		EntityID:  id,
+	}
+	c.LayerMask.SetBit(collider.LayerWall)
+	c.MatchMask.SetBit(collider.LayerPlayer)
+
+	x, y := float64(col*tileSize+tileSize/2), float64(row*tileSize+tileSize/2)
+	transform := components.NewTransformComponent(geometry.NewPoint(x, y), 0, 1)
+	physic, err := components.NewPhysicComponent(components.StaticBody, c, transform)
+	if err != nil {
+		t.Fatalf("NewPhysicComponent() error = %v", err)
+	}
+	physic.SyncCollider()
+
+	return &entities.Entity{
+		Identifier: "Wall",
+		IID:        id,
+		Components: []components.Component{transform, physic},
+	}
+}
+
+// --- Tests ---
+
+func TestWorld_AdvanceRunsFixedTicks(t *testing.T) {
+	clock := newFakeClock()
+	w := NewWorld(WorldConfig{TickRate: 20 * time.Millisecond, Clock: clock})
+
+	if got := w.Advance(); got != 0 {
+		t.Fatalf("first Advance() = %d, expected 0", got)
+	}
+
+	clock.Add(50 * time.Millisecond)
+	if got := w.Advance(); got != 2 {
+		t.Fatalf("Advance() after 50ms = %d, expected 2", got)
+	}
+	if alpha := w.Alpha(); alpha < 0.49 || alpha > 0.51 {
+		t.Errorf("Alpha() = %v, expected 0.5", alpha)
+	}
+
+	clock.Add(10 * time.Millisecond)
+	if got := w.Advance(); got != 1 {
+		t.Fatalf("Advance() after leftover + 10ms = %d, expected 1", got)
+	}
+	if w.CurrentTick() != 3 {
+		t.Errorf("CurrentTick() = %d, expected 3", w.CurrentTick())
+	}
+}
+
+func TestWorld_AdvanceDropsBacklog(t *testing.T) {
+	clock := newFakeClock()
+	w := NewWorld(WorldConfig{TickRate: 20 * time.Millisecond, MaxTicksPerStep: 3, Clock: clock})
+	w.Advance()
+
+	clock.Add(time.Second)
+	if got := w.Advance(); got != 3 {
+		t.Fatalf("Advance() = %d, expected capped value 3", got)
+	}
+	if got := w.Advance(); got != 0 {
+		t.Errorf("Advance() after cap = %d, expected backlog to be dropped", got)
+	}
+}
+
+func TestWorld_TickSnapshotsBeforeIntegrating(t *testing.T) {
+	w := NewWorld(WorldConfig{TickRate: 100 * time.Millisecond, Clock: newFakeClock()})
+	e, physic := newTestBody(t, "mover", components.KinematicBody, 0, 0, 1)
+	if err := w.AddEntity(e); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+	physic.SetVelocity(10, 0)
+
+	w.Tick()
+	w.Tick()
+
+	transform := physic.GetTransform()
+	if transform.PreviousPosition.X != 1 {
+		t.Errorf("PreviousPosition.X = %v, expected 1", transform.PreviousPosition.X)
+	}
+	if transform.Position.X != 2 {
+		t.Errorf("Position.X = %v, expected 2", transform.Position.X)
+	}
+	if got := physic.GetCollider().Transform.X; got != 2 {
+		t.Errorf("collider Transform.X = %v, expected collider to follow transform", got)
+	}
+}
+
+func TestWorld_CollisionEnterStayExit(t *testing.T) {
+	for _, kind := range []spatial.Kind{spatial.KindSpatialHash, spatial.KindQuadTree} {
+		t.Run(string(kind), func(t *testing.T) {
+			w := NewWorld(WorldConfig{TickRate: 100 * time.Millisecond, Clock: newFakeClock(), BroadPhase: kind})
+
+			wall, _ := newTestBody(t, "a-wall", components.StaticBody, 5, 0, 1)
+			mover, physic := newTestBody(t, "b-mover", components.KinematicBody, 0, 0, 1)
+			w.AddEntity(wall)
+			w.AddEntity(mover)
+
+			var got []string
+			for _, eventType := range []string{eventsystem.CollisionEnter, eventsystem.CollisionStay, eventsystem.CollisionExit} {
+				w.CollisionEvents.Register(eventType, func(e eventsystem.Event[eventsystem.CollisionData]) {
+					if e.Data.Collider1.EntityID != "a-wall" || e.Data.Collider2.EntityID != "b-mover" {
+						t.Errorf("unexpected pair %s/%s", e.Data.Collider1.EntityID, e.Data.Collider2.EntityID)
+					}
+					got = append(got, e.EventType)
+				})
+			}
+
+			physic.SetVelocity(30, 0)
+			w.Tick() // x=3, touching (distance 2)
+			w.Tick() // x=6, overlapping
+			physic.SetVelocity(100, 0)
+			w.Tick() // x=16, apart
+
+			expected := []string{eventsystem.CollisionEnter, eventsystem.CollisionStay, eventsystem.CollisionExit}
+			if len(got) != len(expected) {
+				t.Fatalf("events = %v, expected %v", got, expected)
+			}
+			for i := range expected {
+				if got[i] != expected[i] {
+					t.Errorf("events[%d] = %s, expected %s", i, got[i], expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWorldConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    spatial.Kind
+		wantErr bool
+	}{
+		{"Default", "", false},
+		{"Spatial hash", spatial.KindSpatialHash, false},
+		{"Quadtree", spatial.KindQuadTree, false},
+		{"Typo", "quadtre", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WorldConfig{BroadPhase: tt.kind}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorld_UnknownBroadPhasePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewWorld() did not panic, expected an unknown broad phase to fail")
+		}
+	}()
+	NewWorld(WorldConfig{BroadPhase: "quadtre"})
+}
+
+func TestWorld_DefaultBroadPhase(t *testing.T) {
+	w := NewWorld(WorldConfig{})
+
+	if w.Config().BroadPhase != spatial.KindSpatialHash {
+		t.Errorf("BroadPhase = %s, expected %s", w.Config().BroadPhase, spatial.KindSpatialHash)
+	}
+}
+
+func TestWorld_EntityManagement(t *testing.T) {
+	w := NewWorld(WorldConfig{})
+	e, _ := newTestBody(t, "npc-1", components.StaticBody, 0, 0, 1)
+
+	if err := w.AddEntity(e); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+	if err := w.AddEntity(e); err == nil {
+		t.Errorf("AddEntity() with duplicate IID should fail")
+	}
+	if _, ok := w.GetEntity("npc-1"); !ok {
+		t.Errorf("GetEntity() did not find npc-1")
+	}
+	if !w.RemoveEntity("npc-1") {
+		t.Errorf("RemoveEntity() = false, expected true")
+	}
+	if len(w.Entities()) != 0 {
+		t.Errorf("Entities() not empty after removal")
+	}
+}
+
+// recordingStreamer adds a body on its first run and records every tick it
+// was called on.
+type recordingStreamer struct {
+	body  *entities.Entity
+	ticks []uint64
+}
+
+func (s *recordingStreamer) Stream(w *World, tick uint64) error {
+	s.ticks = append(s.ticks, tick)
+	if len(s.ticks) == 1 {
+		return w.AddEntity(s.body)
+	}
+	return nil
+}
+
+func TestWorld_LevelStreamerRunsAfterEachTick(t *testing.T) {
+	w := NewWorld(WorldConfig{})
+	player, _ := newTestBody(t, "player", components.KinematicBody, 0, 0, 1)
+	wall, _ := newTestBody(t, "wall", components.StaticBody, 1, 0, 1)
+	if err := w.AddEntity(player); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+
+	enters := 0
+	w.CollisionEvents.Register(eventsystem.CollisionEnter, func(eventsystem.Event[eventsystem.CollisionData]) { enters++ })
+
+	streamer := &recordingStreamer{body: wall}
+	w.SetLevelStreamer(streamer)
+	w.Tick()
+	if enters != 0 {
+		t.Errorf("streamed body collided in the tick that added it")
+	}
+	w.Tick()
+	if enters != 1 {
+		t.Errorf("enters = %d, expected the streamed body to collide on the next tick", enters)
+	}
+	if len(streamer.ticks) != 2 || streamer.ticks[0] != 1 || streamer.ticks[1] != 2 {
+		t.Errorf("ticks = %v, expected the streamer to run after ticks 1 and 2", streamer.ticks)
+	}
+
+	w.SetLevelStreamer(nil)
+	w.Tick()
+	if len(streamer.ticks) != 2 {
+		t.Errorf("streamer still ran after being removed")
+	}
+}
+
+func TestWorld_StartStop(t *testing.T) {
+	w := NewWorld(WorldConfig{TickRate: time.Millisecond})
+
+	if err := w.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := w.Start(); err == nil {
+		t.Errorf("second Start() should fail while running")
+	}
+	time.Sleep(20 * time.Millisecond)
+	w.Stop()
+
+	if w.IsRunning() {
+		t.Errorf("IsRunning() = true after Stop()")
+	}
+	if w.CurrentTick() == 0 {
+		t.Errorf("expected the loop to have simulated at least one tick")
+	}
+	w.Stop() // no-op
+}
+
+func TestWorld_PlayerWalksDiagonallyIntoWalls(t *testing.T) {
+	const radius = 5
+
+	tests := []struct {
+		name  string
+		tiles [][2]int // (col, row) of every wall tile
+		start geometry.Point
+		ticks int
+		check func(t *testing.T, prev, pos geometry.Point)
+		final func(t *testing.T, pos geometry.Point)
+	}{
+		{
+			// Floor along row 6 (y 96..112), made of separate tiles.
+			name:  "Slides along a tiled floor",
+			tiles: tileRow(0, 15, 6),
+			start: geometry.Point{X: 20, Y: 60},
+			ticks: 60,
+			check: func(t *testing.T, prev, pos geometry.Point) {
+				if pos.Y > 96-radius+1e-9 {
+					t.Fatalf("Y = %v, player entered the floor", pos.Y)
+				}
+				if !almostEqual(pos.X-prev.X, 2) {
+					t.Fatalf("X advanced %v in one tick, expected 2 (snagged on a seam?)", pos.X-prev.X)
+				}
+			},
+			final: func(t *testing.T, pos geometry.Point) {
+				assertPosition(t, pos, geometry.Point{X: 140, Y: 96 - radius})
+			},
+		},
+		{
+			// Floor along row 6 closed by a wall along column 7 (x 112..128).
+			name:  "Stops in a concave corner",
+			tiles: append(tileRow(0, 7, 6), tileColumn(7, 0, 5)...),
+			start: geometry.Point{X: 40, Y: 40},
+			ticks: 100,
+			check: func(t *testing.T, prev, pos geometry.Point) {
+				if pos.X > 112-radius+1e-9 || pos.Y > 96-radius+1e-9 {
+					t.Fatalf("Position = %+v, player tunnelled into the corner", pos)
+				}
+			},
+			final: func(t *testing.T, pos geometry.Point) {
+				assertPosition(t, pos, geometry.Point{X: 112 - radius, Y: 96 - radius})
+			},
+		},
+		{
+			// A ledge ending at x=64; the player must slide off its edge.
+			name:  "Slides past a convex corner",
+			tiles: tileRow(0, 3, 6),
+			start: geometry.Point{X: 40, Y: 85},
+			ticks: 60,
+			check: func(t *testing.T, prev, pos geometry.Point) {
+				if pos.X <= prev.X {
+					t.Fatalf("X = %v after %v, player stuck on the ledge corner", pos.X, prev.X)
+				}
+			},
+			final: func(t *testing.T, pos geometry.Point) {
+				if pos.Y < 112+radius {
+					t.Errorf("Y = %v, expected the player to fall past the ledge", pos.Y)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(WorldConfig{Clock: newFakeClock()})
+			for _, tile := range tt.tiles {
+				if err := w.AddEntity(newTestTile(t, tile[0], tile[1])); err != nil {
+					t.Fatalf("AddEntity() error = %v", err)
+				}
+			}
+			player, physic := newTestBody(t, "player", components.KinematicBody, tt.start.X, tt.start.Y, radius)
+			physic.GetCollider().MatchMask.SetBit(collider.LayerWall)
+			w.AddEntity(player)
+
+			position := physic.GetTransform().Position
+			for i := 0; i < tt.ticks; i++ {
+				prev := *position
+				// Input is re-applied every tick, like a held movement key.
+				physic.SetVelocity(100, 100)
+				w.Tick()
+				tt.check(t, prev, *position)
+			}
+			tt.final(t, *position)
+		})
+	}
+}
+
+func tileRow(fromCol, toCol, row int) [][2]int {
+	tiles := make([][2]int, 0, toCol-fromCol+1)
+	for col := fromCol; col <= toCol; col++ {
+		tiles = append(tiles, [2]int{col, row})
+	}
+	return tiles
+}
+
+func tileColumn(col, fromRow, toRow int) [][2]int {
+	tiles := make([][2]int, 0, toRow-fromRow+1)
+	for row := fromRow; row <= toRow; row++ {
+		tiles = append(tiles, [2]int{col, row})
+	}
+	return tiles
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func assertPosition(t *testing.T, got, expected geometry.Point) {
+	t.Helper()
+	if !almostEqual(got.X, expected.X) || !almostEqual(got.Y, expected.Y) {
+		t.Errorf("Position = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestWorld_ContinuousCollisionStopsFastProjectile(t *testing.T) {
+	for _, ccd := range []bool{false, true} {
+		t.Run(fmt.Sprintf("ccd=%v", ccd), func(t *testing.T) {
+			w := NewWorld(WorldConfig{Clock: newFakeClock()})
+
+			// A single 16px wall tile at x in [64, 80].
+			w.AddEntity(newTestTile(t, 4, 0))
+			bullet, physic := newTestBody(t, "bullet", components.KinematicBody, 20, 8, 2)
+			physic.ContinuousCollision = ccd
+			w.AddEntity(bullet)
+
+			entered := 0
+			w.CollisionEvents.Register(eventsystem.CollisionEnter, func(e eventsystem.Event[eventsystem.CollisionData]) {
+				entered++
+			})
+
+			// 4000px/s moves the bullet 80px per 20ms tick, across the tile.
+			physic.SetVelocity(4000, 0)
+			w.Tick()
+
+			x := physic.GetTransform().Position.X
+			if !ccd {
+				if x != 100 || entered != 0 {
+					t.Fatalf("X = %v, enters = %d, expected the bullet to tunnel to 100 unnoticed", x, entered)
+				}
+				return
+			}
+			if !almostEqual(x, 64-2) {
+				t.Errorf("X = %v, expected the bullet to stop at the wall face (62)", x)
+			}
+			if entered != 1 {
+				t.Errorf("enter events = %d, expected 1", entered)
+			}
+			if physic.GetVelocity().X != 0 {
+				t.Errorf("Velocity.X = %v, expected the bullet to stop moving into the wall", physic.GetVelocity().X)
+			}
+		})
+	}
+}
+
+func TestWorld_RaycastLineOfSight(t *testing.T) {
+	w := NewWorld(WorldConfig{Clock: newFakeClock()})
+	archer, _ := newTestBody(t, "archer", components.KinematicBody, 8, 40, 4)
+	target, _ := newTestBody(t, "target", components.KinematicBody, 120, 40, 4)
+	w.AddEntity(archer)
+	w.AddEntity(target)
+	w.AddEntity(newTestTile(t, 4, 2)) // x in [64, 80], y in [32, 48]
+
+	if _, ok := w.Raycast(geometry.Point{X: 8, Y: 40}, geometry.Vector2{X: 1, Y: 0}, 200, spatial.QueryFilter{}); ok {
+		t.Fatalf("Raycast() hit before the first tick filled the broad phase")
+	}
+	w.Tick()
+
+	filter := spatial.QueryFilter{IgnoreEntityID: "archer"}
+	hit, ok := w.Raycast(geometry.Point{X: 8, Y: 40}, geometry.Vector2{X: 1, Y: 0}, 200, filter)
+	if !ok || hit.EntityID != "tile-04-02" || !almostEqual(hit.Distance, 56) {
+		t.Errorf("Raycast() = %+v, %v, expected the wall tile at distance 56", hit, ok)
+	}
+
+	if all := w.RaycastAll(geometry.Point{X: 8, Y: 40}, geometry.Vector2{X: 1, Y: 0}, 200, filter); len(all) != 2 || all[1].EntityID != "target" {
+		t.Errorf("RaycastAll() = %+v, expected the wall and then the target", all)
+	}
+
+	// Over the top of the tile only a thin ray gets through.
+	over := geometry.Point{X: 8, Y: 30}
+	if hit, ok := w.Raycast(over, geometry.Vector2{X: 1, Y: 0}, 200, filter); ok {
+		t.Errorf("Raycast() over the wall hit %s", hit.EntityID)
+	}
+	if hit, ok := w.CircleCast(over, geometry.Vector2{X: 1, Y: 0}, 200, 3, filter); !ok || hit.EntityID != "tile-04-02" {
+		t.Errorf("CircleCast() over the wall = %+v, %v, expected it to clip the tile", hit, ok)
+	}
+}
+
+func TestWorld_SystemsRunOverRegistry(t *testing.T) {
+	w := NewWorld(WorldConfig{TickRate: 100 * time.Millisecond, Clock: newFakeClock()})
+	e, physic := newTestBody(t, "npc", components.KinematicBody, 0, 0, 1)
+	if err := w.AddEntity(e); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+
+	// A steering-like system runs before physics and sees every body.
+	bodies, _ := ecs.NewQuery2[*components.TransformComponent, *components.PhysicComponent](w.Registry())
+	seen := 0
+	err := w.AddSystem("steering", OrderPhysics-50, ecs.SystemFunc(func(r *ecs.Registry, dt float64) {
+		bodies.Each(func(_ ecs.EntityID, _ *components.TransformComponent, p *components.PhysicComponent) {
+			seen++
+			p.SetVelocity(10, 0)
+		})
+	}))
+	if err != nil {
+		t.Fatalf("AddSystem() error = %v", err)
+	}
+	if fmt.Sprint(w.Registry().Systems()) != "[transforms ai steering physics]" {
+		t.Errorf("Systems() = %v", w.Registry().Systems())
+	}
+
+	w.Tick()
+	if seen != 1 || physic.GetTransform().Position.X != 1 {
+		t.Errorf("steering saw %d bodies and the body moved to %v, expected 1 and x=1", seen, physic.GetTransform().Position)
+	}
+	id, ok := w.EntityID("npc")
+	if !ok || !ecs.Has[*components.PhysicComponent](w.Registry(), id) {
+		t.Fatalf("EntityID(npc) = %v, %v, expected a registry entity holding the body", id, ok)
+	}
+
+	w.RemoveEntity("npc")
+	w.Tick()
+	if w.Registry().Alive(id) || seen != 1 {
+		t.Errorf("removed entity is still simulated")
+	}
+	if _, ok := w.EntityID("npc"); ok {
+		t.Errorf("EntityID(npc) still resolves after removal")
+	}
+}
+
+// inventory is a game component the engine does not register itself.
+type inventory struct {
+	*components.TransformComponent
+}
+
+func TestWorld_AddEntityRejectsUnregisteredComponents(t *testing.T) {
+	w := NewWorld(WorldConfig{})
+	bag := &inventory{components.NewTransformComponent(geometry.NewPoint(0, 0), 0, 1)}
+	e := &entities.Entity{IID: "chest", Components: []components.Component{bag}}
+
+	if err := w.AddEntity(e); !errors.Is(err, ecs.ErrUnregisteredType) {
+		t.Fatalf("AddEntity() error = %v, expected ErrUnregisteredType", err)
+	}
+	if _, exists := w.GetEntity("chest"); exists {
+		t.Errorf("rejected entity was added")
+	}
+
+	if err := RegisterComponent[*inventory](w); err != nil {
+		t.Fatalf("RegisterComponent() error = %v", err)
+	}
+	if err := w.AddEntity(e); err != nil {
+		t.Fatalf("AddEntity() error = %v after registering the type", err)
+	}
+	w.Tick()
+	if id, ok := w.EntityID("chest"); !ok || !ecs.Has[*inventory](w.Registry(), id) {
+		t.Errorf("EntityID(chest) = %v, %v, expected a registry entity holding the inventory", id, ok)
+	}
+}
+
+func TestWorld_AIStepsNPCsEveryTick(t *testing.T) {
+	w := NewWorld(WorldConfig{TickRate: 100 * time.Millisecond, Clock: newFakeClock()})
+	e, physic := newTestBody(t, "goblin", components.KinematicBody, 0, 0, 1)
+	player := components.NewTransformComponent(geometry.NewPoint(50, 0), 0, 1)
+
+	machine := components.NewAIMachine(components.AIIdle,
+		components.AITransition{From: components.AIIdle, To: components.AIChase, Conditions: []components.AICondition{
+			{Kind: components.TargetWithin, Value: 64},
+			{Kind: components.InStateFor, Value: 0.25},
+		}},
+	).On(components.AIChase, components.AIStateHooks{
+		Update: func(a *components.AIComponent, dt float64) { physic.SetVelocity(10, 0) },
+	})
+	npc := components.NewAIComponent(machine, physic.GetTransform())
+	npc.SetTarget("player", player)
+	e.Components = append(e.Components, npc)
+	if err := w.AddEntity(e); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+
+	states := make([]components.AIState, 0)
+	for i := 0; i < 4; i++ {
+		w.Tick()
+		states = append(states, npc.State)
+	}
+	if got := fmt.Sprint(states); got != "[idle idle chase chase]" {
+		t.Errorf("states per tick = %s, expected the NPC to start chasing on the third tick", got)
+	}
+	// The chase hook runs before physics, so the body moved on both chase ticks.
+	if x := physic.GetTransform().Position.X; math.Abs(x-2) > 1e-9 {
+		t.Errorf("NPC at x=%v, expected 2 after two ticks of chasing", x)
+	}
+}
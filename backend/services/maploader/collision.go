@@ -0,0 +1,166 @@
+package maploader
+
+import (
+	"fmt"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/data"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+// StaticColliderConfig controls how the cells of a collision grid become
+// colliders. Zero values are replaced with defaults.
+type StaticColliderConfig struct {
+	CellSize  float64                  // Edge length of a cell in world units, defaults to 16
+	Origin    geometry.Vector2         // World position of the grid's top-left corner
+	Layers    map[int]collider.Bitmask // Layer bits per grid value; nil puts every non-zero value on LayerWall
+	MatchMask collider.Bitmask         // Layers the colliders react to, defaults to players, enemies and projectiles
+	IDPrefix  string                   // Prefix of the generated EntityIDs, defaults to the grid name
+}
+
+// DefaultCellSize is the cell edge length used when a config leaves it unset.
+const DefaultCellSize = coordinate.DefaultCellSize
+
+// CollisionData copies an IntGrid layer into the engine's collision grid.
+func (l *Layer) CollisionData() *data.CollisionData {
+	cells := make([]int, len(l.IntGrid))
+	copy(cells, l.IntGrid)
+	return &data.CollisionData{
+		ID:        l.LayerDefUID,
+		Name:      l.Identifier,
+		Width:     l.GridWidth,
+		Height:    l.GridHeight,
+		Collision: cells,
+	}
+}
+
+// StaticColliders builds the static colliders of an IntGrid layer of level,
+// placed at the level's world position. A nil level places the layer at the
+// world origin, like Grid, and prefixes the EntityIDs with the layer alone.
+func (l *Layer) StaticColliders(level *Level, layers map[int]collider.Bitmask) ([]*collider.Collider, error) {
+	grid := l.Grid(level)
+	prefix := l.Identifier
+	if level != nil {
+		prefix = level.Identifier + "/" + l.Identifier
+	}
+	return BuildStaticColliders(l.CollisionData(), StaticColliderConfig{
+		CellSize: grid.CellSize,
+		Origin:   grid.Origin,
+		Layers:   layers,
+		IDPrefix: prefix,
+	})
+}
+
+// BuildStaticColliders merges the solid cells of grid into as few rectangles
+// as possible and returns one enabled collider per rectangle. Cells are only
+// merged with cells that map to the same layer bits, so water and walls stay
+// apart. Values without layer bits are treated as empty.
+//
+// Merging is greedy: each rectangle grows right as far as it can, then down
+// while the whole row below matches. This is not optimal but keeps a mostly
+// walled map to a few hundred bodies instead of one per cell.
+//
+// It returns a MapLoaderError when the grid holds fewer or more cells than
+// Width*Height.
+func BuildStaticColliders(grid *data.CollisionData, config StaticColliderConfig) ([]*collider.Collider, error) {
+	if grid.Width < 0 || grid.Height < 0 || len(grid.Collision) != grid.Width*grid.Height {
+		return nil, NewMapLoaderError(fmt.Sprintf("collision grid %q has %d cells, expected %dx%d",
+			grid.Name, len(grid.Collision), grid.Width, grid.Height))
+	}
+	if config.CellSize <= 0 {
+		config.CellSize = DefaultCellSize
+	}
+	if config.MatchMask == 0 {
+		config.MatchMask.SetLayers(collider.LayerPlayer, collider.LayerEnemy, collider.LayerProjectile)
+	}
+	if config.IDPrefix == "" {
+		config.IDPrefix = grid.Name
+	}
+	cells := coordinate.Grid{CellSize: config.CellSize, Origin: config.Origin}
+
+	mask := func(x, y int) collider.Bitmask {
+		value := grid.Collision[y*grid.Width+x]
+		if value == 0 {
+			return 0
+		}
+		if config.Layers == nil {
+			var wall collider.Bitmask
+			wall.SetBit(collider.LayerWall)
+			return wall
+		}
+		return config.Layers[value]
+	}
+
+	visited := make([]bool, len(grid.Collision))
+	colliders := make([]*collider.Collider, 0)
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			layer := mask(x, y)
+			if layer == 0 || visited[y*grid.Width+x] {
+				continue
+			}
+
+			w := 1
+			for x+w < grid.Width && !visited[y*grid.Width+x+w] && mask(x+w, y) == layer {
+				w++
+			}
+			h := 1
+			for y+h < grid.Height && rowMatches(grid, visited, mask, x, y+h, w, layer) {
+				h++
+			}
+			for cy := y; cy < y+h; cy++ {
+				for cx := x; cx < x+w; cx++ {
+					visited[cy*grid.Width+cx] = true
+				}
+			}
+
+			bounds := cells.CellBounds(coordinate.Cell{X: x, Y: y}, w, h)
+			colliders = append(colliders, &collider.Collider{
+				ShapeList: []geometry.Shape{&geometry.Rectangle{Width: bounds.Width(), Height: bounds.Height()}},
+				Transform: geometry.Vector2{X: (bounds.MinX + bounds.MaxX) / 2, Y: (bounds.MinY + bounds.MaxY) / 2},
+				LayerMask: layer,
+				MatchMask: config.MatchMask,
+				Enabled:   true,
+				Tag:       grid.Name,
+				EntityID:  fmt.Sprintf("%s#%d,%d", config.IDPrefix, x, y),
+			})
+		}
+	}
+	return colliders, nil
+}
+
+// rowMatches reports whether the w cells of row y starting at x are unvisited
+// and all map to layer.
+func rowMatches(grid *data.CollisionData, visited []bool, mask func(x, y int) collider.Bitmask, x, y, w int, layer collider.Bitmask) bool {
+	for cx := x; cx < x+w; cx++ {
+		if visited[y*grid.Width+cx] || mask(cx, y) != layer {
+			return false
+		}
+	}
+	return true
+}
+
+// NewStaticBody wraps a collider into an entity with a static PhysicComponent
+// so that it can be added to a World. The collider's EntityID becomes the IID.
+func NewStaticBody(c *collider.Collider) (*entities.Entity, error) {
+	if c.EntityID == "" {
+		return nil, NewMapLoaderError("static collider needs an EntityID")
+	}
+
+	transform := components.NewTransformComponent(geometry.NewPoint(c.Transform.X, c.Transform.Y), c.Rotation, 1)
+	physic, err := components.NewPhysicComponent(components.StaticBody, c, transform)
+	if err != nil {
+		return nil, err
+	}
+	physic.SyncCollider()
+
+	return &entities.Entity{
+		Identifier: c.Tag,
+		IID:        c.EntityID,
+		Position:   []int{int(c.Transform.X), int(c.Transform.Y)},
+		Components: []components.Component{transform, physic},
+	}, nil
+}
@@ -0,0 +1,203 @@
+package maploader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/data"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+)
+
+func layerMask(layers ...uint32) collider.Bitmask {
+	var mask collider.Bitmask
+	mask.SetLayers(layers...)
+	return mask
+}
+
+func TestBuildStaticColliders_GreedyMeshing(t *testing.T) {
+	// 1 = wall, 2 = water, 3 = decoration without collision.
+	grid := &data.CollisionData{
+		Name:   "Collisions",
+		Width:  5,
+		Height: 4,
+		Collision: []int{
+			1, 1, 1, 1, 1,
+			1, 0, 2, 2, 1,
+			1, 0, 2, 2, 1,
+			1, 1, 1, 3, 1,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		layers   map[int]collider.Bitmask
+		expected int
+	}{
+		{
+			name:     "Every value is a wall",
+			expected: 4, // top row, left column, the 3x3 block right of the hole and the cell below the hole
+		},
+		{
+			name: "Water on its own layer",
+			layers: map[int]collider.Bitmask{
+				1: layerMask(collider.LayerWall),
+				2: layerMask(collider.LayerWater),
+			},
+			expected: 5, // top row, left and right columns, water block and the bottom row up to the decoration
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colliders, err := BuildStaticColliders(grid, StaticColliderConfig{CellSize: 10, Layers: tt.layers})
+			if err != nil {
+				t.Fatalf("BuildStaticColliders() error = %v", err)
+			}
+			if len(colliders) != tt.expected {
+				t.Fatalf("len(colliders) = %d, expected %d", len(colliders), tt.expected)
+			}
+
+			// Every solid cell center must be covered exactly once.
+			for y := 0; y < grid.Height; y++ {
+				for x := 0; x < grid.Width; x++ {
+					value := grid.Collision[y*grid.Width+x]
+					solid := value != 0
+					if tt.layers != nil {
+						solid = tt.layers[value] != 0
+					}
+					center := geometry.Point{X: float64(x)*10 + 5, Y: float64(y)*10 + 5}
+					covered := 0
+					for _, c := range colliders {
+						if c.GetWorldSpaceShapes()[0].ContainsPoint(&center) {
+							covered++
+						}
+					}
+					if solid && covered != 1 || !solid && covered != 0 {
+						t.Errorf("cell (%d, %d) covered %d times", x, y, covered)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildStaticColliders_Layers(t *testing.T) {
+	grid := &data.CollisionData{Name: "Collisions", Width: 2, Height: 1, Collision: []int{1, 2}}
+	colliders, err := BuildStaticColliders(grid, StaticColliderConfig{
+		CellSize: 16,
+		Origin:   geometry.Vector2{X: 100, Y: 200},
+		Layers: map[int]collider.Bitmask{
+			1: layerMask(collider.LayerWall),
+			2: layerMask(collider.LayerWater),
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildStaticColliders() error = %v", err)
+	}
+	if len(colliders) != 2 {
+		t.Fatalf("len(colliders) = %d, expected 2", len(colliders))
+	}
+
+	wall, water := colliders[0], colliders[1]
+	if !wall.LayerMask.IsSet(collider.LayerWall) || !water.LayerMask.IsSet(collider.LayerWater) || water.LayerMask.IsSet(collider.LayerWall) {
+		t.Errorf("LayerMasks = %b, %b, expected wall and water bits", wall.LayerMask, water.LayerMask)
+	}
+	if wall.Transform != (geometry.Vector2{X: 108, Y: 208}) {
+		t.Errorf("Transform = %+v, expected the cell center offset by the origin", wall.Transform)
+	}
+	if !wall.MatchMask.IsSet(collider.LayerPlayer) || !wall.Enabled {
+		t.Errorf("collider = %+v, expected an enabled collider matching players", wall)
+	}
+	if wall.EntityID == water.EntityID {
+		t.Errorf("EntityIDs are not unique: %q", wall.EntityID)
+	}
+}
+
+func TestBuildStaticColliders_LargeSolidMap(t *testing.T) {
+	const size = 256
+	grid := &data.CollisionData{Name: "Collisions", Width: size, Height: size, Collision: make([]int, size*size)}
+	for i := range grid.Collision {
+		grid.Collision[i] = 1
+	}
+	// Carve a walkable room in the middle.
+	for y := 64; y < 192; y++ {
+		for x := 64; x < 192; x++ {
+			grid.Collision[y*size+x] = 0
+		}
+	}
+
+	colliders, err := BuildStaticColliders(grid, StaticColliderConfig{})
+	if err != nil {
+		t.Fatalf("BuildStaticColliders() error = %v", err)
+	}
+	if len(colliders) > 4 {
+		t.Errorf("len(colliders) = %d, expected the walls around the room to merge into at most 4", len(colliders))
+	}
+}
+
+func TestBuildStaticColliders_TruncatedGrid(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []int
+	}{
+		{"Truncated", []int{1, 1, 1, 1, 1}},
+		{"Empty", nil},
+		{"Too long", make([]int, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := &data.CollisionData{Name: "Collisions", Width: 3, Height: 2, Collision: tt.cells}
+			colliders, err := BuildStaticColliders(grid, StaticColliderConfig{})
+			var mapErr *MapLoaderError
+			if !errors.As(err, &mapErr) || colliders != nil {
+				t.Errorf("BuildStaticColliders() = %v, %v, expected a MapLoaderError", colliders, err)
+			}
+		})
+	}
+}
+
+func TestLayer_StaticColliders(t *testing.T) {
+	project := loadSample(t)
+	level := project.Level("Level_0")
+	level.WorldX, level.WorldY = 512, 0
+
+	colliders, err := level.Layer("Collisions").StaticColliders(level, nil)
+	if err != nil {
+		t.Fatalf("StaticColliders() error = %v", err)
+	}
+	if len(colliders) != 2 {
+		t.Fatalf("len(colliders) = %d, expected the top row and the water cell", len(colliders))
+	}
+	if bounds := colliders[0].GetBounds(); bounds.MinX != 512 || bounds.MaxX != 560 || bounds.MaxY != 16 {
+		t.Errorf("GetBounds() = %+v, expected the top row at the level's world position", bounds)
+	}
+
+	body, err := NewStaticBody(colliders[0])
+	if err != nil {
+		t.Fatalf("NewStaticBody() error = %v", err)
+	}
+	physic, ok := body.Components[1].(*components.PhysicComponent)
+	if !ok || !physic.IsStatic() || physic.GetCollider() != colliders[0] || body.IID != colliders[0].EntityID {
+		t.Errorf("NewStaticBody() = %+v, expected a static body owning the collider", body)
+	}
+}
+
+func TestLayer_StaticCollidersWithoutLevel(t *testing.T) {
+	layer := loadSample(t).Level("Level_0").Layer("Collisions")
+
+	colliders, err := layer.StaticColliders(nil, nil)
+	if err != nil {
+		t.Fatalf("StaticColliders() error = %v", err)
+	}
+	if len(colliders) != 2 {
+		t.Fatalf("len(colliders) = %d, expected the top row and the water cell", len(colliders))
+	}
+	if bounds := colliders[0].GetBounds(); bounds.MinX != 0 || bounds.MaxX != 48 {
+		t.Errorf("GetBounds() = %+v, expected the top row at the world origin", bounds)
+	}
+	if id := colliders[0].EntityID; id != "Collisions#0,0" {
+		t.Errorf("EntityID = %q, expected Collisions#0,0", id)
+	}
+}
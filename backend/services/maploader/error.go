@@ -0,0 +1,19 @@
+package maploader
+
+import (
+	"fmt"
+)
+
+// MapLoaderError reports a project file that cannot be read or does not
+// follow the LDtk format.
+type MapLoaderError struct {
+	Message string
+}
+
+func (e *MapLoaderError) Error() string {
+	return fmt.Sprintf("Map Loader Error: %s", e.Message)
+}
+
+func NewMapLoaderError(message string) *MapLoaderError {
+	return &MapLoaderError{Message: message}
+}
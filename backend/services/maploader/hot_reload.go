@@ -0,0 +1,84 @@
+package maploader
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// HotReloader re-parses a project whenever one of its files changes on disk
+// and hands the new version to a LevelStreamer, which swaps it into the world
+// between ticks. It is meant for development: level designers save in LDtk
+// and see the change on the running server without reconnecting.
+type HotReloader struct {
+	path     string
+	streamer *LevelStreamer
+	watcher  *Watcher
+	notify   func(changed []string, err error)
+}
+
+// NewHotReloader creates a reloader for the project at path. The project's
+// directory, including external levels, is polled every interval.
+func NewHotReloader(path string, streamer *LevelStreamer, interval time.Duration) *HotReloader {
+	return &HotReloader{
+		path:     path,
+		streamer: streamer,
+		watcher:  NewWatcher(filepath.Dir(path), interval),
+	}
+}
+
+// OnReload sets the function told how a reload went: err is nil once the new
+// version took effect in the world, and the error that stopped it otherwise.
+// changed lists the files that triggered it. The function runs on the
+// watcher's goroutine for files that fail to parse and on the ticking
+// goroutine for everything else, so it must not block. Set it before Start.
+func (r *HotReloader) OnReload(fn func(changed []string, err error)) {
+	r.notify = fn
+}
+
+// Check polls the map directory once and reloads the project when a file
+// changed. It reports whether a new version was queued; the streamer applies
+// it on its next Update, see OnReload for the outcome. A project that fails to
+// parse is not queued, so a half-saved file leaves the running map as is.
+func (r *HotReloader) Check() (bool, error) {
+	changed, err := r.watcher.Poll()
+	if err != nil || len(changed) == 0 {
+		return false, err
+	}
+	return r.reload(changed)
+}
+
+func (r *HotReloader) reload(changed []string) (bool, error) {
+	project, err := Load(r.path)
+	if err != nil {
+		r.report(changed, err)
+		return false, err
+	}
+	r.streamer.queueReload(project, func(err error) { r.report(changed, err) })
+	return true, nil
+}
+
+func (r *HotReloader) report(changed []string, err error) {
+	if r.notify != nil {
+		r.notify(changed, err)
+	}
+}
+
+// Start records the current state of the map directory and reloads in the
+// background from then on. Outcomes are reported to the OnReload function.
+func (r *HotReloader) Start() error {
+	if _, err := r.watcher.Poll(); err != nil {
+		return err
+	}
+	return r.watcher.Start(func(changed []string, err error) {
+		if err != nil {
+			r.report(changed, err)
+			return
+		}
+		r.reload(changed)
+	})
+}
+
+// Stop halts the background reloading.
+func (r *HotReloader) Stop() {
+	r.watcher.Stop()
+}
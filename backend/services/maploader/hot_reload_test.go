@@ -0,0 +1,213 @@
+package maploader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+func TestLevelStreamer_Reload(t *testing.T) {
+	registry := NewEntityRegistry()
+	registry.Register("NPC", BodyFactory(components.KinematicBody, collider.LayerEnemy))
+	streamer := NewLevelStreamer(corridor(t), registry, StreamingConfig{})
+
+	w := engine.NewWorld(engine.WorldConfig{})
+	player := &entities.Entity{IID: "player"}
+	if err := w.AddEntity(player); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+	focus := []geometry.Point{{X: 40, Y: 8}}
+	if err := streamer.Update(w, focus, 1); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	wallA, _ := w.GetEntity("A/Collisions#0,0")
+	wallB, _ := w.GetEntity("B/Collisions#0,0")
+	npc, _ := w.GetEntity("npc-B")
+
+	// A gets a wider wall, B's NPC moves and C is deleted.
+	edited := corridor(t)
+	edited.Level("A").Layer("Collisions").IntGrid = []int{1, 1}
+	edited.Level("B").Layer("Entities").Entities[0].Position = []int{20, 8}
+	edited.Levels = edited.Levels[:2]
+
+	events := make([]string, 0)
+	for _, eventType := range []string{eventsystem.LevelLoad, eventsystem.LevelActivate, eventsystem.LevelDeactivate, eventsystem.LevelUnload, eventsystem.LevelReload} {
+		w.LevelEvents.Register(eventType, func(e eventsystem.Event[eventsystem.LevelData]) {
+			events = append(events, e.EventType+":"+e.Data.Identifier)
+		})
+	}
+
+	streamer.Reload(edited)
+	if err := streamer.Update(w, focus, 2); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	w.Tick() // dispatches the queued level events
+
+	if got, _ := w.GetEntity("player"); got != player {
+		t.Errorf("player entity was replaced by the reload")
+	}
+	if got, _ := w.GetEntity("B/Collisions#0,0"); got != wallB {
+		t.Errorf("unchanged wall of B was replaced")
+	}
+	if got, ok := w.GetEntity("A/Collisions#0,0"); !ok || got == wallA {
+		t.Errorf("changed wall of A was not replaced")
+	}
+	if got, ok := w.GetEntity("npc-B"); !ok || got == npc || got.Position[0] != 52 {
+		t.Errorf("npc-B = %+v, expected it respawned at x=52", got)
+	}
+	if streamer.IsLoaded("C") || streamer.Project() != edited {
+		t.Errorf("deleted level C is still loaded")
+	}
+	if got := len(w.Entities()); got != 4 {
+		t.Errorf("len(Entities()) = %d, expected the player, two walls and npc-B", got)
+	}
+	if fmt.Sprint(events) != "[level_reload:A level_reload:B level_unload:C]" {
+		t.Errorf("events = %v, expected A and B reloaded and C unloaded", events)
+	}
+}
+
+func TestLevelStreamer_ReloadIsAtomic(t *testing.T) {
+	registry := NewEntityRegistry()
+	registry.Register("NPC", BodyFactory(components.KinematicBody, collider.LayerEnemy))
+	original := corridor(t)
+	streamer := NewLevelStreamer(original, registry, StreamingConfig{})
+
+	w := engine.NewWorld(engine.WorldConfig{})
+	focus := []geometry.Point{{X: 40, Y: 8}}
+	if err := streamer.Update(w, focus, 1); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	before := len(w.Entities())
+	wallA, _ := w.GetEntity("A/Collisions#0,0")
+
+	// A's change is valid, but B's grid lost a cell, so nothing may apply.
+	edited := corridor(t)
+	edited.Level("A").Layer("Collisions").IntGrid = []int{1, 1}
+	edited.Level("B").Layer("Collisions").IntGrid = []int{1}
+	edited.Levels = edited.Levels[:2]
+
+	streamer.Reload(edited)
+	var loaderErr *MapLoaderError
+	if err := streamer.Update(w, focus, 2); !errors.As(err, &loaderErr) {
+		t.Fatalf("Update() error = %v, expected a *MapLoaderError", err)
+	}
+	if streamer.Project() != original || !streamer.IsLoaded("C") {
+		t.Errorf("the rejected reload replaced the project")
+	}
+	if got, _ := w.GetEntity("A/Collisions#0,0"); got != wallA {
+		t.Errorf("wall of A was replaced by a rejected reload")
+	}
+	if got := len(w.Entities()); got != before {
+		t.Errorf("len(Entities()) = %d, expected the %d entities of the old map", got, before)
+	}
+
+	if err := streamer.Update(w, focus, 3); err != nil {
+		t.Errorf("Update() error = %v, expected the rejected reload to be dropped", err)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "world.ldtk")
+	write := func(path, data string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(project, "{}")
+
+	watcher := NewWatcher(dir, 0)
+	poll := func() string {
+		t.Helper()
+		changed, err := watcher.Poll()
+		if err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+		for i := range changed {
+			changed[i], _ = filepath.Rel(dir, changed[i])
+		}
+		return fmt.Sprint(changed)
+	}
+
+	if got := poll(); got != "[]" {
+		t.Errorf("first Poll() = %s, expected only a baseline", got)
+	}
+	write(project, `{"jsonVersion": "1.5.3"}`)
+	write(filepath.Join(dir, "Level_0.ldtkl"), "{}")
+	write(filepath.Join(dir, "notes.txt"), "todo")
+	if got := poll(); got != "[Level_0.ldtkl world.ldtk]" {
+		t.Errorf("Poll() = %s, expected both map files", got)
+	}
+	if got := poll(); got != "[]" {
+		t.Errorf("Poll() = %s, expected no changes", got)
+	}
+	if err := os.Remove(filepath.Join(dir, "Level_0.ldtkl")); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(); got != "[Level_0.ldtkl]" {
+		t.Errorf("Poll() = %s, expected the removed level", got)
+	}
+}
+
+func TestHotReloader_Check(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "world.ldtk")
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	level := func(width int) string {
+		return fmt.Sprintf(`{"jsonVersion": "1.5.3", "levels": [{"identifier": "Level_0", "iid": "l0", "pxWid": %d, "pxHei": 16}]}`, width)
+	}
+	write(level(16))
+	project, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	streamer := NewLevelStreamer(project, nil, StreamingConfig{})
+	reloader := NewHotReloader(path, streamer, 0)
+	reports := make([]string, 0)
+	reloader.OnReload(func(changed []string, err error) {
+		reports = append(reports, fmt.Sprintf("%d files, failed: %v", len(changed), err != nil))
+	})
+
+	if reloaded, err := reloader.Check(); reloaded || err != nil {
+		t.Fatalf("first Check() = %v, %v, expected a baseline", reloaded, err)
+	}
+
+	write(`{"jsonVersion": "1.5.3", "levels": [`)
+	reloaded, err := reloader.Check()
+	var loaderErr *MapLoaderError
+	if reloaded || !errors.As(err, &loaderErr) {
+		t.Errorf("Check() = %v, %v, expected a *MapLoaderError for a broken file", reloaded, err)
+	}
+
+	write(level(320))
+	if reloaded, err := reloader.Check(); !reloaded || err != nil {
+		t.Fatalf("Check() = %v, %v, expected a reload", reloaded, err)
+	}
+	if len(reports) != 1 {
+		t.Errorf("reports = %v, expected the queued reload to wait for Update", reports)
+	}
+	if err := streamer.Update(engine.NewWorld(engine.WorldConfig{}), nil, 1); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if streamer.Project().Level("Level_0").Width != 320 {
+		t.Errorf("Project() was not swapped for the reloaded map")
+	}
+	if fmt.Sprint(reports) != "[1 files, failed: true 1 files, failed: false]" {
+		t.Errorf("reports = %v, expected the broken file then the applied reload", reports)
+	}
+}
@@ -0,0 +1,255 @@
+package maploader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+)
+
+// Load reads and parses the LDtk project at path. Levels saved as separate
+// .ldtkl files ("Save levels to separate files" in LDtk) are read from the
+// project's directory.
+func Load(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewMapLoaderError(fmt.Sprintf("cannot read %s: %v", path, err))
+	}
+
+	project, err := decodeProject(data, path)
+	if err != nil {
+		return nil, err
+	}
+	project.Path = path
+
+	for _, level := range project.Levels {
+		if level.ExternalRelPath == "" {
+			continue
+		}
+		if err := loadExternalLevel(filepath.Dir(path), level); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := project.link(); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// Parse parses an LDtk project from memory. Levels stored in external files
+// cannot be resolved without a directory and keep a nil Layers slice; use
+// Load for such projects.
+func Parse(data []byte) (*Project, error) {
+	project, err := decodeProject(data, "project")
+	if err != nil {
+		return nil, err
+	}
+	if err := project.link(); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func decodeProject(data []byte, name string) (*Project, error) {
+	var project Project
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, jsonError(name, data, err)
+	}
+	if project.JSONVersion == "" {
+		return nil, NewMapLoaderError(name + " is not an LDtk project: missing jsonVersion")
+	}
+	return &project, nil
+}
+
+// loadExternalLevel replaces level with the content of its .ldtkl file.
+func loadExternalLevel(dir string, level *Level) error {
+	path := filepath.Join(dir, filepath.FromSlash(level.ExternalRelPath))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewMapLoaderError(fmt.Sprintf("cannot read level %q from %s: %v", level.Identifier, path, err))
+	}
+
+	external := *level
+	if err := json.Unmarshal(data, &external); err != nil {
+		return jsonError(path, data, err)
+	}
+	if external.IID != level.IID {
+		return NewMapLoaderError(fmt.Sprintf("%s holds level %q, expected %q", path, external.IID, level.IID))
+	}
+	*level = external
+	return nil
+}
+
+// UnmarshalJSON decodes the definitions and turns the tileset custom data and
+// enum tags into the lookup maps of entities.Tileset.
+func (d *Definitions) UnmarshalJSON(data []byte) error {
+	type plain Definitions
+	var raw struct {
+		plain
+		Tilesets []*tilesetJSON `json:"tilesets"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*d = Definitions(raw.plain)
+	d.Tilesets = make([]*entities.Tileset, len(raw.Tilesets))
+	for i, t := range raw.Tilesets {
+		tileset := t.Tileset
+		tileset.Path = filepath.FromSlash(tileset.Path)
+		tileset.CustomData = make(map[int]string, len(t.CustomData))
+		for _, c := range t.CustomData {
+			tileset.CustomData[c.TileID] = c.Data
+		}
+		tileset.Enums = make(map[int]entities.EnumSet)
+		for _, tag := range t.EnumTags {
+			for _, id := range tag.TileIDs {
+				tileset.Enums[id] = append(tileset.Enums[id], tag.EnumValueID)
+			}
+		}
+		d.Tilesets[i] = &tileset
+	}
+	return nil
+}
+
+type tilesetJSON struct {
+	entities.Tileset
+	CustomData []struct {
+		TileID int    `json:"tileId"`
+		Data   string `json:"data"`
+	} `json:"customData"`
+	EnumTags []struct {
+		EnumValueID string `json:"enumValueId"`
+		TileIDs     []int  `json:"tileIds"`
+	} `json:"enumTags"`
+}
+
+// link resolves the uid references between definitions and level data and
+// checks that every layer is consistent with its definition.
+func (p *Project) link() error {
+	tilesets := make(map[int]*entities.Tileset, len(p.Defs.Tilesets))
+	for _, tileset := range p.Defs.Tilesets {
+		if _, exists := tilesets[tileset.ID]; exists {
+			return NewMapLoaderError(fmt.Sprintf("duplicate tileset uid %d", tileset.ID))
+		}
+		tilesets[tileset.ID] = tileset
+	}
+	tileset := func(uid *int, owner string) (*entities.Tileset, error) {
+		if uid == nil {
+			return nil, nil
+		}
+		t, ok := tilesets[*uid]
+		if !ok {
+			return nil, NewMapLoaderError(fmt.Sprintf("%s references unknown tileset uid %d", owner, *uid))
+		}
+		return t, nil
+	}
+	linkRect := func(rect *entities.TileRect, owner string) error {
+		if rect == nil {
+			return nil
+		}
+		t, err := tileset(&rect.TilesetUID, owner)
+		rect.Tileset = t
+		return err
+	}
+
+	layerDefs := make(map[int]*LayerDef, len(p.Defs.Layers))
+	for _, def := range p.Defs.Layers {
+		owner := fmt.Sprintf("layer definition %q", def.Identifier)
+		if !def.Type.valid() {
+			return NewMapLoaderError(fmt.Sprintf("%s has unknown type %q", owner, def.Type))
+		}
+		t, err := tileset(def.TilesetDefUID, owner)
+		if err != nil {
+			return err
+		}
+		def.TilesetDef = t
+		layerDefs[def.UID] = def
+	}
+
+	for _, enums := range [][]*EnumDef{p.Defs.Enums, p.Defs.ExternalEnums} {
+		for _, def := range enums {
+			for _, value := range def.Values {
+				if err := linkRect(value.TileRect, fmt.Sprintf("enum value %s.%s", def.Identifier, value.ID)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, level := range p.Levels {
+		for _, layer := range level.Layers {
+			owner := fmt.Sprintf("layer %q of level %q", layer.Identifier, level.Identifier)
+			if !layer.Type.valid() {
+				return NewMapLoaderError(fmt.Sprintf("%s has unknown type %q", owner, layer.Type))
+			}
+			def, ok := layerDefs[layer.LayerDefUID]
+			if !ok {
+				return NewMapLoaderError(fmt.Sprintf("%s references unknown layer definition uid %d", owner, layer.LayerDefUID))
+			}
+			layer.Def = def
+			t, err := tileset(layer.TilesetUID, owner)
+			if err != nil {
+				return err
+			}
+			layer.Tileset = t
+
+			if layer.Type == LayerIntGrid && len(layer.IntGrid) != layer.GridWidth*layer.GridHeight {
+				return NewMapLoaderError(fmt.Sprintf("%s has %d IntGrid values, expected %dx%d",
+					owner, len(layer.IntGrid), layer.GridWidth, layer.GridHeight))
+			}
+			for _, tile := range append(layer.GridTiles, layer.AutoLayerTiles...) {
+				if len(tile.Position) != 2 || len(tile.Src) != 2 {
+					return NewMapLoaderError(fmt.Sprintf("%s has tile %d without a pixel position", owner, tile.ID))
+				}
+			}
+			for i, entity := range layer.Entities {
+				if entity.Entity == nil || entity.Identifier == "" {
+					return NewMapLoaderError(fmt.Sprintf("%s has entity #%d without an identifier", owner, i))
+				}
+				entityOwner := fmt.Sprintf("entity %q in %s", entity.Identifier, owner)
+				if len(entity.Position) != 2 {
+					return NewMapLoaderError(entityOwner + " has no pixel position")
+				}
+				if err := linkRect(entity.TileRect, entityOwner); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (t LayerType) valid() bool {
+	switch t {
+	case LayerIntGrid, LayerEntities, LayerTiles, LayerAutoLayer:
+		return true
+	}
+	return false
+}
+
+// jsonError describes a decoding error with the line it occurred on.
+func jsonError(name string, data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return NewMapLoaderError(fmt.Sprintf("malformed JSON in %s at line %d: %v", name, lineOf(data, syntaxErr.Offset), err))
+	case errors.As(err, &typeErr):
+		return NewMapLoaderError(fmt.Sprintf("unexpected %s for field %q in %s at line %d, expected %s",
+			typeErr.Value, typeErr.Field, name, lineOf(data, typeErr.Offset), typeErr.Type))
+	}
+	return NewMapLoaderError(fmt.Sprintf("cannot decode %s: %v", name, err))
+}
+
+func lineOf(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
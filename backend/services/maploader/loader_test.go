@@ -0,0 +1,216 @@
+package maploader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadSample(t *testing.T) *Project {
+	t.Helper()
+	project, err := Load(filepath.Join("testdata", "sample.ldtk"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return project
+}
+
+func TestLoad_Definitions(t *testing.T) {
+	project := loadSample(t)
+
+	if project.WorldLayout != "GridVania" || project.DefaultGridSize != 16 {
+		t.Errorf("WorldLayout, DefaultGridSize = %q, %d, expected GridVania, 16", project.WorldLayout, project.DefaultGridSize)
+	}
+
+	tileset := project.Tileset(1)
+	if tileset == nil {
+		t.Fatalf("Tileset(1) = nil")
+	}
+	if tileset.Identifier != "Dungeon" || tileset.GridSize != 16 || tileset.Width != 64 {
+		t.Errorf("Tileset = %+v, expected the 64px Dungeon tileset with 16px tiles", tileset)
+	}
+	if tileset.Path != filepath.FromSlash("tilesets/dungeon.png") {
+		t.Errorf("Path = %q, expected an OS specific relative path", tileset.Path)
+	}
+	if tileset.CustomData[5] != `{"damage":3}` {
+		t.Errorf("CustomData[5] = %q, expected the tile's custom data", tileset.CustomData[5])
+	}
+	if enums := tileset.Enums[1]; len(enums) != 2 || enums[0] != "Solid" || enums[1] != "Slippery" {
+		t.Errorf("Enums[1] = %v, expected [Solid Slippery]", enums)
+	}
+
+	collisions := project.LayerDef(11)
+	if collisions == nil || collisions.Type != LayerIntGrid || collisions.TilesetDef != tileset {
+		t.Fatalf("LayerDef(11) = %+v, expected the IntGrid layer using the Dungeon tileset", collisions)
+	}
+	if len(collisions.IntGridValues) != 2 || collisions.IntGridValues[1].Identifier != "water" {
+		t.Errorf("IntGridValues = %v, expected wall and water", collisions.IntGridValues)
+	}
+
+	enum := project.Enum("TileFlags")
+	if enum == nil || len(enum.Values) != 2 {
+		t.Fatalf("Enum(TileFlags) = %+v, expected two values", enum)
+	}
+	if enum.Values[0].TileRect.Tileset != tileset {
+		t.Errorf("enum value icon not linked to its tileset")
+	}
+}
+
+func TestLoad_Level(t *testing.T) {
+	project := loadSample(t)
+
+	level := project.Level("Level_0")
+	if level == nil {
+		t.Fatalf("Level(Level_0) = nil")
+	}
+	if project.LevelByIID(level.IID) != level {
+		t.Errorf("LevelByIID() did not find Level_0")
+	}
+	if level.Width != 48 || level.Height != 32 || len(level.Neighbours) != 1 || level.Neighbours[0].Dir != "e" {
+		t.Errorf("Level = %+v, expected a 48x32 level with an eastern neighbour", level)
+	}
+	if len(level.Properties) != 1 || level.Properties[0].Value != "dungeon_theme" {
+		t.Errorf("Properties = %v, expected the music field", level.Properties)
+	}
+
+	grid := level.Layer("Collisions")
+	if grid == nil || grid.Def != project.LayerDef(11) {
+		t.Fatalf("Layer(Collisions) = %+v, expected it linked to its definition", grid)
+	}
+	if grid.IntGridAt(2, 1) != 2 || grid.IntGridAt(0, 1) != 0 || grid.IntGridAt(5, 5) != 0 {
+		t.Errorf("IntGridAt() did not read the row-major grid")
+	}
+	if tiles := grid.Tiles(); len(tiles) != 1 || tiles[0].ID != 0 {
+		t.Errorf("Tiles() = %v, expected the auto-layer tile", tiles)
+	}
+
+	ground := level.Layer("Ground")
+	if tiles := ground.Tiles(); len(tiles) != 1 || tiles[0].ID != 5 || tiles[0].Flip != 1 || ground.Tileset != project.Tileset(1) {
+		t.Errorf("Ground layer = %+v, expected one flipped tile from the Dungeon tileset", ground)
+	}
+
+	spawns := level.Layer("Entities").Entities
+	if len(spawns) != 1 {
+		t.Fatalf("len(Entities) = %d, expected 1", len(spawns))
+	}
+	spawn := spawns[0]
+	if spawn.Identifier != "PlayerSpawn" || spawn.Position[0] != 24 || spawn.Position[1] != 32 || spawn.Width != 16 {
+		t.Errorf("entity = %+v, expected the player spawn at (24, 32)", spawn.Entity)
+	}
+	if len(spawn.Properties) != 1 || spawn.Properties[0].Identifier != "team" || spawn.Properties[0].Value != "red" {
+		t.Errorf("Properties = %v, expected team = red", spawn.Properties)
+	}
+	if spawn.TileRect == nil || spawn.TileRect.Tileset != project.Tileset(1) {
+		t.Errorf("TileRect = %+v, expected it linked to the Dungeon tileset", spawn.TileRect)
+	}
+}
+
+func TestLoad_ExternalLevels(t *testing.T) {
+	dir := t.TempDir()
+	project := `{"jsonVersion": "1.5.3", "externalLevels": true, "defs": {"layers": [{"__type": "IntGrid", "identifier": "Collisions", "uid": 1}]},
+		"levels": [{"identifier": "Level_0", "iid": "l0", "externalRelPath": "sample/Level_0.ldtkl", "layerInstances": null}]}`
+	level := `{"identifier": "Level_0", "iid": "l0", "pxWid": 32, "pxHei": 16, "layerInstances": [
+		{"__identifier": "Collisions", "__type": "IntGrid", "__cWid": 2, "__cHei": 1, "__gridSize": 16, "layerDefUid": 1, "intGridCsv": [1, 0]}]}`
+	if err := os.MkdirAll(filepath.Join(dir, "sample"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sample.ldtk"), []byte(project), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sample", "Level_0.ldtkl"), []byte(level), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(filepath.Join(dir, "sample.ldtk"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if layer := p.Levels[0].Layer("Collisions"); layer == nil || layer.IntGridAt(0, 0) != 1 {
+		t.Errorf("external level layers were not loaded: %+v", p.Levels[0])
+	}
+
+	parsed, err := Parse([]byte(project))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Levels[0].Layers != nil {
+		t.Errorf("Parse() loaded external level layers without a directory")
+	}
+}
+
+func TestParse_MalformedFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		message string
+	}{
+		{
+			name:    "Broken JSON",
+			data:    "{\n\"jsonVersion\": \"1.5.3\",\n\"levels\": [}",
+			message: "line 3",
+		},
+		{
+			name:    "Wrong field type",
+			data:    `{"jsonVersion": "1.5.3", "levels": [{"identifier": "Level_0", "pxWid": "wide"}]}`,
+			message: "pxWid",
+		},
+		{
+			name:    "Not a project",
+			data:    `{"levels": []}`,
+			message: "missing jsonVersion",
+		},
+		{
+			name: "Unknown layer definition",
+			data: `{"jsonVersion": "1.5.3", "levels": [{"identifier": "Level_0", "layerInstances": [
+				{"__identifier": "Walls", "__type": "IntGrid", "layerDefUid": 99}]}]}`,
+			message: "unknown layer definition uid 99",
+		},
+		{
+			name:    "Unknown tileset",
+			data:    `{"jsonVersion": "1.5.3", "defs": {"layers": [{"__type": "Tiles", "identifier": "Ground", "uid": 1, "tilesetDefUid": 7}]}}`,
+			message: "unknown tileset uid 7",
+		},
+		{
+			name:    "Unsupported layer type",
+			data:    `{"jsonVersion": "1.5.3", "defs": {"layers": [{"__type": "Paths", "identifier": "Roads", "uid": 1}]}}`,
+			message: `unknown type "Paths"`,
+		},
+		{
+			name: "Truncated IntGrid",
+			data: `{"jsonVersion": "1.5.3", "defs": {"layers": [{"__type": "IntGrid", "identifier": "Walls", "uid": 1}]},
+				"levels": [{"identifier": "Level_0", "layerInstances": [
+				{"__identifier": "Walls", "__type": "IntGrid", "__cWid": 2, "__cHei": 2, "layerDefUid": 1, "intGridCsv": [1, 0, 1]}]}]}`,
+			message: "3 IntGrid values, expected 2x2",
+		},
+		{
+			name: "Entity without identifier",
+			data: `{"jsonVersion": "1.5.3", "defs": {"layers": [{"__type": "Entities", "identifier": "Entities", "uid": 1}]},
+				"levels": [{"identifier": "Level_0", "layerInstances": [
+				{"__identifier": "Entities", "__type": "Entities", "layerDefUid": 1, "entityInstances": [{"width": 16}]}]}]}`,
+			message: "without an identifier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			var loaderErr *MapLoaderError
+			if !errors.As(err, &loaderErr) {
+				t.Fatalf("Parse() error = %v, expected a *MapLoaderError", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Parse() error = %q, expected it to mention %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "missing.ldtk"))
+	var loaderErr *MapLoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Errorf("Load() error = %v, expected a *MapLoaderError", err)
+	}
+}
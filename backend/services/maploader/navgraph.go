@@ -0,0 +1,129 @@
+package maploader
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/pathfinding"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/spatial"
+)
+
+// DefaultNavNodeIdentifier is the LDtk entity placed as a nav node when the
+// config does not name one.
+const DefaultNavNodeIdentifier = "NavNode"
+
+// NavGraphConfig holds the settings of BuildWaypointGraph. Zero values are
+// replaced with defaults.
+type NavGraphConfig struct {
+	NodeIdentifier string                   // Entity identifier of nav nodes, defaults to NavNode
+	CollisionLayer string                   // IntGrid layer links are checked against, defaults to Collisions
+	ColliderLayers map[int]collider.Bitmask // Layer bits per IntGrid value, see StaticColliderConfig.Layers
+	WallMask       collider.Bitmask         // Layers a link may not cross, defaults to walls
+	AgentRadius    float64                  // Links are swept with a circle of this radius
+}
+
+// navNodeFields are the fields of a nav node entity: an EntityRef array
+// called "links" and an optional Bool "oneWay" making its links one-way.
+type navNodeFields struct {
+	Links  []entities.EntityRef `json:"links"`
+	OneWay bool                 `json:"oneWay"`
+}
+
+// BuildWaypointGraph builds a waypoint graph from the nav node entities of
+// every level. Each node becomes a waypoint keyed by its IID at its world
+// position, and each entry of its links field becomes a link. Links may join
+// nodes of different levels.
+//
+// Every link is swept against the static colliders of the levels, so a link
+// passing through a wall is reported rather than letting agents walk through
+// it. All broken links are reported in a single MapLoaderError.
+func BuildWaypointGraph(project *Project, config NavGraphConfig) (*pathfinding.WaypointGraph, error) {
+	if config.NodeIdentifier == "" {
+		config.NodeIdentifier = DefaultNavNodeIdentifier
+	}
+	if config.CollisionLayer == "" {
+		config.CollisionLayer = DefaultCollisionLayer
+	}
+	if config.WallMask == 0 {
+		config.WallMask.SetBit(collider.LayerWall)
+	}
+
+	graph := pathfinding.NewWaypointGraph()
+	walls := spatial.NewSpatialHash(0)
+	links := make(map[string]navNodeFields)
+	order := make([]string, 0)
+
+	for _, level := range project.Levels {
+		if layer := level.Layer(config.CollisionLayer); layer != nil && layer.Type == LayerIntGrid {
+			colliders, err := layer.StaticColliders(level, config.ColliderLayers)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range colliders {
+				if err := walls.Insert(c); err != nil {
+					return nil, NewMapLoaderError("cannot index walls of level " + level.Identifier + ": " + err.Error())
+				}
+			}
+		}
+		for _, layer := range level.Layers {
+			if layer.Type != LayerEntities {
+				continue
+			}
+			for _, instance := range layer.Entities {
+				if instance.Identifier != config.NodeIdentifier {
+					continue
+				}
+				ctx := &SpawnContext{Project: project, Level: level, Layer: layer, Instance: instance}
+				if _, err := graph.AddNode(instance.IID, ctx.WorldPosition()); err != nil {
+					return nil, NewMapLoaderError("level " + level.Identifier + ": " + err.Error())
+				}
+				var fields navNodeFields
+				if err := ctx.DecodeFields(&fields); err != nil {
+					return nil, NewMapLoaderError(fmt.Sprintf("nav node %s in level %s: %v", instance.IID, level.Identifier, err))
+				}
+				links[instance.IID] = fields
+				order = append(order, instance.IID)
+			}
+		}
+	}
+
+	problems := make([]string, 0)
+	filter := spatial.QueryFilter{Mask: config.WallMask}
+	for _, from := range order {
+		fields := links[from]
+		for _, ref := range fields.Links {
+			a, _ := graph.Node(from)
+			b, exists := graph.Node(ref.EntityIID)
+			if !exists {
+				problems = append(problems, fmt.Sprintf("nav node %s links to %s, which is not a nav node", from, ref.EntityIID))
+				continue
+			}
+			if hit, blocked := sweep(walls, a.Position, b.Position, config.AgentRadius, filter); blocked {
+				problems = append(problems, fmt.Sprintf("link %s -> %s passes through %s at (%.0f, %.0f)",
+					from, ref.EntityIID, hit.EntityID, hit.Point.X, hit.Point.Y))
+				continue
+			}
+			if err := graph.Link(from, ref.EntityIID, fields.OneWay); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, NewMapLoaderError("invalid nav graph: " + strings.Join(problems, "; "))
+	}
+	return graph, nil
+}
+
+// sweep casts a ray, or a circle of the given radius, from a to b.
+func sweep(walls spatial.BroadPhase, a, b geometry.Point, radius float64, filter spatial.QueryFilter) (spatial.RaycastHit, bool) {
+	direction := geometry.Vector2{X: b.X - a.X, Y: b.Y - a.Y}
+	distance := math.Hypot(direction.X, direction.Y)
+	if radius > 0 {
+		return spatial.CircleCast(walls, a, direction, distance, radius, filter)
+	}
+	return spatial.Raycast(walls, a, direction, distance, filter)
+}
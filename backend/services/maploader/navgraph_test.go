@@ -0,0 +1,122 @@
+package maploader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/pathfinding"
+)
+
+// navProject returns a 64x32 level with a wall in cell (1, 0) and nav nodes
+// in the corners: a at (8, 8), b at (56, 8), c at (8, 24) and d at (56, 24).
+func navProject(t *testing.T, links map[string][]string) *Project {
+	t.Helper()
+
+	positions := map[string][2]int{"a": {8, 8}, "b": {56, 8}, "c": {8, 24}, "d": {56, 24}}
+	nodes := make([]string, 0, len(positions))
+	for _, id := range []string{"a", "b", "c", "d"} {
+		refs := make([]string, 0)
+		for _, to := range links[id] {
+			refs = append(refs, fmt.Sprintf(`{"entityIid": "%s", "layerIid": "entities", "levelIid": "l0", "worldIid": "w"}`, to))
+		}
+		nodes = append(nodes, fmt.Sprintf(`{"__identifier": "NavNode", "iid": "%s", "px": [%d, %d], "width": 4, "height": 4,
+			"fieldInstances": [{"__identifier": "links", "__type": "Array<EntityRef>", "__value": [%s]}]}`,
+			id, positions[id][0], positions[id][1], strings.Join(refs, ", ")))
+	}
+
+	project, err := Parse([]byte(`{"jsonVersion": "1.5.3", "defs": {"layers": [
+		{"__type": "Entities", "identifier": "Entities", "uid": 1},
+		{"__type": "IntGrid", "identifier": "Collisions", "uid": 2}]},
+		"levels": [{"identifier": "Level_0", "iid": "l0", "pxWid": 64, "pxHei": 32, "layerInstances": [
+			{"__identifier": "Entities", "__type": "Entities", "layerDefUid": 1, "entityInstances": [` + strings.Join(nodes, ", ") + `]},
+			{"__identifier": "Collisions", "__type": "IntGrid", "__cWid": 4, "__cHei": 2, "__gridSize": 16, "layerDefUid": 2,
+			 "intGridCsv": [0, 1, 0, 0, 0, 0, 0, 0]}]}]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return project
+}
+
+func TestBuildWaypointGraph(t *testing.T) {
+	project := navProject(t, map[string][]string{"a": {"c"}, "c": {"d"}, "d": {"b"}})
+
+	graph, err := BuildWaypointGraph(project, NavGraphConfig{})
+	if err != nil {
+		t.Fatalf("BuildWaypointGraph() error = %v", err)
+	}
+	if graph.Len() != 4 {
+		t.Fatalf("Len() = %d, expected 4", graph.Len())
+	}
+	if d, _ := graph.Node("d"); d.Position != (geometry.Point{X: 56, Y: 24}) {
+		t.Errorf("d.Position = %v, expected the entity's world position", d.Position)
+	}
+
+	route, err := graph.ShortestPath("a", "b")
+	if err != nil {
+		t.Fatalf("ShortestPath() error = %v", err)
+	}
+	ids := make([]string, len(route))
+	for i, w := range route {
+		ids[i] = w.ID
+	}
+	if fmt.Sprint(ids) != "[a c d b]" {
+		t.Errorf("route = %v, expected to go around the wall", ids)
+	}
+
+	// Links are two-way by default.
+	if _, err := graph.ShortestPath("b", "a"); err != nil {
+		t.Errorf("ShortestPath(b, a) error = %v", err)
+	}
+
+	if nearest, ok := graph.NearestNode(geometry.Point{X: 50, Y: 4}, nil); !ok || nearest.ID != "b" {
+		t.Errorf("NearestNode() = %v, expected b", nearest)
+	}
+
+	path, err := graph.FindPath(geometry.Point{X: 2, Y: 6}, geometry.Point{X: 60, Y: 4}, nil)
+	if err != nil || len(path) != 6 {
+		t.Errorf("FindPath() = %v, %v, expected start, four waypoints and goal", path, err)
+	}
+}
+
+func TestBuildWaypointGraph_RejectsBrokenLinks(t *testing.T) {
+	project := navProject(t, map[string][]string{"a": {"b", "ghost"}, "c": {"d"}})
+
+	_, err := BuildWaypointGraph(project, NavGraphConfig{})
+	var loaderErr *MapLoaderError
+	if !errors.As(err, &loaderErr) {
+		t.Fatalf("BuildWaypointGraph() error = %v, expected a *MapLoaderError", err)
+	}
+	for _, message := range []string{"link a -> b passes through Level_0/Collisions#1,0", "links to ghost"} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("error = %q, expected it to mention %q", err, message)
+		}
+	}
+}
+
+func TestBuildWaypointGraph_AgentRadius(t *testing.T) {
+	// c -> d runs 8px below the wall's bottom edge.
+	project := navProject(t, map[string][]string{"c": {"d"}})
+
+	if _, err := BuildWaypointGraph(project, NavGraphConfig{AgentRadius: 4}); err != nil {
+		t.Errorf("small agent: error = %v", err)
+	}
+	if _, err := BuildWaypointGraph(project, NavGraphConfig{AgentRadius: 10}); err == nil {
+		t.Errorf("large agent: expected the link to clip the wall")
+	}
+}
+
+func TestWaypointGraph_NoRoute(t *testing.T) {
+	graph, err := BuildWaypointGraph(navProject(t, map[string][]string{"a": {"c"}}), NavGraphConfig{})
+	if err != nil {
+		t.Fatalf("BuildWaypointGraph() error = %v", err)
+	}
+	if _, err := graph.ShortestPath("a", "d"); !errors.Is(err, pathfinding.ErrNoPath) {
+		t.Errorf("ShortestPath(a, d) error = %v, expected ErrNoPath", err)
+	}
+	if _, err := graph.ShortestPath("a", "ghost"); !errors.Is(err, pathfinding.ErrUnknownNode) {
+		t.Errorf("ShortestPath(a, ghost) error = %v, expected ErrUnknownNode", err)
+	}
+}
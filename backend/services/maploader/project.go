@@ -0,0 +1,259 @@
+package maploader
+
+import (
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+)
+
+// LayerType is the kind of an LDtk layer, as found in its __type field.
+type LayerType string
+
+// Layer types supported by the loader.
+const (
+	LayerIntGrid   LayerType = "IntGrid"
+	LayerEntities  LayerType = "Entities"
+	LayerTiles     LayerType = "Tiles"
+	LayerAutoLayer LayerType = "AutoLayer"
+)
+
+// Project is a parsed LDtk project. Tilesets, layers and entities reference
+// their definitions directly, so the raw uids rarely have to be looked up.
+type Project struct {
+	Path            string `json:"-"` // File the project was loaded from, empty when parsed from memory
+	IID             string `json:"iid"`
+	JSONVersion     string `json:"jsonVersion"`
+	WorldLayout     string `json:"worldLayout"` // Free, GridVania, LinearHorizontal or LinearVertical
+	WorldGridWidth  int    `json:"worldGridWidth"`
+	WorldGridHeight int    `json:"worldGridHeight"`
+	DefaultGridSize int    `json:"defaultGridSize"`
+	BgColor         string `json:"bgColor"`
+	ExternalLevels  bool   `json:"externalLevels"` // Levels are stored in separate .ldtkl files
+
+	Defs   Definitions `json:"defs"`
+	Levels []*Level    `json:"levels"`
+}
+
+// Definitions holds the project-wide layer, entity, tileset and enum
+// definitions that level data refers to by uid.
+type Definitions struct {
+	Layers        []*LayerDef         `json:"layers"`
+	Entities      []*EntityDef        `json:"entities"`
+	Tilesets      []*entities.Tileset `json:"-"` // Decoded separately to fill CustomData and Enums
+	Enums         []*EnumDef          `json:"enums"`
+	ExternalEnums []*EnumDef          `json:"externalEnums"`
+	LevelFields   []*FieldDef         `json:"levelFields"`
+}
+
+// LayerDef describes a layer shared by every level of the project.
+type LayerDef struct {
+	Identifier    string            `json:"identifier"`
+	UID           int               `json:"uid"`
+	Type          LayerType         `json:"__type"`
+	GridSize      int               `json:"gridSize"`
+	Opacity       float64           `json:"displayOpacity"`
+	OffsetX       int               `json:"pxOffsetX"`
+	OffsetY       int               `json:"pxOffsetY"`
+	TilesetDefUID *int              `json:"tilesetDefUid"`
+	IntGridValues []*IntGridValue   `json:"intGridValues"`
+	AutoSourceUID *int              `json:"autoSourceLayerDefUid"` // IntGrid layer an AutoLayer reads its rules from
+	RequiredTags  []string          `json:"requiredTags"`
+	ExcludedTags  []string          `json:"excludedTags"`
+	TilesetDef    *entities.Tileset `json:"-"`
+}
+
+// IntGridValue names one value of an IntGrid layer, e.g. 1 = "wall".
+type IntGridValue struct {
+	Value      int    `json:"value"`
+	Identifier string `json:"identifier"`
+	Color      string `json:"color"`
+}
+
+// EntityDef describes an entity type that levels can place instances of.
+type EntityDef struct {
+	Identifier string      `json:"identifier"`
+	UID        int         `json:"uid"`
+	Width      int         `json:"width"`
+	Height     int         `json:"height"`
+	PivotX     float64     `json:"pivotX"`
+	PivotY     float64     `json:"pivotY"`
+	Tags       []string    `json:"tags"`
+	Fields     []*FieldDef `json:"fieldDefs"`
+}
+
+// FieldDef describes a custom field of an entity or a level.
+type FieldDef struct {
+	Identifier string `json:"identifier"`
+	UID        int    `json:"uid"`
+	Type       string `json:"__type"` // e.g. Int, Float, String, Bool, Point, LocalEnum.Door, Array<Int>
+	CanBeNull  bool   `json:"canBeNull"`
+	IsArray    bool   `json:"isArray"`
+}
+
+// EnumDef is a project enum. Tilesets can tag their tiles with its values.
+type EnumDef struct {
+	Identifier      string       `json:"identifier"`
+	UID             int          `json:"uid"`
+	Values          []*EnumValue `json:"values"`
+	IconTilesetUID  *int         `json:"iconTilesetUid"`
+	ExternalRelPath string       `json:"externalRelPath"`
+	Tags            []string     `json:"tags"`
+}
+
+// EnumValue is one value of an EnumDef.
+type EnumValue struct {
+	ID       string             `json:"id"`
+	Color    int                `json:"color"`
+	TileRect *entities.TileRect `json:"tileRect"`
+}
+
+// Level is one level of the project. Its position in the world is given in
+// pixels by WorldX and WorldY.
+type Level struct {
+	Identifier      string               `json:"identifier"`
+	IID             string               `json:"iid"`
+	UID             int                  `json:"uid"`
+	WorldX          int                  `json:"worldX"`
+	WorldY          int                  `json:"worldY"`
+	WorldDepth      int                  `json:"worldDepth"`
+	Width           int                  `json:"pxWid"`
+	Height          int                  `json:"pxHei"`
+	BgColor         string               `json:"__bgColor"`
+	Properties      []*entities.Property `json:"fieldInstances"`
+	Layers          []*Layer             `json:"layerInstances"` // Topmost layer first
+	ExternalRelPath string               `json:"externalRelPath"`
+	Neighbours      []*Neighbour         `json:"__neighbours"`
+}
+
+// Neighbour links a level to an adjacent one. Dir is n, s, w, e, <, > or o
+// (overlap), as written by LDtk.
+type Neighbour struct {
+	LevelIID string `json:"levelIid"`
+	Dir      string `json:"dir"`
+}
+
+// Layer is a layer instance inside a level. Depending on Type only some of
+// IntGrid, AutoLayerTiles, GridTiles and Entities are filled.
+type Layer struct {
+	Identifier  string    `json:"__identifier"`
+	IID         string    `json:"iid"`
+	Type        LayerType `json:"__type"`
+	GridWidth   int       `json:"__cWid"` // Width in cells
+	GridHeight  int       `json:"__cHei"` // Height in cells
+	GridSize    int       `json:"__gridSize"`
+	Opacity     float64   `json:"__opacity"`
+	OffsetX     int       `json:"__pxTotalOffsetX"`
+	OffsetY     int       `json:"__pxTotalOffsetY"`
+	Visible     bool      `json:"visible"`
+	LevelUID    int       `json:"levelId"`
+	LayerDefUID int       `json:"layerDefUid"`
+	TilesetUID  *int      `json:"__tilesetDefUid"`
+	TilesetPath string    `json:"__tilesetRelPath"`
+
+	IntGrid        []int                     `json:"intGridCsv"` // Row-major, GridWidth*GridHeight values, 0 is empty
+	AutoLayerTiles []*Tile                   `json:"autoLayerTiles"`
+	GridTiles      []*Tile                   `json:"gridTiles"`
+	Entities       []*entities.TileMapEntity `json:"entityInstances"`
+
+	Def     *LayerDef         `json:"-"`
+	Tileset *entities.Tileset `json:"-"`
+}
+
+// Tile is a tile drawn by a Tiles or AutoLayer layer.
+type Tile struct {
+	ID       int     `json:"t"`   // Tile index inside the tileset
+	Position []int   `json:"px"`  // Pixel position in the layer (x, y)
+	Src      []int   `json:"src"` // Pixel position in the tileset image (x, y)
+	Flip     int     `json:"f"`   // Bit 0: flipped on X, bit 1: flipped on Y
+	Alpha    float64 `json:"a"`
+}
+
+// IntGridAt returns the IntGrid value of the cell at (cx, cy), 0 for empty
+// cells and cells outside the layer.
+func (l *Layer) IntGridAt(cx, cy int) int {
+	if cx < 0 || cy < 0 || cx >= l.GridWidth || cy >= l.GridHeight || len(l.IntGrid) == 0 {
+		return 0
+	}
+	return l.IntGrid[cy*l.GridWidth+cx]
+}
+
+// Tiles returns the tiles drawn by the layer, whatever its type.
+func (l *Layer) Tiles() []*Tile {
+	if l.Type == LayerTiles {
+		return l.GridTiles
+	}
+	return l.AutoLayerTiles
+}
+
+// Grid returns the coordinate grid of the layer placed in level, so that its
+// pixels and cells can be converted to world positions. A nil level places
+// the layer at the world origin.
+func (l *Layer) Grid(level *Level) coordinate.Grid {
+	x, y := l.OffsetX, l.OffsetY
+	if level != nil {
+		x, y = x+level.WorldX, y+level.WorldY
+	}
+	return coordinate.NewGrid(float64(l.GridSize), float64(x), float64(y))
+}
+
+// Layer returns the layer instance with the given identifier, or nil.
+func (l *Level) Layer(identifier string) *Layer {
+	for _, layer := range l.Layers {
+		if layer.Identifier == identifier {
+			return layer
+		}
+	}
+	return nil
+}
+
+// Level returns the level with the given identifier, or nil.
+func (p *Project) Level(identifier string) *Level {
+	for _, level := range p.Levels {
+		if level.Identifier == identifier {
+			return level
+		}
+	}
+	return nil
+}
+
+// LevelByIID returns the level with the given iid, or nil.
+func (p *Project) LevelByIID(iid string) *Level {
+	for _, level := range p.Levels {
+		if level.IID == iid {
+			return level
+		}
+	}
+	return nil
+}
+
+// Tileset returns the tileset definition with the given uid, or nil.
+func (p *Project) Tileset(uid int) *entities.Tileset {
+	for _, tileset := range p.Defs.Tilesets {
+		if tileset.ID == uid {
+			return tileset
+		}
+	}
+	return nil
+}
+
+// LayerDef returns the layer definition with the given uid, or nil.
+func (p *Project) LayerDef(uid int) *LayerDef {
+	for _, def := range p.Defs.Layers {
+		if def.UID == uid {
+			return def
+		}
+	}
+	return nil
+}
+
+// Enum returns the enum definition with the given identifier, including
+// external enums, or nil.
+func (p *Project) Enum(identifier string) *EnumDef {
+	for _, enums := range [][]*EnumDef{p.Defs.Enums, p.Defs.ExternalEnums} {
+		for _, def := range enums {
+			if def.Identifier == identifier {
+				return def
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package maploader
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/prefab"
+)
+
+// EntityFactory turns one LDtk entity instance into an engine entity. A
+// factory may return a nil entity to skip the instance.
+type EntityFactory func(ctx *SpawnContext) (*entities.Entity, error)
+
+// SpawnContext describes the entity instance being spawned and offers helpers
+// to build its components in world space.
+type SpawnContext struct {
+	Project  *Project
+	Level    *Level
+	Layer    *Layer
+	Instance *entities.TileMapEntity
+}
+
+// BodyConfig is the physics configuration an entity instance can override
+// through its fields. Fields use the json names below, e.g. a Float field
+// called "mass".
+type BodyConfig struct {
+	Mass          float64 `json:"mass"`
+	Friction      float64 `json:"friction"`
+	Restitution   float64 `json:"restitution"`
+	LinearDamping float64 `json:"linearDamping"`
+	IsTrigger     bool    `json:"isTrigger"`
+}
+
+// EntityRegistry maps LDtk entity identifiers to the factories that spawn
+// them. It is safe for concurrent use.
+type EntityRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]EntityFactory
+}
+
+// NewEntityRegistry creates a registry without factories.
+func NewEntityRegistry() *EntityRegistry {
+	return &EntityRegistry{factories: make(map[string]EntityFactory)}
+}
+
+// Register sets the factory used for instances of the given identifier,
+// replacing any previous one.
+func (r *EntityRegistry) Register(identifier string, factory EntityFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[identifier] = factory
+}
+
+// Factory returns the factory registered for identifier.
+func (r *EntityRegistry) Factory(identifier string) (EntityFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, exists := r.factories[identifier]
+	return factory, exists
+}
+
+// Spawn runs the factory registered for the context's instance. It returns a
+// MapLoaderError when no factory is registered.
+func (r *EntityRegistry) Spawn(ctx *SpawnContext) (*entities.Entity, error) {
+	factory, exists := r.Factory(ctx.Instance.Identifier)
+	if !exists {
+		return nil, NewMapLoaderError(fmt.Sprintf("no factory registered for entity %q", ctx.Instance.Identifier))
+	}
+	e, err := factory(ctx)
+	if err != nil {
+		return nil, NewMapLoaderError(fmt.Sprintf("cannot spawn %q (%s) in level %q: %v",
+			ctx.Instance.Identifier, ctx.Instance.IID, ctx.Level.Identifier, err))
+	}
+	return e, nil
+}
+
+// SpawnLevel spawns every entity instance of level's Entities layers.
+// Instances without a registered factory are skipped, so editor-only markers
+// do not have to be registered.
+func (r *EntityRegistry) SpawnLevel(project *Project, level *Level) ([]*entities.Entity, error) {
+	spawned := make([]*entities.Entity, 0)
+	for _, layer := range level.Layers {
+		if layer.Type != LayerEntities {
+			continue
+		}
+		for _, instance := range layer.Entities {
+			if _, exists := r.Factory(instance.Identifier); !exists {
+				continue
+			}
+			e, err := r.Spawn(&SpawnContext{Project: project, Level: level, Layer: layer, Instance: instance})
+			if err != nil {
+				return nil, err
+			}
+			if e != nil {
+				spawned = append(spawned, e)
+			}
+		}
+	}
+	return spawned, nil
+}
+
+// ---------------------------------------------------------------------------
+// Context helpers
+// ---------------------------------------------------------------------------
+
+// WorldPosition returns the instance's pivot point in world space.
+func (c *SpawnContext) WorldPosition() geometry.Point {
+	var grid coordinate.Grid
+	switch {
+	case c.Layer != nil:
+		grid = c.Layer.Grid(c.Level)
+	case c.Level != nil:
+		grid.Origin = geometry.Vector2{X: float64(c.Level.WorldX), Y: float64(c.Level.WorldY)}
+	}
+	return grid.PixelToWorld(coordinate.Pixel{X: float64(c.Instance.Position[0]), Y: float64(c.Instance.Position[1])})
+}
+
+// pivotOffset returns the offset from the pivot to the center of the
+// instance's box.
+func (c *SpawnContext) pivotOffset() geometry.Vector2 {
+	px, py := 0.5, 0.5
+	if len(c.Instance.Pivot) == 2 {
+		px, py = float64(c.Instance.Pivot[0]), float64(c.Instance.Pivot[1])
+	}
+	return geometry.Vector2{
+		X: (0.5 - px) * float64(c.Instance.Width),
+		Y: (0.5 - py) * float64(c.Instance.Height),
+	}
+}
+
+// Bounds returns the world-space box the instance covers in the editor.
+func (c *SpawnContext) Bounds() geometry.Bounds {
+	p, offset := c.WorldPosition(), c.pivotOffset()
+	cx, cy := p.X+offset.X, p.Y+offset.Y
+	w, h := float64(c.Instance.Width)/2, float64(c.Instance.Height)/2
+	return geometry.Bounds{MinX: cx - w, MinY: cy - h, MaxX: cx + w, MaxY: cy + h}
+}
+
+// DecodeFields decodes the instance's field instances into target, see
+// entities.DecodeProperties.
+func (c *SpawnContext) DecodeFields(target any) error {
+	return c.Instance.DecodeProperties(target)
+}
+
+// NewTransform returns a transform placed at the instance's pivot.
+func (c *SpawnContext) NewTransform() *components.TransformComponent {
+	p := c.WorldPosition()
+	return components.NewTransformComponent(geometry.NewPoint(p.X, p.Y), 0, 1)
+}
+
+// NewBody returns a physics component whose collider covers the instance's
+// box, on the given layer and keyed by the instance IID. Mass, friction,
+// restitution, damping and the trigger flag can be overridden through the
+// instance's fields, see BodyConfig.
+func (c *SpawnContext) NewBody(pt components.PhysicBodyType, layer uint32, transform *components.TransformComponent) (*components.PhysicComponent, error) {
+	config := BodyConfig{Mass: 1, Friction: 0.5, IsTrigger: layer == collider.LayerTrigger}
+	if err := c.DecodeFields(&config); err != nil {
+		return nil, err
+	}
+
+	col := &collider.Collider{
+		ShapeList: []geometry.Shape{&geometry.Rectangle{
+			Center: geometry.Point(c.pivotOffset()),
+			Width:  float64(c.Instance.Width),
+			Height: float64(c.Instance.Height),
+		}},
+		IsTrigger: config.IsTrigger,
+		Enabled:   true,
+		Tag:       c.Instance.Identifier,
+		EntityID:  c.Instance.IID,
+		UserData:  c.Instance,
+	}
+	col.LayerMask.SetBit(layer)
+	col.MatchMask.SetLayers(collider.LayerPlayer, collider.LayerEnemy, collider.LayerProjectile, collider.LayerWall)
+
+	body, err := components.NewPhysicComponent(pt, col, transform)
+	if err != nil {
+		return nil, err
+	}
+	body.Mass = config.Mass
+	body.Friction = config.Friction
+	body.Restitution = config.Restitution
+	body.LinearDamping = config.LinearDamping
+	body.SyncCollider()
+	return body, nil
+}
+
+// NewEntity returns an entity carrying the instance's identity and the given
+// components. Data points back to the LDtk instance.
+func (c *SpawnContext) NewEntity(comps ...components.Component) *entities.Entity {
+	p := c.WorldPosition()
+	tags := make([]string, len(c.Instance.Tags))
+	copy(tags, c.Instance.Tags)
+	return &entities.Entity{
+		Identifier: c.Instance.Identifier,
+		IID:        c.Instance.IID,
+		Position:   []int{int(p.X), int(p.Y)},
+		Tags:       tags,
+		Components: comps,
+		Data:       c.Instance,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Stock factories
+// ---------------------------------------------------------------------------
+
+// MarkerFactory spawns entities that only carry a transform, e.g. player
+// spawn points.
+func MarkerFactory(ctx *SpawnContext) (*entities.Entity, error) {
+	return ctx.NewEntity(ctx.NewTransform()), nil
+}
+
+// BodyFactory returns a factory spawning entities with a transform and a body
+// of the given type on the given layer: static for chests and doors,
+// kinematic or rigid for NPCs, LayerTrigger for portals and pressure plates.
+func BodyFactory(pt components.PhysicBodyType, layer uint32) EntityFactory {
+	return func(ctx *SpawnContext) (*entities.Entity, error) {
+		transform := ctx.NewTransform()
+		body, err := ctx.NewBody(pt, layer, transform)
+		if err != nil {
+			return nil, err
+		}
+		return ctx.NewEntity(transform, body), nil
+	}
+}
+
+// PrefabFactory returns a factory spawning the named prefab at the
+// instance's pivot, keyed by the instance IID. Data points back to the LDtk
+// instance.
+func PrefabFactory(library *prefab.Library, name string) EntityFactory {
+	return func(ctx *SpawnContext) (*entities.Entity, error) {
+		e, err := library.Spawn(name, ctx.Instance.IID, ctx.WorldPosition())
+		if err != nil {
+			return nil, err
+		}
+		e.Data = ctx.Instance
+		return e, nil
+	}
+}
@@ -0,0 +1,173 @@
+package maploader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/prefab"
+)
+
+func TestEntityRegistry_SpawnLevel(t *testing.T) {
+	project := loadSample(t)
+	level := project.Level("Level_0")
+	level.WorldX, level.WorldY = 100, 200
+
+	registry := NewEntityRegistry()
+	if spawned, err := registry.SpawnLevel(project, level); err != nil || len(spawned) != 0 {
+		t.Fatalf("SpawnLevel() = %v, %v, expected unregistered instances to be skipped", spawned, err)
+	}
+
+	registry.Register("PlayerSpawn", MarkerFactory)
+	spawned, err := registry.SpawnLevel(project, level)
+	if err != nil {
+		t.Fatalf("SpawnLevel() error = %v", err)
+	}
+	if len(spawned) != 1 {
+		t.Fatalf("len(spawned) = %d, expected 1", len(spawned))
+	}
+
+	spawn := spawned[0]
+	if spawn.Identifier != "PlayerSpawn" || spawn.IID != "e0000000-0000-11ef-9e3c-000000000001" || len(spawn.Tags) != 1 {
+		t.Errorf("entity = %+v, expected the spawn point's identity", spawn)
+	}
+	transform, ok := spawn.Components[0].(*components.TransformComponent)
+	if !ok || transform.Position.X != 124 || transform.Position.Y != 232 {
+		t.Errorf("Components[0] = %+v, expected a transform at the world pivot (124, 232)", spawn.Components[0])
+	}
+}
+
+func TestBodyFactory(t *testing.T) {
+	project := loadSample(t)
+	level := project.Level("Level_0")
+	layer := level.Layer("Entities")
+	instance := layer.Entities[0]
+	instance.Properties = append(instance.Properties,
+		&entities.Property{Identifier: "mass", Type: "Float", Value: 4.0},
+		&entities.Property{Identifier: "friction", Type: "Float", Value: nil},
+	)
+
+	ctx := &SpawnContext{Project: project, Level: level, Layer: layer, Instance: instance}
+	e, err := BodyFactory(components.StaticBody, collider.LayerWall)(ctx)
+	if err != nil {
+		t.Fatalf("BodyFactory() error = %v", err)
+	}
+
+	body, ok := e.Components[1].(*components.PhysicComponent)
+	if !ok {
+		t.Fatalf("Components[1] = %T, expected *components.PhysicComponent", e.Components[1])
+	}
+	if !body.IsStatic() || body.Mass != 4 || body.Friction != 0.5 {
+		t.Errorf("body = %+v, expected a static body with mass 4 and the default friction", body)
+	}
+
+	c := body.GetCollider()
+	if !c.LayerMask.IsSet(collider.LayerWall) || c.EntityID != instance.IID || c.IsTrigger {
+		t.Errorf("collider = %+v, expected a solid wall keyed by the instance IID", c)
+	}
+	// The pivot sits at the bottom center of the 16x16 box at (24, 32).
+	if bounds := c.GetBounds(); bounds != ctx.Bounds() || bounds.MinX != 16 || bounds.MinY != 16 || bounds.MaxY != 32 {
+		t.Errorf("GetBounds() = %+v, expected the editor box %+v", bounds, ctx.Bounds())
+	}
+
+	trigger, err := BodyFactory(components.StaticBody, collider.LayerTrigger)(ctx)
+	if err != nil {
+		t.Fatalf("BodyFactory() error = %v", err)
+	}
+	if !trigger.Components[1].(*components.PhysicComponent).GetCollider().IsTrigger {
+		t.Errorf("expected bodies on LayerTrigger to be triggers")
+	}
+}
+
+func TestPrefabFactory(t *testing.T) {
+	project := loadSample(t)
+	level := project.Level("Level_0")
+	layer := level.Layer("Entities")
+	instance := layer.Entities[0]
+
+	library := prefab.NewLibrary()
+	_, err := library.Add(&prefab.Definition{
+		Name:       "chest",
+		Collider:   &prefab.ColliderDef{Layer: "wall", Shapes: []prefab.ShapeDef{{Type: prefab.ShapeRectangle, Width: 16, Height: 16}}},
+		Components: []prefab.ComponentDef{{Type: prefab.KindTransform}, {Type: prefab.KindPhysic, Params: prefab.Params{"physic_type": 0}}},
+	})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	ctx := &SpawnContext{Project: project, Level: level, Layer: layer, Instance: instance}
+	e, err := PrefabFactory(library, "chest")(ctx)
+	if err != nil {
+		t.Fatalf("PrefabFactory() error = %v", err)
+	}
+	if e.Identifier != "chest" || e.IID != instance.IID || e.Data != instance {
+		t.Errorf("entity = %+v, expected a chest keyed by the instance", e)
+	}
+	body := e.Components[1].(*components.PhysicComponent)
+	if p := ctx.WorldPosition(); body.GetTransform().Position.X != p.X || body.GetCollider().Transform.Y != p.Y {
+		t.Errorf("body is not at the instance's pivot %+v", p)
+	}
+	if _, err := PrefabFactory(library, "mimic")(ctx); !errors.Is(err, prefab.ErrUnknownPrefab) {
+		t.Errorf("PrefabFactory() error = %v, expected ErrUnknownPrefab", err)
+	}
+}
+
+func TestEntityRegistry_FactoryErrors(t *testing.T) {
+	project := loadSample(t)
+	level := project.Level("Level_0")
+	layer := level.Layer("Entities")
+	ctx := &SpawnContext{Project: project, Level: level, Layer: layer, Instance: layer.Entities[0]}
+
+	registry := NewEntityRegistry()
+	var loaderErr *MapLoaderError
+	if _, err := registry.Spawn(ctx); !errors.As(err, &loaderErr) {
+		t.Errorf("Spawn() error = %v, expected a *MapLoaderError for an unregistered identifier", err)
+	}
+
+	registry.Register("PlayerSpawn", func(ctx *SpawnContext) (*entities.Entity, error) {
+		var config struct {
+			Team int `json:"team"` // a String field, cannot decode
+		}
+		return nil, ctx.DecodeFields(&config)
+	})
+	if _, err := registry.SpawnLevel(project, level); !errors.As(err, &loaderErr) {
+		t.Errorf("SpawnLevel() error = %v, expected the factory error to be reported", err)
+	}
+}
+
+func TestDecodeProperties_TypedFields(t *testing.T) {
+	instance := &entities.TileMapEntity{
+		Entity: &entities.Entity{Identifier: "Portal"},
+		Properties: []*entities.Property{
+			{Identifier: "target", Type: "EntityRef", Value: map[string]any{"entityIid": "exit", "levelIid": "level-1"}},
+			{Identifier: "arrival", Type: "Point", Value: map[string]any{"cx": 3.0, "cy": 7.0}},
+			{Identifier: "key", Type: "LocalEnum.Key", Value: "Gold"},
+			{Identifier: "cooldowns", Type: "Array<Int>", Value: []any{1.0, 2.0}},
+			{Identifier: "locked", Type: "Bool", Value: true},
+		},
+	}
+
+	config := struct {
+		Target    entities.EntityRef `json:"target"`
+		Arrival   entities.GridPoint `json:"arrival"`
+		Key       string             `json:"key"`
+		Cooldowns []int              `json:"cooldowns"`
+		Locked    bool               `json:"locked"`
+		Charges   int                `json:"charges"`
+	}{Charges: 3}
+
+	if err := instance.DecodeProperties(&config); err != nil {
+		t.Fatalf("DecodeProperties() error = %v", err)
+	}
+	if config.Target.EntityIID != "exit" || config.Target.LevelIID != "level-1" {
+		t.Errorf("Target = %+v, expected the exit in level-1", config.Target)
+	}
+	if config.Arrival != (entities.GridPoint{CX: 3, CY: 7}) || config.Key != "Gold" || !config.Locked {
+		t.Errorf("config = %+v, expected typed point, enum and bool values", config)
+	}
+	if len(config.Cooldowns) != 2 || config.Cooldowns[1] != 2 || config.Charges != 3 {
+		t.Errorf("config = %+v, expected the array decoded and missing fields left alone", config)
+	}
+}
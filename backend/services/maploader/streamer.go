@@ -0,0 +1,522 @@
+package maploader
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/coordinate"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+// DefaultCollisionLayer is the IntGrid layer streamed as static colliders when
+// the config does not name one.
+const DefaultCollisionLayer = "Collisions"
+
+// StreamingConfig holds the settings of a LevelStreamer. Zero values are
+// replaced with defaults.
+type StreamingConfig struct {
+	LoadMargin     float64                  // Levels closer than this to a player are activated
+	CollisionLayer string                   // IntGrid layer turned into static colliders
+	ColliderLayers map[int]collider.Bitmask // Layer bits per IntGrid value, see StaticColliderConfig.Layers
+	FocusMask      collider.Bitmask         // Colliders on these layers pull levels in, defaults to players
+}
+
+// LevelStreamer keeps the levels of a multi-level project in a World around
+// the players. A level containing a player (or within LoadMargin of one) is
+// active: its static colliders and spawned entities are in the world. The
+// neighbours of active levels are loaded: only their static colliders are
+// resident, so bodies crossing a level border never fall through a wall.
+// Every other level is unloaded.
+//
+// Install it with World.SetLevelStreamer, or call Update directly. Transitions
+// are emitted on the world's LevelEvents.
+type LevelStreamer struct {
+	project  *Project
+	registry *EntityRegistry
+	config   StreamingConfig
+	levels   map[string]*streamedLevel // by level IID
+
+	reloadMu   sync.Mutex
+	reload     *Project      // queued by Reload, swapped in by the next Update
+	reloadDone []func(error) // told the outcome of the queued reload
+}
+
+// streamedLevel tracks what a level put into the world. Both maps go from
+// entity IID to a signature of the content it was built from, so a reload
+// can tell which entities changed.
+type streamedLevel struct {
+	level     *Level
+	colliders map[string]string // static bodies
+	spawned   map[string]string // entities spawned by the registry
+	active    bool
+}
+
+// NewLevelStreamer creates a streamer for project. registry may be nil, in
+// which case levels only contribute static colliders.
+func NewLevelStreamer(project *Project, registry *EntityRegistry, config StreamingConfig) *LevelStreamer {
+	if config.CollisionLayer == "" {
+		config.CollisionLayer = DefaultCollisionLayer
+	}
+	if config.FocusMask == 0 {
+		config.FocusMask.SetBit(collider.LayerPlayer)
+	}
+	if registry == nil {
+		registry = NewEntityRegistry()
+	}
+	return &LevelStreamer{
+		project:  project,
+		registry: registry,
+		config:   config,
+		levels:   make(map[string]*streamedLevel),
+	}
+}
+
+// LevelBounds returns the world-space area covered by level.
+func LevelBounds(level *Level) geometry.Bounds {
+	grid := coordinate.NewGrid(0, float64(level.WorldX), float64(level.WorldY))
+	topLeft := grid.PixelToWorld(coordinate.Pixel{})
+	bottomRight := grid.PixelToWorld(coordinate.Pixel{X: float64(level.Width), Y: float64(level.Height)})
+	return geometry.Bounds{MinX: topLeft.X, MinY: topLeft.Y, MaxX: bottomRight.X, MaxY: bottomRight.Y}
+}
+
+// IsLoaded reports whether the static colliders of the level are resident.
+func (s *LevelStreamer) IsLoaded(levelIID string) bool {
+	_, loaded := s.levels[levelIID]
+	return loaded
+}
+
+// IsActive reports whether the entities of the level are spawned.
+func (s *LevelStreamer) IsActive(levelIID string) bool {
+	l, loaded := s.levels[levelIID]
+	return loaded && l.active
+}
+
+// Stream implements engine.LevelStreamer using the positions of the world's
+// bodies on FocusMask layers.
+func (s *LevelStreamer) Stream(w *engine.World, tick uint64) error {
+	focus := make([]geometry.Point, 0)
+	for _, e := range w.Entities() {
+		for _, c := range e.Components {
+			p, ok := c.(*components.PhysicComponent)
+			if !ok || !p.IsActive() || p.GetCollider() == nil || p.GetTransform() == nil {
+				continue
+			}
+			if p.GetCollider().LayerMask.HasAny(s.config.FocusMask) {
+				focus = append(focus, *p.GetTransform().Position)
+			}
+		}
+	}
+	return s.Update(w, focus, tick)
+}
+
+// Project returns the project currently streamed.
+func (s *LevelStreamer) Project() *Project {
+	return s.project
+}
+
+// Reload queues a new version of the project. The next Update swaps it in:
+// loaded levels are diffed against their new version and only the static
+// colliders and spawned entities that changed are replaced. Entities the
+// streamer did not create, such as connected players, are left untouched.
+// Reload is safe to call from any goroutine.
+//
+// A reload that cannot be applied, for example because a collision grid is
+// truncated, is dropped as a whole: Update returns its error and the world
+// keeps running the previous version.
+func (s *LevelStreamer) Reload(project *Project) {
+	s.queueReload(project, nil)
+}
+
+// queueReload queues project like Reload and calls done with the outcome once
+// an Update applied or rejected it. A reload replaced before it was applied
+// reports the outcome of the one replacing it.
+func (s *LevelStreamer) queueReload(project *Project, done func(error)) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	s.reload = project
+	if done != nil {
+		s.reloadDone = append(s.reloadDone, done)
+	}
+}
+
+// Update brings the world in line with the given player positions: a queued
+// reload is applied first, then levels that are no longer needed leave and
+// the new ones are loaded and activated. Levels are processed in IID order so
+// runs are deterministic.
+func (s *LevelStreamer) Update(w *engine.World, focus []geometry.Point, tick uint64) error {
+	if err := s.applyReload(w, tick); err != nil {
+		return err
+	}
+
+	active := make(map[string]bool)
+	for _, level := range s.project.Levels {
+		bounds := LevelBounds(level)
+		bounds.MinX, bounds.MinY = bounds.MinX-s.config.LoadMargin, bounds.MinY-s.config.LoadMargin
+		bounds.MaxX, bounds.MaxY = bounds.MaxX+s.config.LoadMargin, bounds.MaxY+s.config.LoadMargin
+		for _, p := range focus {
+			if p.X >= bounds.MinX && p.X <= bounds.MaxX && p.Y >= bounds.MinY && p.Y <= bounds.MaxY {
+				active[level.IID] = true
+				break
+			}
+		}
+	}
+	resident := make(map[string]bool, len(active))
+	for iid := range active {
+		resident[iid] = true
+		for _, n := range s.project.LevelByIID(iid).Neighbours {
+			if s.project.LevelByIID(n.LevelIID) != nil {
+				resident[n.LevelIID] = true
+			}
+		}
+	}
+
+	for _, iid := range sortedKeys(s.levels) {
+		l := s.levels[iid]
+		if l.active && !active[iid] {
+			s.deactivate(w, l, tick)
+		}
+		if !resident[iid] {
+			s.unload(w, l, tick)
+		}
+	}
+	for _, iid := range sortedKeys(resident) {
+		l, loaded := s.levels[iid]
+		if !loaded {
+			var err error
+			if l, err = s.load(w, s.project.LevelByIID(iid), tick); err != nil {
+				return err
+			}
+		}
+		if active[iid] && !l.active {
+			if err := s.activate(w, l, tick); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *LevelStreamer) load(w *engine.World, level *Level, tick uint64) (*streamedLevel, error) {
+	l := &streamedLevel{level: level, colliders: make(map[string]string)}
+	bodies, err := s.staticBodies(level)
+	if err != nil {
+		return nil, err
+	}
+	staged, err := stageColliders(bodies)
+	if err == nil {
+		err = addStaged(w, l.colliders, staged)
+	}
+	if err != nil {
+		removeAll(w, l.colliders)
+		return nil, NewMapLoaderError("cannot load level " + level.Identifier + ": " + err.Error())
+	}
+	s.levels[level.IID] = l
+	s.emit(w, eventsystem.LevelLoad, level, tick)
+	return l, nil
+}
+
+func (s *LevelStreamer) activate(w *engine.World, l *streamedLevel, tick uint64) error {
+	staged, err := s.stageSpawns(s.project, l.level, l.level.entityInstances())
+	if err != nil {
+		return err
+	}
+	l.spawned = make(map[string]string)
+	if err := addStaged(w, l.spawned, staged); err != nil {
+		removeAll(w, l.spawned)
+		l.spawned = nil
+		return NewMapLoaderError("cannot activate level " + l.level.Identifier + ": " + err.Error())
+	}
+	l.active = true
+	s.emit(w, eventsystem.LevelActivate, l.level, tick)
+	return nil
+}
+
+func (s *LevelStreamer) deactivate(w *engine.World, l *streamedLevel, tick uint64) {
+	removeAll(w, l.spawned)
+	l.spawned = nil
+	l.active = false
+	s.emit(w, eventsystem.LevelDeactivate, l.level, tick)
+}
+
+// staticBodies returns the static colliders of level keyed by EntityID.
+func (s *LevelStreamer) staticBodies(level *Level) (map[string]*collider.Collider, error) {
+	bodies := make(map[string]*collider.Collider)
+	if layer := level.Layer(s.config.CollisionLayer); layer != nil && layer.Type == LayerIntGrid {
+		colliders, err := layer.StaticColliders(level, s.config.ColliderLayers)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range colliders {
+			bodies[c.EntityID] = c
+		}
+	}
+	return bodies, nil
+}
+
+// stagedEntity is an entity built for a level but not added to the world yet,
+// with the signature recorded for it once it is.
+type stagedEntity struct {
+	entity    *entities.Entity
+	signature string
+}
+
+// stageColliders builds a static body per collider, in EntityID order.
+func stageColliders(bodies map[string]*collider.Collider) ([]stagedEntity, error) {
+	staged := make([]stagedEntity, 0, len(bodies))
+	for _, iid := range sortedKeys(bodies) {
+		body, err := NewStaticBody(bodies[iid])
+		if err != nil {
+			return nil, err
+		}
+		staged = append(staged, stagedEntity{entity: body, signature: colliderSignature(bodies[iid])})
+	}
+	return staged, nil
+}
+
+// stageSpawns runs the registry on the given instances of level, in IID
+// order. Instances without a factory and factories returning nil are skipped.
+func (s *LevelStreamer) stageSpawns(project *Project, level *Level, instances map[string]*spawnInstance) ([]stagedEntity, error) {
+	staged := make([]stagedEntity, 0, len(instances))
+	for _, iid := range sortedKeys(instances) {
+		instance := instances[iid]
+		if _, exists := s.registry.Factory(instance.entity.Identifier); !exists {
+			continue
+		}
+		e, err := s.registry.Spawn(&SpawnContext{Project: project, Level: level, Layer: instance.layer, Instance: instance.entity})
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			staged = append(staged, stagedEntity{entity: e, signature: instance.signature()})
+		}
+	}
+	return staged, nil
+}
+
+// addStaged adds the staged entities to the world and records their
+// signatures in into.
+func addStaged(w *engine.World, into map[string]string, staged []stagedEntity) error {
+	for _, st := range staged {
+		if err := w.AddEntity(st.entity); err != nil {
+			return err
+		}
+		into[st.entity.IID] = st.signature
+	}
+	return nil
+}
+
+// levelReload is the change a reload makes to one loaded level.
+type levelReload struct {
+	l         *streamedLevel
+	level     *Level   // New version of the level, nil when it was deleted
+	removed   []string // Colliders and spawned entities that changed or left
+	colliders []stagedEntity
+	spawned   []stagedEntity
+}
+
+// applyReload swaps in the project queued by Reload. Every loaded level is
+// rebuilt from the new project before the world is touched, so a reload that
+// fails (a broken collision grid, a failing factory, an IID already taken)
+// leaves the old map running in full. Otherwise the changes of all levels are
+// applied in one pass: everything that changed is removed before anything is
+// added back, so an entity keeping its IID is replaced rather than duplicated.
+func (s *LevelStreamer) applyReload(w *engine.World, tick uint64) error {
+	s.reloadMu.Lock()
+	project, done := s.reload, s.reloadDone
+	s.reload, s.reloadDone = nil, nil
+	s.reloadMu.Unlock()
+	if project == nil {
+		return nil
+	}
+
+	err := s.swapProject(w, project, tick)
+	for _, fn := range done {
+		fn(err)
+	}
+	return err
+}
+
+func (s *LevelStreamer) swapProject(w *engine.World, project *Project, tick uint64) error {
+	reloads, err := s.stageReload(w, project)
+	if err != nil {
+		return err
+	}
+
+	s.project = project
+	for _, r := range reloads {
+		for _, iid := range r.removed {
+			w.RemoveEntity(iid)
+			delete(r.l.colliders, iid)
+			delete(r.l.spawned, iid)
+		}
+	}
+	for _, r := range reloads {
+		if r.level == nil {
+			if r.l.active {
+				s.deactivate(w, r.l, tick)
+			}
+			s.unload(w, r.l, tick)
+			continue
+		}
+		r.l.level = r.level
+		if err := addStaged(w, r.l.colliders, r.colliders); err != nil {
+			return NewMapLoaderError("cannot reload level " + r.level.Identifier + ": " + err.Error())
+		}
+		if err := addStaged(w, r.l.spawned, r.spawned); err != nil {
+			return NewMapLoaderError("cannot reload level " + r.level.Identifier + ": " + err.Error())
+		}
+		s.emit(w, eventsystem.LevelReload, r.level, tick)
+	}
+	return nil
+}
+
+// stageReload diffs every loaded level against its version in project and
+// builds the entities to add, in level IID order. It leaves the streamer and
+// the world alone.
+func (s *LevelStreamer) stageReload(w *engine.World, project *Project) ([]*levelReload, error) {
+	reloads := make([]*levelReload, 0, len(s.levels))
+	removed := make(map[string]bool)
+	for _, iid := range sortedKeys(s.levels) {
+		l := s.levels[iid]
+		r := &levelReload{l: l, level: project.LevelByIID(iid)}
+		reloads = append(reloads, r)
+		if r.level == nil {
+			r.removed = append(sortedKeys(l.colliders), sortedKeys(l.spawned)...)
+			for _, iid := range r.removed {
+				removed[iid] = true
+			}
+			continue
+		}
+
+		bodies, err := s.staticBodies(r.level)
+		if err != nil {
+			return nil, err
+		}
+		added := make(map[string]*collider.Collider)
+		for iid, c := range bodies {
+			if signature, exists := l.colliders[iid]; !exists || signature != colliderSignature(c) {
+				added[iid] = c
+			}
+		}
+		for iid := range l.colliders {
+			if _, kept := bodies[iid]; !kept || added[iid] != nil {
+				r.removed = append(r.removed, iid)
+			}
+		}
+		if r.colliders, err = stageColliders(added); err != nil {
+			return nil, NewMapLoaderError("cannot reload level " + r.level.Identifier + ": " + err.Error())
+		}
+
+		if l.active {
+			instances := r.level.entityInstances()
+			spawned := make(map[string]*spawnInstance)
+			for iid, instance := range instances {
+				if signature, exists := l.spawned[iid]; !exists || signature != instance.signature() {
+					spawned[iid] = instance
+				}
+			}
+			for iid := range l.spawned {
+				if _, kept := instances[iid]; !kept || spawned[iid] != nil {
+					r.removed = append(r.removed, iid)
+				}
+			}
+			if r.spawned, err = s.stageSpawns(project, r.level, spawned); err != nil {
+				return nil, err
+			}
+		}
+		for _, iid := range r.removed {
+			removed[iid] = true
+		}
+	}
+
+	// The staged entities must not clash with each other or with entities
+	// the reload keeps, such as connected players.
+	taken := make(map[string]bool)
+	for _, r := range reloads {
+		for _, staged := range [][]stagedEntity{r.colliders, r.spawned} {
+			for _, st := range staged {
+				iid := st.entity.IID
+				if _, exists := w.GetEntity(iid); (exists && !removed[iid]) || taken[iid] {
+					return nil, NewMapLoaderError("cannot reload level " + r.level.Identifier + ": entity '" + iid + "' already exists in world")
+				}
+				taken[iid] = true
+			}
+		}
+	}
+	return reloads, nil
+}
+
+// spawnInstance is an entity instance together with the layer it sits on.
+type spawnInstance struct {
+	layer  *Layer
+	entity *entities.TileMapEntity
+}
+
+// signature covers everything a factory may read from the instance.
+func (i *spawnInstance) signature() string {
+	data, err := json.Marshal(struct {
+		Entity  *entities.TileMapEntity
+		OffsetX int
+		OffsetY int
+	}{i.entity, i.layer.OffsetX, i.layer.OffsetY})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// entityInstances returns the instances of every Entities layer keyed by IID.
+func (l *Level) entityInstances() map[string]*spawnInstance {
+	instances := make(map[string]*spawnInstance)
+	for _, layer := range l.Layers {
+		if layer.Type != LayerEntities {
+			continue
+		}
+		for _, e := range layer.Entities {
+			instances[e.IID] = &spawnInstance{layer: layer, entity: e}
+		}
+	}
+	return instances
+}
+
+func colliderSignature(c *collider.Collider) string {
+	b := c.GetBounds()
+	return fmt.Sprintf("%v %v %v %v %d", b.MinX, b.MinY, b.MaxX, b.MaxY, c.LayerMask)
+}
+
+func (s *LevelStreamer) unload(w *engine.World, l *streamedLevel, tick uint64) {
+	removeAll(w, l.colliders)
+	delete(s.levels, l.level.IID)
+	s.emit(w, eventsystem.LevelUnload, l.level, tick)
+}
+
+func (s *LevelStreamer) emit(w *engine.World, eventType string, level *Level, tick uint64) {
+	w.LevelEvents.Emit(*eventsystem.NewEvent(eventsystem.LevelData{
+		LevelIID:   level.IID,
+		Identifier: level.Identifier,
+		Bounds:     LevelBounds(level),
+		Tick:       tick,
+	}, eventType))
+}
+
+func removeAll(w *engine.World, iids map[string]string) {
+	for iid := range iids {
+		w.RemoveEntity(iid)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package maploader
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/components"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/entities"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/collider"
+	"github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/physics/geometry"
+	eventsystem "github.com/Akif-jpg/MyHobieMMORPGGame/services/engine/systems/event_system"
+)
+
+// corridor returns a project of three 32x16 levels A, B and C side by side.
+// Each level has a wall in its left cell and an NPC in its right cell.
+func corridor(t *testing.T) *Project {
+	t.Helper()
+
+	names := []string{"A", "B", "C"}
+	levels := make([]string, len(names))
+	for i, name := range names {
+		neighbours := make([]string, 0, 2)
+		if i > 0 {
+			neighbours = append(neighbours, fmt.Sprintf(`{"levelIid": "%s", "dir": "w"}`, names[i-1]))
+		}
+		if i < len(names)-1 {
+			neighbours = append(neighbours, fmt.Sprintf(`{"levelIid": "%s", "dir": "e"}`, names[i+1]))
+		}
+		levels[i] = fmt.Sprintf(`{"identifier": "%[1]s", "iid": "%[1]s", "worldX": %[2]d, "pxWid": 32, "pxHei": 16,
+			"__neighbours": [%[3]s], "layerInstances": [
+			{"__identifier": "Entities", "__type": "Entities", "layerDefUid": 1, "entityInstances": [
+				{"__identifier": "NPC", "iid": "npc-%[1]s", "px": [24, 8], "__pivot": [0.5, 0.5], "width": 8, "height": 8}]},
+			{"__identifier": "Collisions", "__type": "IntGrid", "__cWid": 2, "__cHei": 1, "__gridSize": 16, "layerDefUid": 2, "intGridCsv": [1, 0]}]}`,
+			name, i*32, strings.Join(neighbours, ", "))
+	}
+
+	project, err := Parse([]byte(`{"jsonVersion": "1.5.3", "worldLayout": "Free", "defs": {"layers": [
+		{"__type": "Entities", "identifier": "Entities", "uid": 1},
+		{"__type": "IntGrid", "identifier": "Collisions", "uid": 2}]},
+		"levels": [` + strings.Join(levels, ", ") + `]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return project
+}
+
+func TestLevelStreamer_FollowsPlayer(t *testing.T) {
+	project := corridor(t)
+	registry := NewEntityRegistry()
+	registry.Register("NPC", BodyFactory(components.KinematicBody, collider.LayerEnemy))
+
+	w := engine.NewWorld(engine.WorldConfig{})
+	events := make([]string, 0)
+	for _, eventType := range []string{eventsystem.LevelLoad, eventsystem.LevelActivate, eventsystem.LevelDeactivate, eventsystem.LevelUnload} {
+		w.LevelEvents.Register(eventType, func(e eventsystem.Event[eventsystem.LevelData]) {
+			events = append(events, e.EventType+":"+e.Data.Identifier)
+		})
+	}
+
+	// A trigger, so that walking through walls does not push it around.
+	player := &collider.Collider{ShapeList: []geometry.Shape{&geometry.Circle{Radius: 2}}, Enabled: true, IsTrigger: true}
+	player.LayerMask.SetBit(collider.LayerPlayer)
+	transform := components.NewTransformComponent(geometry.NewPoint(10, 8), 0, 1)
+	body, err := components.NewPhysicComponent(components.KinematicBody, player, transform)
+	if err != nil {
+		t.Fatalf("NewPhysicComponent() error = %v", err)
+	}
+	if err := w.AddEntity(&entities.Entity{IID: "player", Components: []components.Component{transform, body}}); err != nil {
+		t.Fatalf("AddEntity() error = %v", err)
+	}
+
+	streamer := NewLevelStreamer(project, registry, StreamingConfig{})
+	w.SetLevelStreamer(streamer)
+
+	steps := []struct {
+		x        float64
+		active   string
+		loaded   []string
+		entities int // player + static walls + NPCs
+		events   []string
+	}{
+		{
+			x: 10, active: "A", loaded: []string{"A", "B"}, entities: 1 + 2 + 1,
+			events: []string{"level_load:A", "level_activate:A", "level_load:B"},
+		},
+		{
+			x: 40, active: "B", loaded: []string{"A", "B", "C"}, entities: 1 + 3 + 1,
+			events: []string{"level_deactivate:A", "level_activate:B", "level_load:C"},
+		},
+		{
+			x: 80, active: "C", loaded: []string{"B", "C"}, entities: 1 + 2 + 1,
+			events: []string{"level_unload:A", "level_deactivate:B", "level_activate:C"},
+		},
+	}
+
+	for _, step := range steps {
+		events = events[:0]
+		transform.SetPosition(step.x, 8)
+		w.Tick()
+
+		for _, level := range project.Levels {
+			if streamer.IsActive(level.IID) != (level.IID == step.active) {
+				t.Errorf("x=%v: IsActive(%s) = %v", step.x, level.IID, streamer.IsActive(level.IID))
+			}
+			loaded := false
+			for _, iid := range step.loaded {
+				loaded = loaded || iid == level.IID
+			}
+			if streamer.IsLoaded(level.IID) != loaded {
+				t.Errorf("x=%v: IsLoaded(%s) = %v, expected %v", step.x, level.IID, streamer.IsLoaded(level.IID), loaded)
+			}
+		}
+		if got := len(w.Entities()); got != step.entities {
+			t.Errorf("x=%v: len(Entities()) = %d, expected %d", step.x, got, step.entities)
+		}
+		if fmt.Sprint(events) != fmt.Sprint(step.events) {
+			t.Errorf("x=%v: events = %v, expected %v", step.x, events, step.events)
+		}
+		if _, ok := w.GetEntity("npc-" + step.active); !ok {
+			t.Errorf("x=%v: NPC of level %s was not spawned", step.x, step.active)
+		}
+	}
+}
+
+func TestLevelStreamer_LoadMargin(t *testing.T) {
+	project := corridor(t)
+	w := engine.NewWorld(engine.WorldConfig{})
+	streamer := NewLevelStreamer(project, nil, StreamingConfig{LoadMargin: 8})
+
+	// 4px left of B: A is active on its own and B through the margin.
+	if err := streamer.Update(w, []geometry.Point{{X: 28, Y: 8}}, 1); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !streamer.IsActive("A") || !streamer.IsActive("B") || !streamer.IsLoaded("C") {
+		t.Errorf("expected A and B active and C loaded")
+	}
+
+	if err := streamer.Update(w, nil, 2); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(w.Entities()) != 0 {
+		t.Errorf("len(Entities()) = %d, expected every level to unload without players", len(w.Entities()))
+	}
+}
@@ -0,0 +1,147 @@
+package maploader
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is how often a Watcher polls when none is given.
+const DefaultWatchInterval = 500 * time.Millisecond
+
+// Watcher polls a map directory for changed LDtk files. Polling keeps the
+// loader free of platform specific notification APIs and copes with editors
+// that save by replacing the file.
+type Watcher struct {
+	dir      string
+	interval time.Duration
+
+	mu      sync.Mutex
+	files   map[string]fileStamp // by path, nil until the first poll
+	running bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher creates a watcher for the .ldtk and .ldtkl files below dir.
+func NewWatcher(dir string, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	return &Watcher{dir: dir, interval: interval}
+}
+
+// Poll scans the directory and returns the files that were created, changed
+// or removed since the previous poll, sorted by path. The first poll only
+// records the current state and reports nothing.
+func (w *Watcher) Poll() ([]string, error) {
+	files := make(map[string]fileStamp)
+	err := filepath.WalkDir(w.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isMapFile(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // removed while walking
+		}
+		if err != nil {
+			return err
+		}
+		files[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, NewMapLoaderError("cannot watch " + w.dir + ": " + err.Error())
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	previous := w.files
+	w.files = files
+	if previous == nil {
+		return nil, nil
+	}
+
+	changed := make([]string, 0)
+	for path, stamp := range files {
+		if old, exists := previous[path]; !exists || old != stamp {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, exists := files[path]; !exists {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// Start polls in its own goroutine and calls onChange with every non-empty
+// set of changed files. Poll errors are passed on with a nil file list.
+func (w *Watcher) Start(onChange func(changed []string, err error)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.running {
+		return errors.New("watcher is already running")
+	}
+	w.running = true
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+
+	go w.run(onChange, w.stop, w.done)
+	return nil
+}
+
+// Stop halts the polling goroutine and waits for it to exit. Calling Stop on
+// a watcher that is not running is a no-op.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	stop, done := w.stop, w.done
+	w.mu.Unlock()
+
+	close(stop)
+	<-done
+}
+
+func (w *Watcher) run(onChange func(changed []string, err error), stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			changed, err := w.Poll()
+			if err != nil || len(changed) > 0 {
+				onChange(changed, err)
+			}
+		}
+	}
+}
+
+func isMapFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".ldtk" || ext == ".ldtkl"
+}